@@ -7,9 +7,15 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"path"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/vmware-labs/marketplace-cli/v2/internal"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
 	"github.com/vmware-labs/marketplace-cli/v2/pkg"
 )
@@ -19,12 +25,22 @@ var (
 	AttachProductVersion string
 	AttachCreateVersion  bool
 
-	AttachChartURL string
+	AttachBlueprintFile            string
+	AttachBlueprintSupportingFiles []string
+	AttachBlueprintImages          []string
+	AttachBlueprintVRAVersion      string
+	AttachBlueprintPrerequisites   []string
 
-	AttachContainerImage        string
-	AttachContainerImageFile    string
-	AttachContainerImageTag     string
-	AttachContainerImageTagType string
+	AttachChartURL            string
+	AttachChartSkipValidation bool
+
+	AttachContainerImage                 string
+	AttachContainerImageFile             string
+	AttachContainerImageTag              string
+	AttachContainerImageTagType          string
+	AttachContainerImages                []string
+	AttachContainerImagesFile            string
+	AttachContainerImageSkipVerification bool
 
 	AttachMetaFile        string
 	AttachMetaFileVersion string
@@ -33,39 +49,80 @@ var (
 
 	AttachVMFile string
 
+	AttachFileURL   string
+	AttachFileFetch bool
+
 	AttachInstructions string
 
 	AttachPCAFile string
+
+	AttachWait        bool
+	AttachWaitTimeout time.Duration
+	AttachReplace     bool
 )
 
 func init() {
 	rootCmd.AddCommand(AttachCmd)
-	AttachCmd.AddCommand(AttachChartCmd, AttachContainerImageCmd, AttachMetaFileCmd, AttachOtherCmd, AttachVMCmd)
+	AttachCmd.PersistentFlags().Int64("upload-part-size", internal.DefaultUploadPartSize/1024/1024, "Size in MiB of each part when uploading large files [$MKPCLI_UPLOAD_PART_SIZE]")
+	_ = viper.BindPFlag("marketplace.upload.part-size", AttachCmd.PersistentFlags().Lookup("upload-part-size"))
+	AttachCmd.PersistentFlags().Int("upload-concurrency", internal.DefaultUploadConcurrency, "Number of parts to upload at the same time when uploading large files [$MKPCLI_UPLOAD_CONCURRENCY]")
+	_ = viper.BindPFlag("marketplace.upload.concurrency", AttachCmd.PersistentFlags().Lookup("upload-concurrency"))
+	AttachCmd.AddCommand(AttachBlueprintCmd, AttachChartCmd, AttachContainerImageCmd, AttachMetaFileCmd, AttachOtherCmd, AttachVMCmd)
+
+	// Only other and virtual machine files are hashed with --hash-algo. Both commands share the one flag, so that it can be bound to viper once.
+	hashAlgorithmFlags := pflag.NewFlagSet("hash-algo", pflag.ContinueOnError)
+	hashAlgorithmFlags.String("hash-algo", pkg.DefaultHashAlgorithm, "Algorithm used to hash the uploaded file. One of "+models.HashAlgoSHA1+"|"+models.HashAlgoSHA256+". [$MKPCLI_HASH_ALGO]")
+	_ = viper.BindPFlag("marketplace.upload.hash-algorithm", hashAlgorithmFlags.Lookup("hash-algo"))
+	AttachOtherCmd.Flags().AddFlagSet(hashAlgorithmFlags)
+	AttachVMCmd.Flags().AddFlagSet(hashAlgorithmFlags)
+
+	AttachBlueprintCmd.Flags().StringVarP(&AttachProductSlug, "product", "p", "", "Product slug (required)")
+	_ = AttachBlueprintCmd.MarkFlagRequired("product")
+	AttachBlueprintCmd.Flags().StringVarP(&AttachProductVersion, "product-version", "v", "", "Product version (default to latest version)")
+	AttachBlueprintCmd.Flags().StringVar(&AttachBlueprintFile, "file", "", "Blueprint file to upload (required)")
+	_ = AttachBlueprintCmd.MarkFlagRequired("file")
+	AttachBlueprintCmd.Flags().StringArrayVar(&AttachBlueprintSupportingFiles, "supporting-file", []string{}, "Supporting file to upload with the blueprint (can be used multiple times)")
+	AttachBlueprintCmd.Flags().StringArrayVar(&AttachBlueprintImages, "image", []string{}, "Image to upload with the blueprint (can be used multiple times)")
+	AttachBlueprintCmd.Flags().StringVar(&AttachBlueprintVRAVersion, "vra-version", "", "vRealize Automation version the blueprint is for (required)")
+	_ = AttachBlueprintCmd.MarkFlagRequired("vra-version")
+	AttachBlueprintCmd.Flags().StringArrayVar(&AttachBlueprintPrerequisites, "prerequisite", []string{}, "Prerequisite for deploying the blueprint (can be used multiple times)")
+	AttachBlueprintCmd.Flags().StringVarP(&AttachInstructions, "instructions", "i", "", "Blueprint deployment instructions (required)")
+	_ = AttachBlueprintCmd.MarkFlagRequired("instructions")
+	AttachBlueprintCmd.Flags().BoolVar(&AttachCreateVersion, "create-version", false, "Create the product version, if it doesn't already exist")
+	AttachBlueprintCmd.Flags().BoolVar(&AttachWait, "wait", false, "Wait for the attached asset to be processed")
+	AttachBlueprintCmd.Flags().DurationVar(&AttachWaitTimeout, "wait-timeout", DefaultWaitTimeout, "How long to wait for the attached asset to be processed, with --wait")
 
 	AttachChartCmd.Flags().StringVarP(&AttachProductSlug, "product", "p", "", "Product slug (required)")
 	_ = AttachChartCmd.MarkFlagRequired("product")
 	AttachChartCmd.Flags().StringVarP(&AttachProductVersion, "product-version", "v", "", "Product version (default to latest version)")
-	AttachChartCmd.Flags().StringVarP(&AttachChartURL, "chart", "c", "", "Path to to chart, either local tgz or public URL (required)")
+	AttachChartCmd.Flags().StringVarP(&AttachChartURL, "chart", "c", "", "Path to to chart, either local tgz, public URL, or OCI reference (oci://registry/repository/chart:version) (required)")
 	_ = AttachChartCmd.MarkFlagRequired("chart")
 	AttachChartCmd.Flags().StringVar(&AttachInstructions, "instructions", "", "Chart deployment instructions (required)")
 	_ = AttachChartCmd.MarkFlagRequired("instructions")
 	AttachChartCmd.Flags().BoolVar(&AttachCreateVersion, "create-version", false, "Create the product version, if it doesn't already exist")
 	AttachChartCmd.Flags().StringVar(&AttachPCAFile, "pca-file", "", "Path to a PCA file to upload")
+	AttachChartCmd.Flags().BoolVar(&AttachWait, "wait", false, "Wait for the attached asset to be processed")
+	AttachChartCmd.Flags().DurationVar(&AttachWaitTimeout, "wait-timeout", DefaultWaitTimeout, "How long to wait for the attached asset to be processed, with --wait")
+	AttachChartCmd.Flags().BoolVar(&AttachReplace, "replace", false, "Replace the chart with the same name if it is already attached to the product version")
+	AttachChartCmd.Flags().BoolVar(&AttachChartSkipValidation, "skip-validation", false, "Attach the chart without linting and validating it first")
 
 	AttachContainerImageCmd.Flags().StringVarP(&AttachProductSlug, "product", "p", "", "Product slug (required)")
 	_ = AttachContainerImageCmd.MarkFlagRequired("product")
 	AttachContainerImageCmd.Flags().StringVarP(&AttachProductVersion, "product-version", "v", "", "Product version (default to latest version)")
-	AttachContainerImageCmd.Flags().StringVarP(&AttachContainerImage, "image-repository", "r", "", "Image repository (e.g. registry/repository/image) (required)")
-	_ = AttachContainerImageCmd.MarkFlagRequired("image-repository")
-	AttachContainerImageCmd.Flags().StringVarP(&AttachContainerImageFile, "file", "f", "", "Path to a local tar file to upload")
-	AttachContainerImageCmd.Flags().StringVar(&AttachContainerImageTag, "tag", "", "Image repository tag (required)")
-	_ = AttachContainerImageCmd.MarkFlagRequired("tag")
-	AttachContainerImageCmd.Flags().StringVar(&AttachContainerImageTagType, "tag-type", "", "Image repository tag type (fixed or floating) (required)")
-	_ = AttachContainerImageCmd.MarkFlagRequired("tag-type")
+	AttachContainerImageCmd.Flags().StringVarP(&AttachContainerImage, "image-repository", "r", "", "Image repository (e.g. registry/repository/image) (required, unless the image file only contains one image)")
+	AttachContainerImageCmd.Flags().StringVarP(&AttachContainerImageFile, "file", "f", "", "Path to a local docker-archive or OCI-layout tar file to upload")
+	AttachContainerImageCmd.Flags().StringVar(&AttachContainerImageTag, "tag", "", "Image repository tag (required, unless the image file only contains one image)")
+	AttachContainerImageCmd.Flags().StringVar(&AttachContainerImageTagType, "tag-type", "", "Image repository tag type (fixed or floating) (required, unless every image given with --image or --images-file has a tag type)")
+	AttachContainerImageCmd.Flags().StringArrayVar(&AttachContainerImages, "image", []string{}, "Image to attach, as repository:tag[:fixed|floating] (can be used multiple times)")
+	AttachContainerImageCmd.Flags().StringVar(&AttachContainerImagesFile, "images-file", "", "Path to a YAML file that lists the images to attach")
+	AttachContainerImageCmd.Flags().BoolVar(&AttachContainerImageSkipVerification, "skip-verification", false, "Attach registry images without checking that they exist in their registry")
 	AttachContainerImageCmd.Flags().StringVarP(&AttachInstructions, "instructions", "i", "", "Image deployment instructions (required)")
 	_ = AttachContainerImageCmd.MarkFlagRequired("instructions")
 	AttachContainerImageCmd.Flags().BoolVar(&AttachCreateVersion, "create-version", false, "Create the product version, if it doesn't already exist")
 	AttachContainerImageCmd.Flags().StringVar(&AttachPCAFile, "pca-file", "", "Path to a PCA file to upload")
+	AttachContainerImageCmd.Flags().BoolVar(&AttachWait, "wait", false, "Wait for the attached asset to be processed")
+	AttachContainerImageCmd.Flags().DurationVar(&AttachWaitTimeout, "wait-timeout", DefaultWaitTimeout, "How long to wait for the attached asset to be processed, with --wait")
+	AttachContainerImageCmd.Flags().BoolVar(&AttachReplace, "replace", false, "Replace the image tag if it is already attached to the product version")
 
 	AttachMetaFileCmd.Flags().StringVarP(&AttachProductSlug, "product", "p", "", "Product slug (required)")
 	_ = AttachMetaFileCmd.MarkFlagRequired("product")
@@ -74,22 +131,37 @@ func init() {
 	_ = AttachMetaFileCmd.MarkFlagRequired("metafile")
 	AttachMetaFileCmd.Flags().StringVar(&MetaFileType, "metafile-type", "", "Meta file version (required, one of "+strings.Join(metaFileTypesList(), ", ")+")")
 	AttachMetaFileCmd.Flags().StringVar(&AttachMetaFileVersion, "metafile-version", "", "Meta file type (default is the product version)")
+	AttachMetaFileCmd.Flags().BoolVar(&AttachWait, "wait", false, "Wait for the attached asset to be processed")
+	AttachMetaFileCmd.Flags().DurationVar(&AttachWaitTimeout, "wait-timeout", DefaultWaitTimeout, "How long to wait for the attached asset to be processed, with --wait")
+	AttachMetaFileCmd.Flags().BoolVar(&AttachReplace, "replace", false, "Replace the meta file with the same name if it is already attached to the product version")
 
 	AttachOtherCmd.Flags().StringVarP(&AttachProductSlug, "product", "p", "", "Product slug (required)")
 	_ = AttachOtherCmd.MarkFlagRequired("product")
 	AttachOtherCmd.Flags().StringVarP(&AttachProductVersion, "product-version", "v", "", "Product version (default to latest version)")
-	AttachOtherCmd.Flags().StringVar(&AttachOtherFile, "file", "", "File to upload (required)")
-	_ = AttachOtherCmd.MarkFlagRequired("file")
+	AttachOtherCmd.Flags().StringVar(&AttachOtherFile, "file", "", "File to upload (required, unless using --url)")
+	AttachOtherCmd.Flags().StringVar(&AttachFileURL, "url", "", "URL of an externally hosted file to attach, instead of uploading a file")
+	AttachOtherCmd.Flags().BoolVar(&AttachFileFetch, "fetch", false, "Fetch the file from --url to record its size and hash")
+	AttachOtherCmd.MarkFlagsMutuallyExclusive("file", "url")
+	AttachOtherCmd.MarkFlagsOneRequired("file", "url")
 	AttachOtherCmd.Flags().BoolVar(&AttachCreateVersion, "create-version", false, "Create the product version, if it doesn't already exist")
 	AttachOtherCmd.Flags().StringVar(&AttachPCAFile, "pca-file", "", "Path to a PCA file to upload")
+	AttachOtherCmd.Flags().BoolVar(&AttachWait, "wait", false, "Wait for the attached asset to be processed")
+	AttachOtherCmd.Flags().DurationVar(&AttachWaitTimeout, "wait-timeout", DefaultWaitTimeout, "How long to wait for the attached asset to be processed, with --wait")
+	AttachOtherCmd.Flags().BoolVar(&AttachReplace, "replace", false, "Replace the file with the same name if it is already attached to the product version")
 
 	AttachVMCmd.Flags().StringVarP(&AttachProductSlug, "product", "p", "", "Product slug (required)")
 	_ = AttachVMCmd.MarkFlagRequired("product")
 	AttachVMCmd.Flags().StringVarP(&AttachProductVersion, "product-version", "v", "", "Product version (default to latest version)")
-	AttachVMCmd.Flags().StringVar(&AttachVMFile, "file", "", "Virtual machine file to upload (required)")
-	_ = AttachVMCmd.MarkFlagRequired("file")
+	AttachVMCmd.Flags().StringVar(&AttachVMFile, "file", "", "Virtual machine file to upload (required, unless using --url)")
+	AttachVMCmd.Flags().StringVar(&AttachFileURL, "url", "", "URL of an externally hosted virtual machine file to attach, instead of uploading a file")
+	AttachVMCmd.Flags().BoolVar(&AttachFileFetch, "fetch", false, "Fetch the file from --url to record its size and hash")
+	AttachVMCmd.MarkFlagsMutuallyExclusive("file", "url")
+	AttachVMCmd.MarkFlagsOneRequired("file", "url")
 	AttachVMCmd.Flags().BoolVar(&AttachCreateVersion, "create-version", false, "Create the product version, if it doesn't already exist")
 	AttachVMCmd.Flags().StringVar(&AttachPCAFile, "pca-file", "", "Path to a PCA file to upload")
+	AttachVMCmd.Flags().BoolVar(&AttachWait, "wait", false, "Wait for the attached asset to be processed")
+	AttachVMCmd.Flags().DurationVar(&AttachWaitTimeout, "wait-timeout", DefaultWaitTimeout, "How long to wait for the attached asset to be processed, with --wait")
+	AttachVMCmd.Flags().BoolVar(&AttachReplace, "replace", false, "Replace the file with the same name if it is already attached to the product version")
 }
 
 var AttachCmd = &cobra.Command{
@@ -97,14 +169,55 @@ var AttachCmd = &cobra.Command{
 	Short:     "Attach assets to a product",
 	Long:      "Attach assets to a product in the VMware Marketplace",
 	Args:      cobra.OnlyValidArgs,
-	ValidArgs: []string{AttachChartCmd.Use, AttachContainerImageCmd.Use, AttachVMCmd.Use},
+	ValidArgs: []string{AttachBlueprintCmd.Use, AttachChartCmd.Use, AttachContainerImageCmd.Use, AttachVMCmd.Use},
+}
+
+var AttachBlueprintCmd = &cobra.Command{
+	Use:     "blueprint",
+	Short:   "Attach a vRealize Automation blueprint",
+	Long:    "Upload and attach a vRealize Automation blueprint, along with its supporting files and images, to a product in the VMware Marketplace",
+	Example: fmt.Sprintf("%s attach blueprint -p hyperspace-database-blueprint1 -v 1.2.3 --file hyperspace-db.zip --image diagram.png --vra-version 8.11 --prerequisite \"vRealize Automation 8.11 or later\" --instructions \"Import the blueprint...\"", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: GetRefreshToken,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		product, version, err := Marketplace.GetProductWithVersion(AttachProductSlug, AttachProductVersion)
+		if err != nil {
+			if errors.Is(err, &pkg.VersionDoesNotExistError{}) && AttachCreateVersion {
+				version = product.NewVersion(AttachProductVersion)
+			} else {
+				return err
+			}
+		}
+
+		updatedProduct, err := Marketplace.AttachBlueprint(&pkg.Blueprint{
+			File:          AttachBlueprintFile,
+			Files:         AttachBlueprintSupportingFiles,
+			Images:        AttachBlueprintImages,
+			VRAVersion:    AttachBlueprintVRAVersion,
+			Instructions:  AttachInstructions,
+			Prerequisites: AttachBlueprintPrerequisites,
+		}, product, version)
+		if err != nil {
+			return handleDryRun(err)
+		}
+
+		updatedProduct, err = waitForAttachedAssets(cmd, updatedProduct, version, pkg.AssetTypeBlueprint, filepath.Base(AttachBlueprintFile))
+		if err != nil {
+			return err
+		}
+
+		Output.PrintHeader(fmt.Sprintf("Blueprints for %s %s:", updatedProduct.DisplayName, version.Number))
+		return Output.RenderBlueprints(updatedProduct.GetBlueprintsForVersion(version.Number))
+	},
 }
 
 var AttachChartCmd = &cobra.Command{
 	Use:     "chart",
 	Short:   "Attach a chart",
 	Long:    "Attaches a Helm Chart to a product in the VMware Marketplace",
-	Example: fmt.Sprintf("%s attach chart -p hyperspace-database-chart1 -v 1.2.3 --chart hyperspace-db-1.2.3.tgz --instructions \"helm install...\"", AppName),
+	Example: fmt.Sprintf("%s attach chart -p hyperspace-database-chart1 -v 1.2.3 --chart hyperspace-db-1.2.3.tgz --instructions \"helm install...\"\n%s attach chart -p hyperspace-database-chart1 -v 1.2.3 --chart oci://registry.example.com/charts/hyperspace-db:1.2.3 --instructions \"helm install...\"", AppName, AppName),
 	Args:    cobra.NoArgs,
 	PreRunE: GetRefreshToken,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -140,15 +253,45 @@ var AttachChartCmd = &cobra.Command{
 			return fmt.Errorf("failed to parse chart URL: %w", err)
 		}
 
+		if !AttachChartSkipValidation {
+			var warnings []string
+			warnings, err = Marketplace.ValidateChart(chartURL, version.Number)
+			printChartWarnings(cmd, warnings)
+			if err != nil {
+				return fmt.Errorf("%w\nTo attach the chart anyway, use --skip-validation", err)
+			}
+		}
+
+		existingCharts := chartURLs(product, version.Number)
+		if AttachReplace {
+			name, err := chartName(chartURL)
+			if err != nil {
+				return err
+			}
+			product.RemoveCharts(version.Number, name, "")
+		}
+
 		var updatedProduct *models.Product
 		if chartURL.Scheme == "" || chartURL.Scheme == "file" {
 			updatedProduct, err = Marketplace.AttachLocalChart(AttachChartURL, AttachInstructions, product, version)
 		} else if chartURL.Scheme == "http" || chartURL.Scheme == "https" {
 			updatedProduct, err = Marketplace.AttachPublicChart(chartURL, AttachInstructions, product, version)
+		} else if chartURL.Scheme == "oci" {
+			updatedProduct, err = Marketplace.AttachOCIChart(chartURL, AttachInstructions, product, version)
 		} else {
 			return fmt.Errorf("unsupported protocol scheme: %s", chartURL.Scheme)
 		}
 
+		if err != nil {
+			return handleDryRun(err)
+		}
+
+		attachedCharts := []string{AttachChartURL}
+		if chartURL.Scheme != "http" && chartURL.Scheme != "https" {
+			// Local and OCI charts are uploaded, so they are found by their new URL
+			attachedCharts = newChartURLs(existingCharts, updatedProduct, version.Number)
+		}
+		updatedProduct, err = waitForAttachedAssets(cmd, updatedProduct, version, pkg.AssetTypeChart, attachedCharts...)
 		if err != nil {
 			return err
 		}
@@ -158,7 +301,22 @@ var AttachChartCmd = &cobra.Command{
 	},
 }
 
+// ValidateHashAlgorithm checks the --hash-algo flag, for the commands that upload files hashed with it
+func ValidateHashAlgorithm(cmd *cobra.Command, args []string) error {
+	_, err := pkg.NewHasher(viper.GetString("marketplace.upload.hash-algorithm"))
+	if err != nil {
+		return fmt.Errorf("invalid --hash-algo: %w", err)
+	}
+	return nil
+}
+
 func ValidateTagType(cmd *cobra.Command, args []string) error {
+	if AttachContainerImageTagType == "" {
+		if attachingMultipleContainerImages() {
+			return nil
+		}
+		return fmt.Errorf("required flag(s) \"tag-type\" not set")
+	}
 	AttachContainerImageTagType = strings.ToUpper(AttachContainerImageTagType)
 	if AttachContainerImageTagType != models.ImageTagTypeFixed && AttachContainerImageTagType != models.ImageTagTypeFloating {
 		return fmt.Errorf("invalid image tag type: %s. must be either \"%s\" or \"%s\"", AttachContainerImageTagType, models.ImageTagTypeFixed, models.ImageTagTypeFloating)
@@ -166,15 +324,109 @@ func ValidateTagType(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func attachingMultipleContainerImages() bool {
+	return len(AttachContainerImages) > 0 || AttachContainerImagesFile != ""
+}
+
+// containerImagesToAttach collects the images from --images-file, --image, and --image-repository and --tag
+func containerImagesToAttach() ([]*pkg.ContainerImage, error) {
+	var images []*pkg.ContainerImage
+	if AttachContainerImagesFile != "" {
+		loadedImages, err := pkg.LoadContainerImages(AttachContainerImagesFile, AttachContainerImageTagType)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, loadedImages...)
+	}
+
+	for _, ref := range AttachContainerImages {
+		image, err := pkg.ParseContainerImage(ref, AttachContainerImageTagType)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+
+	if AttachContainerImage != "" || AttachContainerImageTag != "" || AttachContainerImageFile != "" {
+		err := resolveContainerImage()
+		if err != nil {
+			return nil, err
+		}
+		if AttachContainerImageTagType == "" {
+			return nil, fmt.Errorf("--tag-type is required for --image-repository %s", AttachContainerImage)
+		}
+		images = append(images, &pkg.ContainerImage{
+			Repository: AttachContainerImage,
+			Tag:        AttachContainerImageTag,
+			TagType:    AttachContainerImageTagType,
+			File:       AttachContainerImageFile,
+		})
+	}
+
+	if len(images) == 0 {
+		return nil, fmt.Errorf("%s does not list any images", AttachContainerImagesFile)
+	}
+	return images, nil
+}
+
+// resolveContainerImage fills in the image repository and tag from the image file, if they were not given
+func resolveContainerImage() error {
+	if AttachContainerImage != "" && AttachContainerImageTag != "" {
+		return nil
+	}
+	if AttachContainerImageFile == "" {
+		return fmt.Errorf("--image-repository and --tag are required when not attaching an image file")
+	}
+
+	images, err := pkg.ImageArchiveInspector(AttachContainerImageFile)
+	if err != nil {
+		return err
+	}
+
+	var matches []*pkg.ArchiveImage
+	for _, image := range images {
+		if image.Matches(AttachContainerImage, AttachContainerImageTag) {
+			matches = append(matches, image)
+		}
+	}
+	if len(matches) != 1 {
+		return fmt.Errorf("%s contains %d matching images, please select one with --image-repository and --tag", AttachContainerImageFile, len(matches))
+	}
+
+	if AttachContainerImage == "" {
+		if matches[0].Repository == "" {
+			return fmt.Errorf("%s does not name the repository for %s, please set it with --image-repository", AttachContainerImageFile, matches[0])
+		}
+		AttachContainerImage = matches[0].Repository
+	}
+	if AttachContainerImageTag == "" {
+		AttachContainerImageTag = matches[0].Tag
+	}
+	return nil
+}
+
 var AttachContainerImageCmd = &cobra.Command{
 	Use:     "image",
 	Short:   "Attach a container image",
 	Long:    "Attaches a container image to a product in the VMware Marketplace",
-	Example: fmt.Sprintf("%s attach image -p hyperspace-database-image1 -v 1.2.3 --image hyperspace-labs/hyperspace-db --tag 1.2.3 --tag-type fixed --instructions \"docker run...\"", AppName),
+	Example: fmt.Sprintf("%s attach image -p hyperspace-database-image1 -v 1.2.3 --image-repository hyperspace-labs/hyperspace-db --tag 1.2.3 --tag-type fixed --instructions \"docker run...\"\n%s attach image -p hyperspace-database-image1 -v 1.2.3 --image hyperspace-labs/hyperspace-db:1.2.3:fixed --image hyperspace-labs/hyperspace-db-ui:1.2.3:fixed --instructions \"docker run...\"", AppName, AppName),
 	Args:    cobra.NoArgs,
 	PreRunE: RunSerially(ValidateTagType, GetRefreshToken),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
+		var images []*pkg.ContainerImage
+		var err error
+		if AttachContainerImageSkipVerification {
+			Marketplace.DisableRegistryVerification()
+		}
+		if attachingMultipleContainerImages() {
+			images, err = containerImagesToAttach()
+		} else {
+			err = resolveContainerImage()
+		}
+		if err != nil {
+			return err
+		}
 
 		product, version, err := Marketplace.GetProductWithVersion(AttachProductSlug, AttachProductVersion)
 		if err != nil {
@@ -202,12 +454,33 @@ var AttachContainerImageCmd = &cobra.Command{
 			product.SetPCAFile(version.Number, pcaUrl)
 		}
 
+		if AttachReplace {
+			product.RemoveContainerImage(version.Number, AttachContainerImage, AttachContainerImageTag)
+			for _, image := range images {
+				product.RemoveContainerImage(version.Number, image.Repository, image.Tag)
+			}
+		}
+
 		var updatedProduct *models.Product
-		if AttachContainerImageFile != "" {
+		if images != nil {
+			updatedProduct, err = Marketplace.AttachContainerImages(images, AttachInstructions, product, version)
+		} else if AttachContainerImageFile != "" {
 			updatedProduct, err = Marketplace.AttachLocalContainerImage(AttachContainerImageFile, AttachContainerImage, AttachContainerImageTag, AttachContainerImageTagType, AttachInstructions, product, version)
 		} else {
 			updatedProduct, err = Marketplace.AttachPublicContainerImage(AttachContainerImage, AttachContainerImageTag, AttachContainerImageTagType, AttachInstructions, product, version)
 		}
+		if err != nil {
+			return handleDryRun(err)
+		}
+
+		attachedImages := []string{fmt.Sprintf("%s:%s", AttachContainerImage, AttachContainerImageTag)}
+		if images != nil {
+			attachedImages = []string{}
+			for _, image := range images {
+				attachedImages = append(attachedImages, fmt.Sprintf("%s:%s", image.Repository, image.Tag))
+			}
+		}
+		updatedProduct, err = waitForAttachedAssets(cmd, updatedProduct, version, pkg.AssetTypeContainerImage, attachedImages...)
 		if err != nil {
 			return err
 		}
@@ -221,9 +494,9 @@ var AttachOtherCmd = &cobra.Command{
 	Use:     "other",
 	Short:   "Attach an other file",
 	Long:    "Upload and attach an other file (.pak, .vlcp, .zip, .tar, .gz, .tgz, .ova, .vmoapp) to a product in the VMware Marketplace",
-	Example: fmt.Sprintf("%s attach other -p hyperspace-database-vm1 -v 1.2.3 --file hyperspace-db-1.2.3.tgz", AppName),
+	Example: fmt.Sprintf("%s attach other -p hyperspace-database-vm1 -v 1.2.3 --file hyperspace-db-1.2.3.tgz\n%s attach other -p hyperspace-database-vm1 -v 1.2.3 --url https://downloads.example.com/hyperspace-db-1.2.3.tgz --fetch", AppName, AppName),
 	Args:    cobra.NoArgs,
-	PreRunE: GetRefreshToken,
+	PreRunE: RunSerially(ValidateHashAlgorithm, GetRefreshToken),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
 
@@ -253,7 +526,25 @@ var AttachOtherCmd = &cobra.Command{
 			product.SetPCAFile(version.Number, pcaUrl)
 		}
 
-		updatedProduct, err := Marketplace.AttachOtherFile(AttachOtherFile, product, version)
+		filename := filepath.Base(AttachOtherFile)
+		if AttachFileURL != "" {
+			filename = externalFileName(AttachFileURL)
+		}
+		if AttachReplace {
+			product.RemoveAddonFile(version.Number, filename)
+		}
+
+		var updatedProduct *models.Product
+		if AttachFileURL != "" {
+			updatedProduct, err = Marketplace.AttachExternalOtherFile(AttachFileURL, AttachFileFetch, product, version)
+		} else {
+			updatedProduct, err = Marketplace.AttachOtherFile(AttachOtherFile, product, version)
+		}
+		if err != nil {
+			return handleDryRun(err)
+		}
+
+		updatedProduct, err = waitForAttachedAssets(cmd, updatedProduct, version, pkg.AssetTypeOther, filename)
 		if err != nil {
 			return err
 		}
@@ -285,7 +576,16 @@ var AttachMetaFileCmd = &cobra.Command{
 		if AttachMetaFileVersion == "" {
 			AttachMetaFileVersion = version.Number
 		}
+		if AttachReplace {
+			product.RemoveMetaFile(version.Number, filepath.Base(AttachMetaFile))
+		}
+
 		updatedProduct, err := Marketplace.AttachMetaFile(AttachMetaFile, metaFileTypeMapping[MetaFileType], AttachMetaFileVersion, product, version)
+		if err != nil {
+			return handleDryRun(err)
+		}
+
+		updatedProduct, err = waitForAttachedAssets(cmd, updatedProduct, version, pkg.AssetTypeMetaFile, filepath.Base(AttachMetaFile))
 		if err != nil {
 			return err
 		}
@@ -299,9 +599,9 @@ var AttachVMCmd = &cobra.Command{
 	Use:     "vm",
 	Short:   "Attach a virtual machine file (ISO or OVA)",
 	Long:    "Upload and attach a virtual machine file (ISO or OVA) to a product in the VMware Marketplace",
-	Example: fmt.Sprintf("%s attach vm -p hyperspace-database-vm1 -v 1.2.3 --file hyperspace-db-1.2.3.iso", AppName),
+	Example: fmt.Sprintf("%s attach vm -p hyperspace-database-vm1 -v 1.2.3 --file hyperspace-db-1.2.3.iso\n%s attach vm -p hyperspace-database-vm1 -v 1.2.3 --url https://downloads.example.com/hyperspace-db-1.2.3.ova --fetch", AppName, AppName),
 	Args:    cobra.NoArgs,
-	PreRunE: GetRefreshToken,
+	PreRunE: RunSerially(ValidateHashAlgorithm, GetRefreshToken),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
 
@@ -331,7 +631,25 @@ var AttachVMCmd = &cobra.Command{
 			product.SetPCAFile(version.Number, pcaUrl)
 		}
 
-		updatedProduct, err := Marketplace.UploadVM(AttachVMFile, product, version)
+		filename := filepath.Base(AttachVMFile)
+		if AttachFileURL != "" {
+			filename = externalFileName(AttachFileURL)
+		}
+		if AttachReplace {
+			product.RemoveFile(version.Number, filename)
+		}
+
+		var updatedProduct *models.Product
+		if AttachFileURL != "" {
+			updatedProduct, err = Marketplace.AttachExternalVM(AttachFileURL, AttachFileFetch, product, version)
+		} else {
+			updatedProduct, err = Marketplace.UploadVM(AttachVMFile, product, version)
+		}
+		if err != nil {
+			return handleDryRun(err)
+		}
+
+		updatedProduct, err = waitForAttachedAssets(cmd, updatedProduct, version, pkg.AssetTypeVM, filename)
 		if err != nil {
 			return err
 		}
@@ -340,3 +658,72 @@ var AttachVMCmd = &cobra.Command{
 		return Output.RenderFiles(updatedProduct.GetFilesForVersion(version.Number))
 	},
 }
+
+// externalFileName returns the name of the file at the URL, which is how the Marketplace will list it
+func externalFileName(fileURL string) string {
+	parsedURL, err := url.Parse(fileURL)
+	if err != nil {
+		return path.Base(fileURL)
+	}
+	return path.Base(parsedURL.Path)
+}
+
+// chartName returns the name of the chart at the URL, which is used to find the chart that it replaces
+func chartName(chartURL *url.URL) (string, error) {
+	var chart *models.ChartVersion
+	var err error
+	if chartURL.Scheme == "oci" {
+		// The chart is named after the last part of its repository
+		name, _, _ := strings.Cut(path.Base(chartURL.Path), ":")
+		return name, nil
+	} else if chartURL.Scheme == "http" || chartURL.Scheme == "https" {
+		chart, err = Marketplace.DownloadChart(chartURL)
+	} else if chartURL.Scheme == "" || chartURL.Scheme == "file" {
+		chart, err = pkg.LoadChart(AttachChartURL)
+	} else {
+		return "", fmt.Errorf("unsupported protocol scheme: %s", chartURL.Scheme)
+	}
+	if err != nil {
+		return "", err
+	}
+	return chart.Repo.Name, nil
+}
+
+// chartURLs returns the URLs of the charts attached to the product version
+func chartURLs(product *models.Product, version string) []string {
+	var urls []string
+	for _, chart := range product.GetChartsForVersion(version) {
+		urls = append(urls, chart.HelmTarUrl)
+	}
+	return urls
+}
+
+// newChartURLs returns the URLs of the charts on the product version that are not in the existing URLs
+func newChartURLs(existing []string, product *models.Product, version string) []string {
+	var urls []string
+	for _, chartURL := range chartURLs(product, version) {
+		isNew := true
+		for _, existingURL := range existing {
+			if chartURL == existingURL {
+				isNew = false
+				break
+			}
+		}
+		if isNew {
+			urls = append(urls, chartURL)
+		}
+	}
+	return urls
+}
+
+// waitForAttachedAssets waits for the assets this command attached, found by their names, to be processed if --wait was given
+func waitForAttachedAssets(cmd *cobra.Command, product *models.Product, version *models.Version, assetType string, names ...string) (*models.Product, error) {
+	if !AttachWait {
+		return product, nil
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("could not find the attached %s asset on %s %s to wait for", assetType, product.Slug, version.Number)
+	}
+	updatedProduct, _, err := waitForAssets(cmd, product.Slug, version.Number, assetType, names, AttachWaitTimeout)
+	return updatedProduct, err
+}
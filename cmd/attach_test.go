@@ -8,6 +8,8 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	"github.com/spf13/viper"
 	"github.com/vmware-labs/marketplace-cli/v2/cmd"
 	"github.com/vmware-labs/marketplace-cli/v2/cmd/output/outputfakes"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/internalfakes"
@@ -46,6 +48,41 @@ var _ = Describe("ValidateTagType", func() {
 	})
 })
 
+var _ = Describe("ValidateHashAlgorithm", func() {
+	AfterEach(func() {
+		viper.Set("marketplace.upload.hash-algorithm", pkg.DefaultHashAlgorithm)
+	})
+
+	It("validates the hash algorithm", func() {
+		By("accepting SHA1", func() {
+			viper.Set("marketplace.upload.hash-algorithm", "sha1")
+			Expect(cmd.ValidateHashAlgorithm(nil, nil)).To(Succeed())
+		})
+
+		By("accepting SHA256", func() {
+			viper.Set("marketplace.upload.hash-algorithm", "SHA256")
+			Expect(cmd.ValidateHashAlgorithm(nil, nil)).To(Succeed())
+		})
+
+		By("rejecting anything else", func() {
+			viper.Set("marketplace.upload.hash-algorithm", "md5")
+			err := cmd.ValidateHashAlgorithm(nil, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("invalid --hash-algo: unsupported hash algorithm: \"md5\""))
+		})
+	})
+})
+
+var _ = Describe("--hash-algo", func() {
+	It("is only a flag for the commands that upload hashed files", func() {
+		Expect(cmd.AttachVMCmd.Flags().Lookup("hash-algo")).ToNot(BeNil())
+		Expect(cmd.AttachOtherCmd.Flags().Lookup("hash-algo")).ToNot(BeNil())
+		Expect(cmd.AttachChartCmd.Flags().Lookup("hash-algo")).To(BeNil())
+		Expect(cmd.AttachMetaFileCmd.Flags().Lookup("hash-algo")).To(BeNil())
+		Expect(cmd.AttachCmd.PersistentFlags().Lookup("hash-algo")).To(BeNil())
+	})
+})
+
 var _ = Describe("AttachCmd", func() {
 	var (
 		marketplace *pkgfakes.FakeMarketplaceInterface
@@ -59,6 +96,8 @@ var _ = Describe("AttachCmd", func() {
 		cmd.Output = output
 		cmd.AttachCreateVersion = false
 		cmd.AttachPCAFile = ""
+		cmd.AttachReplace = false
+		cmd.AttachChartSkipValidation = false
 	})
 
 	Describe("AttachChartCmd", func() {
@@ -124,6 +163,96 @@ var _ = Describe("AttachCmd", func() {
 				})
 			})
 
+			It("validates the chart before attaching it", func() {
+				cmd.AttachProductSlug = "my-super-product"
+				cmd.AttachProductVersion = "1.1.1"
+				cmd.AttachChartURL = "/path/to/my-chart"
+				cmd.AttachInstructions = "helm install it"
+				err := cmd.AttachChartCmd.RunE(cmd.AttachChartCmd, []string{""})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(marketplace.ValidateChartCallCount()).To(Equal(1))
+				chartUrl, productVersion := marketplace.ValidateChartArgsForCall(0)
+				Expect(chartUrl.String()).To(Equal("/path/to/my-chart"))
+				Expect(productVersion).To(Equal("1.1.1"))
+			})
+
+			When("validating the chart returns warnings", func() {
+				BeforeEach(func() {
+					marketplace.ValidateChartReturns([]string{"Chart.yaml does not set an appVersion"}, nil)
+				})
+				It("prints them and attaches the chart", func() {
+					stderr := NewBuffer()
+					cmd.AttachChartCmd.SetErr(stderr)
+					cmd.AttachProductSlug = "my-super-product"
+					cmd.AttachProductVersion = "1.1.1"
+					cmd.AttachChartURL = "/path/to/my-chart"
+					cmd.AttachInstructions = "helm install it"
+					err := cmd.AttachChartCmd.RunE(cmd.AttachChartCmd, []string{""})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(stderr).To(Say("Warning: Chart.yaml does not set an appVersion"))
+					Expect(marketplace.AttachLocalChartCallCount()).To(Equal(1))
+				})
+			})
+
+			When("the chart is not valid", func() {
+				BeforeEach(func() {
+					marketplace.ValidateChartReturns(nil, &pkg.ChartValidationError{
+						Chart:    "/path/to/my-chart",
+						Problems: []string{"[ERROR] templates/: parse error"},
+					})
+				})
+				It("returns an error and does not attach the chart", func() {
+					cmd.AttachProductSlug = "my-super-product"
+					cmd.AttachProductVersion = "1.1.1"
+					cmd.AttachChartURL = "/path/to/my-chart"
+					cmd.AttachInstructions = "helm install it"
+					err := cmd.AttachChartCmd.RunE(cmd.AttachChartCmd, []string{""})
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("chart /path/to/my-chart is not valid:\n  [ERROR] templates/: parse error\nTo attach the chart anyway, use --skip-validation"))
+					Expect(marketplace.AttachLocalChartCallCount()).To(Equal(0))
+				})
+
+				When("skipping validation", func() {
+					It("attaches the chart", func() {
+						cmd.AttachProductSlug = "my-super-product"
+						cmd.AttachProductVersion = "1.1.1"
+						cmd.AttachChartURL = "/path/to/my-chart"
+						cmd.AttachInstructions = "helm install it"
+						cmd.AttachChartSkipValidation = true
+						err := cmd.AttachChartCmd.RunE(cmd.AttachChartCmd, []string{""})
+						Expect(err).ToNot(HaveOccurred())
+						Expect(marketplace.ValidateChartCallCount()).To(Equal(0))
+						Expect(marketplace.AttachLocalChartCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			When("in dry run mode", func() {
+				BeforeEach(func() {
+					marketplace.AttachLocalChartReturns(nil, &pkg.DryRunError{
+						Product: testProduct,
+						Changes: []*pkg.FieldChange{{Field: "chartversions[0].helmtarurl", New: "https://example.com/uploaded-chart.tgz"}},
+					})
+				})
+				It("outputs the changes", func() {
+					cmd.AttachProductSlug = "my-super-product"
+					cmd.AttachProductVersion = "1.1.1"
+					cmd.AttachChartURL = "/path/to/my-chart"
+					cmd.AttachInstructions = "helm install it"
+					err := cmd.AttachChartCmd.RunE(cmd.AttachChartCmd, []string{""})
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(output.PrintHeaderCallCount()).To(Equal(1))
+					Expect(output.PrintHeaderArgsForCall(0)).To(Equal("Changes that would be made to My Super Product (dry run):"))
+					Expect(output.RenderChangesCallCount()).To(Equal(1))
+					changes := output.RenderChangesArgsForCall(0)
+					Expect(changes).To(HaveLen(1))
+					Expect(changes[0].Field).To(Equal("chartversions[0].helmtarurl"))
+					Expect(output.RenderChartsCallCount()).To(Equal(0))
+				})
+			})
+
 			When("attaching the chart fails", func() {
 				BeforeEach(func() {
 					marketplace.AttachLocalChartReturns(nil, errors.New("attach local chart failed"))
@@ -203,6 +332,80 @@ var _ = Describe("AttachCmd", func() {
 			})
 		})
 
+		Context("chart is in an OCI registry", func() {
+			BeforeEach(func() {
+				updatedProduct.ChartVersions = append(updatedProduct.ChartVersions, &models.ChartVersion{
+					AppVersion: "1.1.1",
+					HelmTarUrl: "https://example.com/uploaded-chart.tgz",
+					Readme:     "helm install it",
+				})
+				marketplace.AttachOCIChartReturns(updatedProduct, nil)
+			})
+			It("attaches the chart", func() {
+				cmd.AttachProductSlug = "my-super-product"
+				cmd.AttachProductVersion = "1.1.1"
+				cmd.AttachChartURL = "oci://registry.example.com/charts/my-chart:1.0.0"
+				cmd.AttachInstructions = "helm install it"
+				err := cmd.AttachChartCmd.RunE(cmd.AttachChartCmd, []string{""})
+				Expect(err).ToNot(HaveOccurred())
+
+				By("attaching the OCI chart", func() {
+					Expect(marketplace.AttachOCIChartCallCount()).To(Equal(1))
+					chartUrl, instructions, product, version := marketplace.AttachOCIChartArgsForCall(0)
+					Expect(chartUrl.String()).To(Equal("oci://registry.example.com/charts/my-chart:1.0.0"))
+					Expect(instructions).To(Equal("helm install it"))
+					Expect(product.Slug).To(Equal("my-super-product"))
+					Expect(version.Number).To(Equal("1.1.1"))
+				})
+
+				By("outputting the updated list of charts", func() {
+					Expect(output.RenderChartsCallCount()).To(Equal(1))
+					charts := output.RenderChartsArgsForCall(0)
+					Expect(charts).To(HaveLen(1))
+					Expect(charts[0].HelmTarUrl).To(Equal("https://example.com/uploaded-chart.tgz"))
+				})
+			})
+
+			When("replacing a chart that is already attached", func() {
+				BeforeEach(func() {
+					testProduct.ChartVersions = []*models.ChartVersion{
+						{AppVersion: "1.1.1", Repo: &models.Repo{Name: "my-chart"}, HelmTarUrl: "https://example.com/my-chart-0.9.0.tgz"},
+						{AppVersion: "1.1.1", Repo: &models.Repo{Name: "other-chart"}, HelmTarUrl: "https://example.com/other-chart.tgz"},
+					}
+				})
+				It("removes only the chart named after the repository", func() {
+					cmd.AttachProductSlug = "my-super-product"
+					cmd.AttachProductVersion = "1.1.1"
+					cmd.AttachChartURL = "oci://registry.example.com/charts/my-chart:1.0.0"
+					cmd.AttachInstructions = "helm install it"
+					cmd.AttachReplace = true
+					err := cmd.AttachChartCmd.RunE(cmd.AttachChartCmd, []string{""})
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(marketplace.AttachOCIChartCallCount()).To(Equal(1))
+					_, _, product, _ := marketplace.AttachOCIChartArgsForCall(0)
+					charts := product.GetChartsForVersion("1.1.1")
+					Expect(charts).To(HaveLen(1))
+					Expect(charts[0].Repo.Name).To(Equal("other-chart"))
+				})
+			})
+
+			When("attaching the chart fails", func() {
+				BeforeEach(func() {
+					marketplace.AttachOCIChartReturns(nil, errors.New("attach oci chart failed"))
+				})
+				It("returns an error", func() {
+					cmd.AttachProductSlug = "my-super-product"
+					cmd.AttachProductVersion = "1.1.1"
+					cmd.AttachChartURL = "oci://registry.example.com/charts/my-chart:1.0.0"
+					cmd.AttachInstructions = "helm install it"
+					err := cmd.AttachChartCmd.RunE(cmd.AttachChartCmd, []string{""})
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("attach oci chart failed"))
+				})
+			})
+		})
+
 		Context("chart is available on the network", func() {
 			BeforeEach(func() {
 				updatedProduct.ChartVersions = append(updatedProduct.ChartVersions, &models.ChartVersion{
@@ -251,6 +454,34 @@ var _ = Describe("AttachCmd", func() {
 				})
 			})
 
+			When("replacing a chart that is already attached", func() {
+				BeforeEach(func() {
+					testProduct.ChartVersions = []*models.ChartVersion{
+						{AppVersion: "1.1.1", Repo: &models.Repo{Name: "my-chart"}, HelmTarUrl: "https://example.com/public/my-chart-0.9.0.tgz"},
+						{AppVersion: "1.1.1", Repo: &models.Repo{Name: "other-chart"}, HelmTarUrl: "https://example.com/public/other-chart.tgz"},
+					}
+					marketplace.DownloadChartReturns(&models.ChartVersion{Repo: &models.Repo{Name: "my-chart"}}, nil)
+				})
+				It("removes only the chart with the same name before attaching", func() {
+					cmd.AttachProductSlug = "my-super-product"
+					cmd.AttachProductVersion = "1.1.1"
+					cmd.AttachChartURL = "https://example.com/public/my-chart.tgz"
+					cmd.AttachInstructions = "helm install it"
+					cmd.AttachReplace = true
+					err := cmd.AttachChartCmd.RunE(cmd.AttachChartCmd, []string{""})
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(marketplace.DownloadChartCallCount()).To(Equal(1))
+					Expect(marketplace.DownloadChartArgsForCall(0).String()).To(Equal("https://example.com/public/my-chart.tgz"))
+
+					Expect(marketplace.AttachPublicChartCallCount()).To(Equal(1))
+					_, _, product, _ := marketplace.AttachPublicChartArgsForCall(0)
+					charts := product.GetChartsForVersion("1.1.1")
+					Expect(charts).To(HaveLen(1))
+					Expect(charts[0].Repo.Name).To(Equal("other-chart"))
+				})
+			})
+
 			When("attaching the chart fails", func() {
 				BeforeEach(func() {
 					marketplace.AttachPublicChartReturns(nil, errors.New("attach public chart failed"))
@@ -427,8 +658,12 @@ var _ = Describe("AttachCmd", func() {
 			test.AddContainerImages(updatedProduct, "1.1.1", "docker run it", nginx)
 			marketplace.AttachLocalContainerImageReturns(updatedProduct, nil)
 			marketplace.AttachPublicContainerImageReturns(updatedProduct, nil)
+			marketplace.AttachContainerImagesReturns(updatedProduct, nil)
 
 			cmd.AttachContainerImageFile = ""
+			cmd.AttachContainerImages = []string{}
+			cmd.AttachContainerImagesFile = ""
+			cmd.AttachContainerImageSkipVerification = false
 			cmd.AttachPCAFile = ""
 		})
 
@@ -476,6 +711,47 @@ var _ = Describe("AttachCmd", func() {
 			})
 		})
 
+		When("skipping verification", func() {
+			It("disables the registry check before attaching", func() {
+				cmd.AttachProductSlug = "my-super-product"
+				cmd.AttachProductVersion = "1.1.1"
+				cmd.AttachContainerImage = "docker.io/bitnami/nginx"
+				cmd.AttachContainerImageTag = "1.21.6"
+				cmd.AttachContainerImageTagType = "FIXED"
+				cmd.AttachInstructions = "docker run it"
+				cmd.AttachContainerImageSkipVerification = true
+				err := cmd.AttachContainerImageCmd.RunE(cmd.AttachContainerImageCmd, []string{""})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(marketplace.DisableRegistryVerificationCallCount()).To(Equal(1))
+				Expect(marketplace.AttachPublicContainerImageCallCount()).To(Equal(1))
+			})
+		})
+
+		When("replacing an image tag that is already attached", func() {
+			BeforeEach(func() {
+				test.AddContainerImages(testProduct, "1.1.1", "docker run it",
+					test.CreateFakeContainerImage("docker.io/bitnami/nginx", "1.21.6", "latest"),
+				)
+			})
+			It("removes the existing tag before attaching", func() {
+				cmd.AttachProductSlug = "my-super-product"
+				cmd.AttachProductVersion = "1.1.1"
+				cmd.AttachContainerImage = "docker.io/bitnami/nginx"
+				cmd.AttachContainerImageTag = "1.21.6"
+				cmd.AttachContainerImageTagType = "FIXED"
+				cmd.AttachInstructions = "docker run it"
+				cmd.AttachReplace = true
+				err := cmd.AttachContainerImageCmd.RunE(cmd.AttachContainerImageCmd, []string{""})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(marketplace.AttachPublicContainerImageCallCount()).To(Equal(1))
+				_, _, _, _, product, _ := marketplace.AttachPublicContainerImageArgsForCall(0)
+				Expect(product.HasContainerImage("1.1.1", "docker.io/bitnami/nginx", "1.21.6")).To(BeFalse())
+				Expect(product.HasContainerImage("1.1.1", "docker.io/bitnami/nginx", "latest")).To(BeTrue())
+			})
+		})
+
 		When("attaching a PCA file", func() {
 			var uploader *internalfakes.FakeUploader
 			BeforeEach(func() {
@@ -570,6 +846,124 @@ var _ = Describe("AttachCmd", func() {
 					Expect(version.IsNewVersion).To(BeFalse())
 				})
 			})
+
+			When("the image repository and tag are not given", func() {
+				var (
+					imageArchiveInspector         *pkgfakes.FakeImageArchiveInspectorFunc
+					previousImageArchiveInspector pkg.ImageArchiveInspectorFunc
+				)
+
+				BeforeEach(func() {
+					imageArchiveInspector = &pkgfakes.FakeImageArchiveInspectorFunc{}
+					imageArchiveInspector.Returns([]*pkg.ArchiveImage{
+						{Repository: "bitnami/nginx", Tag: "1.21.6"},
+					}, nil)
+					previousImageArchiveInspector = pkg.ImageArchiveInspector
+					pkg.ImageArchiveInspector = imageArchiveInspector.Spy
+
+					cmd.AttachProductSlug = "my-super-product"
+					cmd.AttachProductVersion = "1.1.1"
+					cmd.AttachContainerImage = ""
+					cmd.AttachContainerImageFile = "/path/tp/image.tar"
+					cmd.AttachContainerImageTag = ""
+					cmd.AttachContainerImageTagType = "FIXED"
+					cmd.AttachInstructions = "docker run it"
+				})
+
+				AfterEach(func() {
+					pkg.ImageArchiveInspector = previousImageArchiveInspector
+				})
+
+				It("reads them from the image file", func() {
+					err := cmd.AttachContainerImageCmd.RunE(cmd.AttachContainerImageCmd, []string{""})
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(imageArchiveInspector.ArgsForCall(0)).To(Equal("/path/tp/image.tar"))
+					_, image, tag, _, _, _, _ := marketplace.AttachLocalContainerImageArgsForCall(0)
+					Expect(image).To(Equal("bitnami/nginx"))
+					Expect(tag).To(Equal("1.21.6"))
+				})
+
+				When("the image file contains multiple images", func() {
+					BeforeEach(func() {
+						imageArchiveInspector.Returns([]*pkg.ArchiveImage{
+							{Repository: "bitnami/nginx", Tag: "1.21.6"},
+							{Repository: "bitnami/nginx", Tag: "latest"},
+						}, nil)
+					})
+
+					It("returns an error", func() {
+						err := cmd.AttachContainerImageCmd.RunE(cmd.AttachContainerImageCmd, []string{""})
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(Equal("/path/tp/image.tar contains 2 matching images, please select one with --image-repository and --tag"))
+						Expect(marketplace.AttachLocalContainerImageCallCount()).To(Equal(0))
+					})
+
+					It("selects the image with the given tag", func() {
+						cmd.AttachContainerImageTag = "latest"
+						err := cmd.AttachContainerImageCmd.RunE(cmd.AttachContainerImageCmd, []string{""})
+						Expect(err).ToNot(HaveOccurred())
+
+						_, image, tag, _, _, _, _ := marketplace.AttachLocalContainerImageArgsForCall(0)
+						Expect(image).To(Equal("bitnami/nginx"))
+						Expect(tag).To(Equal("latest"))
+					})
+				})
+			})
+		})
+
+		When("attaching multiple images", func() {
+			It("attaches all of them in one update", func() {
+				cmd.AttachProductSlug = "my-super-product"
+				cmd.AttachProductVersion = "1.1.1"
+				cmd.AttachContainerImage = ""
+				cmd.AttachContainerImageTag = ""
+				cmd.AttachContainerImageTagType = "FIXED"
+				cmd.AttachContainerImages = []string{"docker.io/bitnami/nginx:1.21.6", "docker.io/bitnami/nginx:latest:floating"}
+				cmd.AttachInstructions = "docker run it"
+				err := cmd.AttachContainerImageCmd.RunE(cmd.AttachContainerImageCmd, []string{""})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(marketplace.AttachPublicContainerImageCallCount()).To(Equal(0))
+				Expect(marketplace.AttachContainerImagesCallCount()).To(Equal(1))
+				images, instructions, product, version := marketplace.AttachContainerImagesArgsForCall(0)
+				Expect(images).To(HaveLen(2))
+				Expect(*images[0]).To(Equal(pkg.ContainerImage{Repository: "docker.io/bitnami/nginx", Tag: "1.21.6", TagType: "FIXED"}))
+				Expect(*images[1]).To(Equal(pkg.ContainerImage{Repository: "docker.io/bitnami/nginx", Tag: "latest", TagType: "FLOATING"}))
+				Expect(instructions).To(Equal("docker run it"))
+				Expect(product.Slug).To(Equal("my-super-product"))
+				Expect(version.Number).To(Equal("1.1.1"))
+			})
+
+			When("an image is not valid", func() {
+				It("returns an error", func() {
+					cmd.AttachProductSlug = "my-super-product"
+					cmd.AttachProductVersion = "1.1.1"
+					cmd.AttachContainerImage = ""
+					cmd.AttachContainerImageTag = ""
+					cmd.AttachContainerImageTagType = ""
+					cmd.AttachContainerImages = []string{"docker.io/bitnami/nginx:1.21.6"}
+					cmd.AttachInstructions = "docker run it"
+					err := cmd.AttachContainerImageCmd.RunE(cmd.AttachContainerImageCmd, []string{""})
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("no tag type for image docker.io/bitnami/nginx:1.21.6, add :fixed or :floating"))
+					Expect(marketplace.AttachContainerImagesCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		When("the image repository and tag are not given for a public image", func() {
+			It("returns an error", func() {
+				cmd.AttachProductSlug = "my-super-product"
+				cmd.AttachProductVersion = "1.1.1"
+				cmd.AttachContainerImage = ""
+				cmd.AttachContainerImageTag = ""
+				cmd.AttachContainerImageTagType = "FIXED"
+				cmd.AttachInstructions = "docker run it"
+				err := cmd.AttachContainerImageCmd.RunE(cmd.AttachContainerImageCmd, []string{""})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("--image-repository and --tag are required when not attaching an image file"))
+			})
 		})
 
 		When("getting the product fails", func() {
@@ -681,6 +1075,67 @@ var _ = Describe("AttachCmd", func() {
 		})
 	})
 
+	Describe("AttachBlueprintCmd", func() {
+		BeforeEach(func() {
+			testProduct := test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeOthers)
+			test.AddVersions(testProduct, "1.1.1")
+			marketplace.GetProductWithVersionReturns(testProduct, &models.Version{Number: "1.1.1"}, nil)
+
+			updatedProduct := test.CreateFakeProduct(testProduct.ProductId, "My Super Product", "my-super-product", models.SolutionTypeOthers)
+			test.AddVersions(updatedProduct, "1.1.1")
+			updatedProduct.Blueprints = append(updatedProduct.Blueprints, test.CreateFakeBlueprint("blueprint.zip", "1.1.1"))
+			marketplace.AttachBlueprintReturns(updatedProduct, nil)
+
+			cmd.AttachProductSlug = "my-super-product"
+			cmd.AttachProductVersion = "1.1.1"
+			cmd.AttachBlueprintFile = "path/to/blueprint.zip"
+			cmd.AttachBlueprintSupportingFiles = []string{"path/to/readme.txt"}
+			cmd.AttachBlueprintImages = []string{"path/to/diagram.png"}
+			cmd.AttachBlueprintVRAVersion = "8.11"
+			cmd.AttachBlueprintPrerequisites = []string{"vRealize Automation 8.11"}
+			cmd.AttachInstructions = "Import the blueprint"
+		})
+
+		It("attaches the blueprint", func() {
+			err := cmd.AttachBlueprintCmd.RunE(cmd.AttachBlueprintCmd, []string{""})
+			Expect(err).ToNot(HaveOccurred())
+
+			By("uploading the blueprint", func() {
+				Expect(marketplace.AttachBlueprintCallCount()).To(Equal(1))
+				blueprint, product, version := marketplace.AttachBlueprintArgsForCall(0)
+				Expect(blueprint.File).To(Equal("path/to/blueprint.zip"))
+				Expect(blueprint.Files).To(Equal([]string{"path/to/readme.txt"}))
+				Expect(blueprint.Images).To(Equal([]string{"path/to/diagram.png"}))
+				Expect(blueprint.VRAVersion).To(Equal("8.11"))
+				Expect(blueprint.Instructions).To(Equal("Import the blueprint"))
+				Expect(blueprint.Prerequisites).To(Equal([]string{"vRealize Automation 8.11"}))
+				Expect(product.Slug).To(Equal("my-super-product"))
+				Expect(version.Number).To(Equal("1.1.1"))
+			})
+
+			By("outputting the updated list of blueprints", func() {
+				Expect(output.PrintHeaderCallCount()).To(Equal(1))
+				Expect(output.PrintHeaderArgsForCall(0)).To(Equal("Blueprints for My Super Product 1.1.1:"))
+
+				Expect(output.RenderBlueprintsCallCount()).To(Equal(1))
+				blueprints := output.RenderBlueprintsArgsForCall(0)
+				Expect(blueprints).To(HaveLen(1))
+				Expect(blueprints[0].BlueprintFiles[0].Title).To(Equal("blueprint.zip"))
+			})
+		})
+
+		When("attaching the blueprint fails", func() {
+			BeforeEach(func() {
+				marketplace.AttachBlueprintReturns(nil, errors.New("attach blueprint failed"))
+			})
+			It("returns an error", func() {
+				err := cmd.AttachBlueprintCmd.RunE(cmd.AttachBlueprintCmd, []string{""})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("attach blueprint failed"))
+			})
+		})
+	})
+
 	Describe("AttachOtherCmd", func() {
 		var testProduct *models.Product
 
@@ -693,8 +1148,11 @@ var _ = Describe("AttachCmd", func() {
 			test.AddVersions(updatedProduct, "1.1.1")
 			updatedProduct.AddOnFiles = append(updatedProduct.AddOnFiles, test.CreateFakeOtherFile("fake-file", "1.1.1"))
 			marketplace.AttachOtherFileReturns(updatedProduct, nil)
+			marketplace.AttachExternalOtherFileReturns(updatedProduct, nil)
 
 			cmd.AttachPCAFile = ""
+			cmd.AttachFileURL = ""
+			cmd.AttachFileFetch = false
 		})
 
 		It("attaches the asset", func() {
@@ -732,6 +1190,26 @@ var _ = Describe("AttachCmd", func() {
 			})
 		})
 
+		When("attaching an externally hosted file", func() {
+			It("attaches the file by its URL", func() {
+				cmd.AttachProductSlug = "my-super-product"
+				cmd.AttachProductVersion = "1.1.1"
+				cmd.AttachFileURL = "https://downloads.example.com/files/file.tgz?token=abc"
+				cmd.AttachFileFetch = true
+				cmd.AttachReplace = true
+				err := cmd.AttachOtherCmd.RunE(cmd.AttachOtherCmd, []string{""})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(marketplace.AttachOtherFileCallCount()).To(Equal(0))
+				Expect(marketplace.AttachExternalOtherFileCallCount()).To(Equal(1))
+				fileURL, fetch, product, version := marketplace.AttachExternalOtherFileArgsForCall(0)
+				Expect(fileURL).To(Equal("https://downloads.example.com/files/file.tgz?token=abc"))
+				Expect(fetch).To(BeTrue())
+				Expect(product.Slug).To(Equal("my-super-product"))
+				Expect(version.Number).To(Equal("1.1.1"))
+			})
+		})
+
 		When("attaching a PCA file", func() {
 			var uploader *internalfakes.FakeUploader
 			BeforeEach(func() {
@@ -895,8 +1373,11 @@ var _ = Describe("AttachCmd", func() {
 			test.AddVersions(updatedProduct, "1.1.1")
 			updatedProduct.ProductDeploymentFiles = append(updatedProduct.ProductDeploymentFiles, test.CreateFakeOVA("fake-ova", "1.1.1"))
 			marketplace.UploadVMReturns(updatedProduct, nil)
+			marketplace.AttachExternalVMReturns(updatedProduct, nil)
 
 			cmd.AttachPCAFile = ""
+			cmd.AttachFileURL = ""
+			cmd.AttachFileFetch = false
 		})
 
 		It("attaches the asset", func() {
@@ -934,6 +1415,28 @@ var _ = Describe("AttachCmd", func() {
 			})
 		})
 
+		When("attaching an externally hosted file", func() {
+			BeforeEach(func() {
+				testProduct.ProductDeploymentFiles = append(testProduct.ProductDeploymentFiles, test.CreateFakeOVA("hyperspace-db.ova", "1.1.1"))
+			})
+			It("attaches the file by its URL", func() {
+				cmd.AttachProductSlug = "my-super-product"
+				cmd.AttachProductVersion = "1.1.1"
+				cmd.AttachFileURL = "https://downloads.example.com/vms/hyperspace-db.ova"
+				cmd.AttachReplace = true
+				err := cmd.AttachVMCmd.RunE(cmd.AttachVMCmd, []string{""})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(marketplace.UploadVMCallCount()).To(Equal(0))
+				Expect(marketplace.AttachExternalVMCallCount()).To(Equal(1))
+				fileURL, fetch, product, version := marketplace.AttachExternalVMArgsForCall(0)
+				Expect(fileURL).To(Equal("https://downloads.example.com/vms/hyperspace-db.ova"))
+				Expect(fetch).To(BeFalse())
+				Expect(product.GetFilesForVersion("1.1.1")).To(BeEmpty())
+				Expect(version.Number).To(Equal("1.1.1"))
+			})
+		})
+
 		When("attaching a PCA file", func() {
 			var uploader *internalfakes.FakeUploader
 			BeforeEach(func() {
@@ -5,6 +5,9 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/spf13/cobra"
@@ -20,6 +23,9 @@ type TokenServices interface {
 //go:generate counterfeiter . TokenServicesInitializer
 type TokenServicesInitializer func(cspHost string) TokenServices
 
+// Claims are the claims of the current CSP access token, set by GetRefreshToken
+var Claims *csp.Claims
+
 var InitializeTokenServices TokenServicesInitializer = func(cspHost string) TokenServices {
 	return &csp.TokenServices{
 		CSPHost:     cspHost,
@@ -28,12 +34,48 @@ var InitializeTokenServices TokenServicesInitializer = func(cspHost string) Toke
 	}
 }
 
+// GetAPIToken returns the CSP API token, either given directly or from the token source of the profile
+func GetAPIToken() (string, error) {
+	if apiToken := viper.GetString("csp.api-token"); apiToken != "" {
+		return apiToken, nil
+	}
+
+	if envVar := viper.GetString("csp.api-token-env"); envVar != "" {
+		apiToken := os.Getenv(envVar)
+		if apiToken == "" {
+			return "", fmt.Errorf("missing CSP API token, %s is not set", envVar)
+		}
+		return apiToken, nil
+	}
+
+	if tokenFile := viper.GetString("csp.api-token-file"); tokenFile != "" {
+		contents, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read CSP API token from %s: %w", tokenFile, err)
+		}
+		return strings.TrimSpace(string(contents)), nil
+	}
+
+	return "", fmt.Errorf("missing CSP API token")
+}
+
 func GetRefreshToken(cmd *cobra.Command, args []string) error {
-	tokenServices := InitializeTokenServices(viper.GetString("csp.host"))
+	cspHost := viper.GetString("csp.host")
+	tokenServices := InitializeTokenServices(cspHost)
 
-	apiToken := viper.GetString("csp.api-token")
-	if apiToken == "" {
-		return fmt.Errorf("missing CSP API token")
+	apiToken, err := GetAPIToken()
+	if err != nil {
+		return err
+	}
+
+	var tokenCache *csp.TokenCache
+	if cachePath := viper.GetString("csp.token-cache"); cachePath != "" {
+		tokenCache = csp.NewTokenCache(cachePath)
+		if claims := tokenCache.Get(apiToken, cspHost); claims != nil {
+			Claims = claims
+			viper.Set("csp.refresh-token", claims.Token)
+			return nil
+		}
 	}
 
 	claims, err := tokenServices.Redeem(apiToken)
@@ -41,20 +83,99 @@ func GetRefreshToken(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if tokenCache != nil {
+		// The cache only saves time, so a failure to write it is not a reason to fail the command
+		_ = tokenCache.Put(apiToken, cspHost, claims)
+	}
+
+	Claims = claims
 	viper.Set("csp.refresh-token", claims.Token)
 	return nil
 }
 
+func defaultTokenCachePath() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(cacheDir, AppName, "tokens.json")
+}
+
 func init() {
 	rootCmd.AddCommand(AuthCmd)
+	rootCmd.AddCommand(WhoAmICmd)
+	AuthCmd.AddCommand(AuthStatusCmd)
+	AuthCmd.AddCommand(AuthLogoutCmd)
+	AuthCmd.AddCommand(AuthTokenCmd)
 }
 
 var AuthCmd = &cobra.Command{
-	Use:     "auth",
+	Use:       "auth",
+	Short:     "Manage authentication",
+	Long:      "Manage authentication to the VMware Cloud Services Platform",
+	Args:      cobra.OnlyValidArgs,
+	ValidArgs: []string{AuthStatusCmd.Use, AuthLogoutCmd.Use},
+}
+
+var AuthTokenCmd = &cobra.Command{
+	Use:     "token",
+	Short:   "Fetch and return a valid CSP refresh token",
 	Long:    "Fetch and return a valid CSP refresh token",
 	Hidden:  true,
+	Args:    cobra.NoArgs,
 	PreRunE: GetRefreshToken,
 	Run: func(cmd *cobra.Command, args []string) {
 		cmd.Println(viper.GetString("csp.refresh-token"))
 	},
 }
+
+func renderAuthStatus(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	if Claims == nil {
+		return fmt.Errorf("not authenticated")
+	}
+	Output.PrintHeader("Authenticated to the VMware Cloud Services Platform as:")
+	return Output.RenderIdentity(Claims.GetIdentity())
+}
+
+var AuthStatusCmd = &cobra.Command{
+	Use:     "status",
+	Short:   "Show the current user",
+	Long:    "Show the user, organization and permissions of the CSP API token",
+	Example: fmt.Sprintf("%s auth status -o json", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: GetRefreshToken,
+	RunE:    renderAuthStatus,
+}
+
+var WhoAmICmd = &cobra.Command{
+	Use:     "whoami",
+	Short:   "Show the current user",
+	Long:    "Show the user, organization and permissions of the CSP API token",
+	Example: fmt.Sprintf("%s whoami", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: GetRefreshToken,
+	RunE:    renderAuthStatus,
+}
+
+var AuthLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove cached CSP access tokens",
+	Long:  "Remove the CSP access tokens that are cached between commands",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		cachePath := viper.GetString("csp.token-cache")
+		if cachePath == "" {
+			cmd.Println("Token caching is disabled, nothing to remove")
+			return nil
+		}
+
+		err := csp.NewTokenCache(cachePath).Clear()
+		if err != nil {
+			return err
+		}
+		cmd.Println("Removed cached CSP access tokens")
+		return nil
+	},
+}
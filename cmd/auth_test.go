@@ -5,13 +5,19 @@ package cmd_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/golang-jwt/jwt"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
 	"github.com/spf13/viper"
 
 	. "github.com/vmware-labs/marketplace-cli/v2/cmd"
 	"github.com/vmware-labs/marketplace-cli/v2/cmd/cmdfakes"
+	"github.com/vmware-labs/marketplace-cli/v2/cmd/output/outputfakes"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/csp"
 )
 
@@ -33,6 +39,7 @@ var _ = Describe("Auth", func() {
 		BeforeEach(func() {
 			viper.Set("csp.api-token", "my-csp-api-token")
 			viper.Set("csp.host", "console.cloud.vmware.com.example")
+			viper.Set("csp.token-cache", "")
 			tokenServices.RedeemReturns(&csp.Claims{
 				Token: "my-refresh-token",
 			}, nil)
@@ -61,5 +68,103 @@ var _ = Describe("Auth", func() {
 				Expect(err.Error()).To(Equal("redeem failed"))
 			})
 		})
+
+		Context("token caching is enabled", func() {
+			var cacheDir string
+
+			BeforeEach(func() {
+				var err error
+				cacheDir, err = os.MkdirTemp("", "mkpcli-test-token-cache")
+				Expect(err).ToNot(HaveOccurred())
+				viper.Set("csp.token-cache", filepath.Join(cacheDir, "tokens.json"))
+
+				tokenServices.RedeemReturns(&csp.Claims{
+					StandardClaims: jwt.StandardClaims{
+						ExpiresAt: time.Now().Add(time.Hour).Unix(),
+					},
+					Token: "my-refresh-token",
+				}, nil)
+			})
+
+			AfterEach(func() {
+				viper.Set("csp.token-cache", "")
+				Expect(os.RemoveAll(cacheDir)).To(Succeed())
+			})
+
+			It("reuses the token from the previous command", func() {
+				Expect(GetRefreshToken(nil, []string{})).To(Succeed())
+				Expect(tokenServices.RedeemCallCount()).To(Equal(1))
+
+				viper.Set("csp.refresh-token", "")
+				Claims = nil
+				Expect(GetRefreshToken(nil, []string{})).To(Succeed())
+				Expect(tokenServices.RedeemCallCount()).To(Equal(1))
+				Expect(viper.GetString("csp.refresh-token")).To(Equal("my-refresh-token"))
+				Expect(Claims.Token).To(Equal("my-refresh-token"))
+			})
+
+			When("logging out", func() {
+				It("removes the cached token", func() {
+					Expect(GetRefreshToken(nil, []string{})).To(Succeed())
+
+					stdout := NewBuffer()
+					AuthLogoutCmd.SetOut(stdout)
+					Expect(AuthLogoutCmd.RunE(AuthLogoutCmd, []string{})).To(Succeed())
+					Expect(stdout).To(Say("Removed cached CSP access tokens"))
+					Expect(filepath.Join(cacheDir, "tokens.json")).ToNot(BeAnExistingFile())
+
+					Expect(GetRefreshToken(nil, []string{})).To(Succeed())
+					Expect(tokenServices.RedeemCallCount()).To(Equal(2))
+				})
+			})
+		})
+	})
+
+	Describe("AuthStatusCmd", func() {
+		var output *outputfakes.FakeFormat
+
+		BeforeEach(func() {
+			output = &outputfakes.FakeFormat{}
+			Output = output
+			Claims = &csp.Claims{
+				StandardClaims: jwt.StandardClaims{
+					ExpiresAt: 1700000000,
+				},
+				ContextName: "my-org-id",
+				Context:     "my-org-context",
+				Username:    "john",
+				Domain:      "example.com",
+				Perms:       []string{csp.RoleOrgOwner, "external/marketplace/publisher"},
+			}
+		})
+
+		It("renders the identity from the token", func() {
+			err := AuthStatusCmd.RunE(AuthStatusCmd, []string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(output.PrintHeaderCallCount()).To(Equal(1))
+			Expect(output.PrintHeaderArgsForCall(0)).To(Equal("Authenticated to the VMware Cloud Services Platform as:"))
+
+			Expect(output.RenderIdentityCallCount()).To(Equal(1))
+			identity := output.RenderIdentityArgsForCall(0)
+			Expect(identity.Username).To(Equal("john@example.com"))
+			Expect(identity.ContextName).To(Equal("my-org-id"))
+			Expect(identity.Context).To(Equal("my-org-context"))
+			Expect(identity.OrgOwner).To(BeTrue())
+			Expect(identity.PlatformOperator).To(BeFalse())
+			Expect(identity.ExpiresAt).To(Equal(time.Unix(1700000000, 0)))
+		})
+
+		When("there is no token", func() {
+			BeforeEach(func() {
+				Claims = nil
+			})
+
+			It("returns an error", func() {
+				err := WhoAmICmd.RunE(WhoAmICmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("not authenticated"))
+			})
+		})
 	})
 })
@@ -5,22 +5,192 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
+// profileSettings maps the settings that can be stored in a profile to their configuration keys
+var profileSettings = map[string]string{
+	"csp-host":       "csp.host",
+	"api-token-env":  "csp.api-token-env",
+	"api-token-file": "csp.api-token-file",
+	"host":           "marketplace.host",
+	"api-host":       "marketplace.api-host",
+	"ui-host":        "marketplace.ui-host",
+	"storage-bucket": "marketplace.storage.bucket",
+	"storage-region": "marketplace.storage.region",
+}
+
+type Profile map[string]string
+
+type ConfigFile struct {
+	CurrentProfile string             `yaml:"current-profile,omitempty"`
+	Profiles       map[string]Profile `yaml:"profiles,omitempty"`
+}
+
+func defaultConfigFilePath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".config", AppName, "config.yaml")
+}
+
+func ReadConfigFile(path string) (*ConfigFile, error) {
+	config := &ConfigFile{}
+	if path == "" {
+		return config, nil
+	}
+
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	err = yaml.Unmarshal(contents, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+func (c *ConfigFile) Write(path string) error {
+	if path == "" {
+		return fmt.Errorf("no config file path set")
+	}
+
+	contents, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return fmt.Errorf("failed to create the directory for config file %s: %w", path, err)
+	}
+
+	err = os.WriteFile(path, contents, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadProfile reads the config file and applies the settings from the selected profile.
+// Flags and environment variables still take precedence over the profile.
+func LoadProfile(cmd *cobra.Command, args []string) error {
+	configFilePath := viper.GetString("config-file")
+	if configFilePath == "" {
+		return nil
+	}
+	if _, err := os.Stat(configFilePath); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	viper.SetConfigFile(configFilePath)
+	viper.SetConfigType("yaml")
+	err := viper.ReadInConfig()
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", configFilePath, err)
+	}
+
+	profileName := viper.GetString("profile")
+	if profileName == "" {
+		profileName = viper.GetString("current-profile")
+	}
+	if profileName == "" {
+		return nil
+	}
+
+	profileKey := "profiles." + profileName
+	if !viper.IsSet(profileKey) {
+		return fmt.Errorf("profile %s does not exist in %s", profileName, configFilePath)
+	}
+
+	settings := map[string]interface{}{}
+	for setting, value := range viper.GetStringMapString(profileKey) {
+		key, ok := profileSettings[setting]
+		if !ok {
+			return fmt.Errorf("unknown setting %s in profile %s", setting, profileName)
+		}
+		setNestedValue(settings, strings.Split(key, "."), value)
+	}
+	return viper.MergeConfigMap(settings)
+}
+
+func setNestedValue(settings map[string]interface{}, path []string, value interface{}) {
+	if len(path) == 1 {
+		settings[path[0]] = value
+		return
+	}
+
+	child, ok := settings[path[0]].(map[string]interface{})
+	if !ok {
+		child = map[string]interface{}{}
+		settings[path[0]] = child
+	}
+	setNestedValue(child, path[1:], value)
+}
+
+func profileSettingNames() []string {
+	var names []string
+	for name := range profileSettings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func init() {
 	rootCmd.AddCommand(ConfigCmd)
 	ConfigCmd.SetOut(ConfigCmd.OutOrStdout())
+	for _, subcommand := range []*cobra.Command{ConfigListCmd, ConfigUseCmd, ConfigGetCmd, ConfigSetCmd} {
+		ConfigCmd.AddCommand(subcommand)
+		subcommand.SetOut(subcommand.OutOrStdout())
+	}
+}
+
+// skipProfile is used by the commands that manage profiles, which must work even if the selected profile does not exist yet
+func skipProfile(cmd *cobra.Command, args []string) {}
+
+// secretSettings are replaced when printing the config, so that it can be shared without leaking credentials
+var secretSettings = []string{"csp.api-token", "csp.refresh-token"}
+
+const redactedValue = "REDACTED"
+
+func redactSecrets(config map[string]interface{}) {
+	for _, key := range secretSettings {
+		section, name, _ := strings.Cut(key, ".")
+		settings, ok := config[section].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, ok := settings[name]; ok && value != "" {
+			settings[name] = redactedValue
+		}
+	}
 }
 
 var ConfigCmd = &cobra.Command{
-	Use:    "config",
-	Long:   "Prints the current config",
-	Hidden: true,
+	Use:               "config",
+	Short:             "Print the current config, or manage configuration profiles",
+	Long:              "Prints the current config, with credentials redacted, or manages the profiles in the config file",
+	Args:              cobra.NoArgs,
+	PersistentPreRunE: LoadProfile,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		config := viper.AllSettings()
+		redactSecrets(config)
 		formattedConfig, err := json.MarshalIndent(config, "", "  ")
 		if err != nil {
 			return err
@@ -29,3 +199,131 @@ var ConfigCmd = &cobra.Command{
 		return nil
 	},
 }
+
+var ConfigListCmd = &cobra.Command{
+	Use:              "list",
+	Short:            "List the profiles in the config file",
+	Long:             "List the profiles in the config file, marking the current profile with *",
+	Args:             cobra.NoArgs,
+	PersistentPreRun: skipProfile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		config, err := ReadConfigFile(viper.GetString("config-file"))
+		if err != nil {
+			return err
+		}
+
+		if len(config.Profiles) == 0 {
+			cmd.Printf("No profiles in %s\n", viper.GetString("config-file"))
+			return nil
+		}
+
+		var names []string
+		for name := range config.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			marker := " "
+			if name == config.CurrentProfile {
+				marker = "*"
+			}
+			cmd.Printf("%s %s\n", marker, name)
+		}
+		return nil
+	},
+}
+
+var ConfigUseCmd = &cobra.Command{
+	Use:              "use PROFILE",
+	Short:            "Set the current profile",
+	Long:             "Set the profile that is used when --profile is not given",
+	Example:          fmt.Sprintf("%s config use staging", AppName),
+	Args:             cobra.ExactArgs(1),
+	PersistentPreRun: skipProfile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		configFilePath := viper.GetString("config-file")
+		config, err := ReadConfigFile(configFilePath)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := config.Profiles[args[0]]; !ok {
+			return fmt.Errorf("profile %s does not exist, please create it with \"%s config set --profile %s\"", args[0], AppName, args[0])
+		}
+
+		config.CurrentProfile = args[0]
+		err = config.Write(configFilePath)
+		if err != nil {
+			return err
+		}
+		cmd.Printf("Now using profile %s\n", args[0])
+		return nil
+	},
+}
+
+var ConfigGetCmd = &cobra.Command{
+	Use:     "get SETTING",
+	Short:   "Print the value of a setting",
+	Long:    fmt.Sprintf("Print the value of a setting, after applying the profile, environment variables and flags.\nSettings: %s", strings.Join(profileSettingNames(), ", ")),
+	Example: fmt.Sprintf("%s config get host", AppName),
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		key, ok := profileSettings[args[0]]
+		if !ok {
+			return fmt.Errorf("unknown setting %s, please use one of %s", args[0], strings.Join(profileSettingNames(), ", "))
+		}
+		cmd.Println(viper.GetString(key))
+		return nil
+	},
+}
+
+var ConfigSetCmd = &cobra.Command{
+	Use:   "set SETTING VALUE",
+	Short: "Set a setting in a profile",
+	Long: fmt.Sprintf("Set a setting in the current profile, or the profile given with --profile. The profile is created if it does not exist.\nSettings: %s",
+		strings.Join(profileSettingNames(), ", ")),
+	Example:          fmt.Sprintf("%s config set --profile staging host gtwstg.market.csp.vmware.com", AppName),
+	Args:             cobra.ExactArgs(2),
+	PersistentPreRun: skipProfile,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		if _, ok := profileSettings[args[0]]; !ok {
+			return fmt.Errorf("unknown setting %s, please use one of %s", args[0], strings.Join(profileSettingNames(), ", "))
+		}
+
+		configFilePath := viper.GetString("config-file")
+		config, err := ReadConfigFile(configFilePath)
+		if err != nil {
+			return err
+		}
+
+		profileName := viper.GetString("profile")
+		if profileName == "" {
+			profileName = config.CurrentProfile
+		}
+		if profileName == "" {
+			return fmt.Errorf("no profile selected, please use the --profile parameter")
+		}
+
+		if config.Profiles == nil {
+			config.Profiles = map[string]Profile{}
+		}
+		if config.Profiles[profileName] == nil {
+			config.Profiles[profileName] = Profile{}
+		}
+		if config.CurrentProfile == "" {
+			config.CurrentProfile = profileName
+		}
+		config.Profiles[profileName][args[0]] = args[1]
+
+		err = config.Write(configFilePath)
+		if err != nil {
+			return err
+		}
+		cmd.Printf("Set %s to %s in profile %s\n", args[0], args[1], profileName)
+		return nil
+	},
+}
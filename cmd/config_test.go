@@ -0,0 +1,181 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vmware-labs/marketplace-cli/v2/cmd"
+)
+
+var _ = Describe("Config", func() {
+	var (
+		configDir      string
+		configFilePath string
+		stdout         *Buffer
+	)
+
+	BeforeEach(func() {
+		var err error
+		configDir, err = os.MkdirTemp("", "mkpcli-test-config")
+		Expect(err).ToNot(HaveOccurred())
+		configFilePath = filepath.Join(configDir, "mkpcli", "config.yaml")
+		viper.Set("config-file", configFilePath)
+		viper.Set("profile", "")
+
+		stdout = NewBuffer()
+		for _, command := range []*cobra.Command{cmd.ConfigCmd, cmd.ConfigListCmd, cmd.ConfigUseCmd, cmd.ConfigGetCmd, cmd.ConfigSetCmd} {
+			command.SetOut(stdout)
+		}
+	})
+
+	AfterEach(func() {
+		viper.Set("config-file", "")
+		viper.Set("profile", "")
+		Expect(os.RemoveAll(configDir)).To(Succeed())
+	})
+
+	Describe("ConfigCmd", func() {
+		AfterEach(func() {
+			viper.Set("csp.refresh-token", "")
+		})
+
+		It("prints the config, without the credentials", func() {
+			viper.Set("csp.refresh-token", "my-refresh-token")
+			viper.Set("marketplace.storage.bucket", "my-bucket")
+			err := cmd.ConfigCmd.RunE(cmd.ConfigCmd, []string{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stdout).To(Say(`"refresh-token": "REDACTED"`))
+			Expect(stdout.Contents()).ToNot(ContainSubstring("my-refresh-token"))
+			Expect(string(stdout.Contents())).To(ContainSubstring(`"bucket": "my-bucket"`))
+		})
+	})
+
+	Describe("ConfigSetCmd", func() {
+		It("creates the profile and makes it the current profile", func() {
+			viper.Set("profile", "staging")
+			err := cmd.ConfigSetCmd.RunE(cmd.ConfigSetCmd, []string{"storage-bucket", "my-staging-bucket"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stdout).To(Say("Set storage-bucket to my-staging-bucket in profile staging"))
+
+			config, err := cmd.ReadConfigFile(configFilePath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.CurrentProfile).To(Equal("staging"))
+			Expect(config.Profiles["staging"]).To(Equal(cmd.Profile{"storage-bucket": "my-staging-bucket"}))
+
+			info, err := os.Stat(configFilePath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		})
+
+		When("the setting is unknown", func() {
+			It("returns an error", func() {
+				viper.Set("profile", "staging")
+				err := cmd.ConfigSetCmd.RunE(cmd.ConfigSetCmd, []string{"favorite-color", "blue"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unknown setting favorite-color, please use one of api-host, api-token-env"))
+			})
+		})
+
+		When("no profile is selected", func() {
+			It("returns an error", func() {
+				err := cmd.ConfigSetCmd.RunE(cmd.ConfigSetCmd, []string{"host", "gtw.example.com"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("no profile selected, please use the --profile parameter"))
+			})
+		})
+	})
+
+	Context("with profiles", func() {
+		BeforeEach(func() {
+			config := &cmd.ConfigFile{
+				CurrentProfile: "production",
+				Profiles: map[string]cmd.Profile{
+					"production": {"storage-region": "my-production-region"},
+					"staging": {
+						"storage-region": "my-staging-region",
+						"api-token-env":  "MY_STAGING_TOKEN",
+					},
+				},
+			}
+			Expect(config.Write(configFilePath)).To(Succeed())
+		})
+
+		Describe("ConfigListCmd", func() {
+			It("lists the profiles", func() {
+				err := cmd.ConfigListCmd.RunE(cmd.ConfigListCmd, []string{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stdout).To(Say("\\* production"))
+				Expect(stdout).To(Say("  staging"))
+			})
+		})
+
+		Describe("ConfigUseCmd", func() {
+			It("changes the current profile", func() {
+				err := cmd.ConfigUseCmd.RunE(cmd.ConfigUseCmd, []string{"staging"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stdout).To(Say("Now using profile staging"))
+
+				config, err := cmd.ReadConfigFile(configFilePath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config.CurrentProfile).To(Equal("staging"))
+			})
+
+			When("the profile does not exist", func() {
+				It("returns an error", func() {
+					err := cmd.ConfigUseCmd.RunE(cmd.ConfigUseCmd, []string{"development"})
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("profile development does not exist, please create it with \"mkpcli config set --profile development\""))
+				})
+			})
+		})
+
+		Describe("LoadProfile", func() {
+			It("applies the current profile", func() {
+				Expect(cmd.LoadProfile(nil, []string{})).To(Succeed())
+				Expect(viper.GetString("marketplace.storage.region")).To(Equal("my-production-region"))
+
+				err := cmd.ConfigGetCmd.RunE(cmd.ConfigGetCmd, []string{"storage-region"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stdout).To(Say("my-production-region"))
+			})
+
+			When("a profile is selected", func() {
+				BeforeEach(func() {
+					viper.Set("profile", "staging")
+					Expect(os.Setenv("MY_STAGING_TOKEN", "my-staging-api-token")).To(Succeed())
+				})
+
+				AfterEach(func() {
+					Expect(os.Unsetenv("MY_STAGING_TOKEN")).To(Succeed())
+				})
+
+				It("applies that profile", func() {
+					Expect(cmd.LoadProfile(nil, []string{})).To(Succeed())
+					Expect(viper.GetString("marketplace.storage.region")).To(Equal("my-staging-region"))
+
+					By("reading the API token from the token source", func() {
+						viper.Set("csp.api-token", "")
+						Expect(cmd.GetAPIToken()).To(Equal("my-staging-api-token"))
+					})
+				})
+			})
+
+			When("the profile does not exist", func() {
+				It("returns an error", func() {
+					viper.Set("profile", "development")
+					err := cmd.LoadProfile(nil, []string{})
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("profile development does not exist in " + configFilePath))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,178 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg"
+)
+
+var (
+	DetachProductSlug    string
+	DetachProductVersion string
+
+	DetachChartName    string
+	DetachChartVersion string
+	DetachChartYes     bool
+
+	DetachContainerImage    string
+	DetachContainerImageTag string
+
+	DetachFile string
+)
+
+func init() {
+	rootCmd.AddCommand(DetachCmd)
+	DetachCmd.AddCommand(DetachChartCmd, DetachContainerImageCmd, DetachMetaFileCmd, DetachOtherCmd, DetachVMCmd)
+
+	for _, command := range []*cobra.Command{DetachChartCmd, DetachContainerImageCmd, DetachMetaFileCmd, DetachOtherCmd, DetachVMCmd} {
+		command.Flags().StringVarP(&DetachProductSlug, "product", "p", "", "Product slug (required)")
+		_ = command.MarkFlagRequired("product")
+		command.Flags().StringVarP(&DetachProductVersion, "product-version", "v", "", "Product version (default to latest version)")
+	}
+
+	DetachChartCmd.Flags().StringVar(&DetachChartName, "chart", "", "Name of the chart to detach (required)")
+	_ = DetachChartCmd.MarkFlagRequired("chart")
+	DetachChartCmd.Flags().StringVar(&DetachChartVersion, "chart-version", "", "Version of the chart to detach (default to every version of the chart on the product version)")
+	DetachChartCmd.Flags().BoolVarP(&DetachChartYes, "yes", "y", false, "Detach more than one chart without asking for confirmation")
+
+	DetachContainerImageCmd.Flags().StringVarP(&DetachContainerImage, "image-repository", "r", "", "Image repository (e.g. registry/repository/image) (required)")
+	_ = DetachContainerImageCmd.MarkFlagRequired("image-repository")
+	DetachContainerImageCmd.Flags().StringVar(&DetachContainerImageTag, "tag", "", "Image repository tag (required)")
+	_ = DetachContainerImageCmd.MarkFlagRequired("tag")
+
+	DetachMetaFileCmd.Flags().StringVar(&DetachFile, "metafile", "", "Name of the meta file to detach (required)")
+	_ = DetachMetaFileCmd.MarkFlagRequired("metafile")
+
+	DetachOtherCmd.Flags().StringVar(&DetachFile, "file", "", "Name of the file to detach (required)")
+	_ = DetachOtherCmd.MarkFlagRequired("file")
+
+	DetachVMCmd.Flags().StringVar(&DetachFile, "file", "", "Name of the virtual machine file to detach (required)")
+	_ = DetachVMCmd.MarkFlagRequired("file")
+}
+
+var DetachCmd = &cobra.Command{
+	Use:       "detach",
+	Short:     "Detach assets from a product",
+	Long:      "Detach assets from a product in the VMware Marketplace",
+	Args:      cobra.OnlyValidArgs,
+	ValidArgs: []string{DetachChartCmd.Use, DetachContainerImageCmd.Use, DetachMetaFileCmd.Use, DetachOtherCmd.Use, DetachVMCmd.Use},
+}
+
+var DetachChartCmd = &cobra.Command{
+	Use:     "chart",
+	Short:   "Detach a chart",
+	Long:    "Detaches a Helm Chart from a product in the VMware Marketplace",
+	Example: fmt.Sprintf("%s detach chart -p hyperspace-database-chart1 -v 1.2.3 --chart hyperspace-db --chart-version 0.1.0", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: GetRefreshToken,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		description := "the chart " + DetachChartName
+		if DetachChartVersion != "" {
+			description = fmt.Sprintf("the chart %s %s", DetachChartName, DetachChartVersion)
+		}
+		return detachAsset(pkg.AssetTypeChart, description, func(product *models.Product, version string) (bool, error) {
+			charts := product.GetChartsByName(version, DetachChartName, DetachChartVersion)
+			if len(charts) > 1 && !DetachChartYes {
+				confirmed, err := Confirm(cmd, fmt.Sprintf("Detach %d versions of the chart %s from %s %s?", len(charts), DetachChartName, product.Slug, version))
+				if err != nil {
+					return false, err
+				}
+				if !confirmed {
+					return false, fmt.Errorf("the chart %s was not detached", DetachChartName)
+				}
+			}
+			return product.RemoveCharts(version, DetachChartName, DetachChartVersion), nil
+		})
+	},
+}
+
+var DetachContainerImageCmd = &cobra.Command{
+	Use:     "image",
+	Short:   "Detach a container image",
+	Long:    "Detaches a container image tag from a product in the VMware Marketplace",
+	Example: fmt.Sprintf("%s detach image -p hyperspace-database-image1 -v 1.2.3 --image-repository hyperspace-labs/hyperspace-db --tag 1.2.3", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: GetRefreshToken,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return detachAsset(pkg.AssetTypeContainerImage, fmt.Sprintf("the image %s:%s", DetachContainerImage, DetachContainerImageTag), func(product *models.Product, version string) (bool, error) {
+			return product.RemoveContainerImage(version, DetachContainerImage, DetachContainerImageTag), nil
+		})
+	},
+}
+
+var DetachMetaFileCmd = &cobra.Command{
+	Use:     "metafile",
+	Short:   "Detach a meta file",
+	Long:    "Detaches a meta file from a product in the VMware Marketplace",
+	Example: fmt.Sprintf("%s detach metafile -p hyperspace-database-vm1 -v 1.2.3 --metafile deploy.sh", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: GetRefreshToken,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return detachAsset(pkg.AssetTypeMetaFile, "the meta file "+DetachFile, func(product *models.Product, version string) (bool, error) {
+			return product.RemoveMetaFile(version, DetachFile), nil
+		})
+	},
+}
+
+var DetachOtherCmd = &cobra.Command{
+	Use:     "other",
+	Short:   "Detach an other file",
+	Long:    "Detaches an other file from a product in the VMware Marketplace",
+	Example: fmt.Sprintf("%s detach other -p hyperspace-database-vm1 -v 1.2.3 --file hyperspace-db-1.2.3.tgz", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: GetRefreshToken,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return detachAsset(pkg.AssetTypeOther, "the file "+DetachFile, func(product *models.Product, version string) (bool, error) {
+			return product.RemoveAddonFile(version, DetachFile), nil
+		})
+	},
+}
+
+var DetachVMCmd = &cobra.Command{
+	Use:     "vm",
+	Short:   "Detach a virtual machine file",
+	Long:    "Detaches a virtual machine file (ISO or OVA) from a product in the VMware Marketplace",
+	Example: fmt.Sprintf("%s detach vm -p hyperspace-database-vm1 -v 1.2.3 --file hyperspace-db-1.2.3.iso", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: GetRefreshToken,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return detachAsset(pkg.AssetTypeVM, "the file "+DetachFile, func(product *models.Product, version string) (bool, error) {
+			return product.RemoveFile(version, DetachFile), nil
+		})
+	},
+}
+
+// detachAsset removes an asset from the product version with the remove function, then updates the product
+func detachAsset(assetType, description string, remove func(product *models.Product, version string) (bool, error)) error {
+	product, version, err := Marketplace.GetProductWithVersion(DetachProductSlug, DetachProductVersion)
+	if err != nil {
+		return err
+	}
+
+	product.PrepForUpdate()
+	removed, err := remove(product, version.Number)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("%s %s does not have %s", product.Slug, version.Number, description)
+	}
+
+	updatedProduct, err := Marketplace.PutProduct(product, false)
+	if err != nil {
+		return handleDryRun(err)
+	}
+
+	Output.PrintHeader(fmt.Sprintf("%s assets for %s %s:", assetType, updatedProduct.DisplayName, version.Number))
+	return Output.RenderAssets(pkg.GetAssetsByType(assetType, updatedProduct, version.Number))
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package cmd_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	"github.com/vmware-labs/marketplace-cli/v2/cmd"
+	"github.com/vmware-labs/marketplace-cli/v2/cmd/output/outputfakes"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg/pkgfakes"
+	"github.com/vmware-labs/marketplace-cli/v2/test"
+)
+
+var _ = Describe("DetachCmd", func() {
+	var (
+		marketplace *pkgfakes.FakeMarketplaceInterface
+		output      *outputfakes.FakeFormat
+		product     *models.Product
+	)
+
+	BeforeEach(func() {
+		marketplace = &pkgfakes.FakeMarketplaceInterface{}
+		cmd.Marketplace = marketplace
+		output = &outputfakes.FakeFormat{}
+		cmd.Output = output
+
+		product = test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeImage)
+		test.AddVersions(product, "1.1.1")
+		test.AddContainerImages(product, "1.1.1", "docker run it",
+			test.CreateFakeContainerImage("docker.io/bitnami/nginx", "1.21.6", "latest"),
+		)
+		product.AddOnFiles = []*models.AddOnFile{test.CreateFakeOtherFile("notes.txt", "1.1.1")}
+		marketplace.GetProductWithVersionReturns(product, product.AllVersions[0], nil)
+		marketplace.PutProductStub = func(product *models.Product, versionUpdate bool) (*models.Product, error) {
+			return product, nil
+		}
+
+		cmd.DetachProductSlug = "my-super-product"
+		cmd.DetachProductVersion = "1.1.1"
+	})
+
+	Describe("DetachContainerImageCmd", func() {
+		BeforeEach(func() {
+			cmd.DetachContainerImage = "docker.io/bitnami/nginx"
+			cmd.DetachContainerImageTag = "latest"
+		})
+
+		It("removes the image tag", func() {
+			err := cmd.DetachContainerImageCmd.RunE(cmd.DetachContainerImageCmd, []string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			By("sending the product without the tag", func() {
+				Expect(marketplace.PutProductCallCount()).To(Equal(1))
+				updatedProduct, versionUpdate := marketplace.PutProductArgsForCall(0)
+				Expect(versionUpdate).To(BeFalse())
+				Expect(updatedProduct.HasContainerImage("1.1.1", "docker.io/bitnami/nginx", "latest")).To(BeFalse())
+				Expect(updatedProduct.HasContainerImage("1.1.1", "docker.io/bitnami/nginx", "1.21.6")).To(BeTrue())
+			})
+
+			By("outputting the remaining images", func() {
+				Expect(output.PrintHeaderArgsForCall(0)).To(Equal("Container Image assets for My Super Product 1.1.1:"))
+				assets := output.RenderAssetsArgsForCall(0)
+				Expect(assets).To(HaveLen(1))
+				Expect(assets[0].DisplayName).To(Equal("docker.io/bitnami/nginx:1.21.6"))
+			})
+		})
+
+		When("the image is not attached", func() {
+			BeforeEach(func() {
+				cmd.DetachContainerImageTag = "1.0.0"
+			})
+
+			It("returns an error", func() {
+				err := cmd.DetachContainerImageCmd.RunE(cmd.DetachContainerImageCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("my-super-product 1.1.1 does not have the image docker.io/bitnami/nginx:1.0.0"))
+				Expect(marketplace.PutProductCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("DetachOtherCmd", func() {
+		BeforeEach(func() {
+			cmd.DetachFile = "notes.txt"
+		})
+
+		It("removes the file", func() {
+			err := cmd.DetachOtherCmd.RunE(cmd.DetachOtherCmd, []string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			updatedProduct, _ := marketplace.PutProductArgsForCall(0)
+			Expect(updatedProduct.GetAddonFilesForVersion("1.1.1")).To(BeEmpty())
+			Expect(output.RenderAssetsArgsForCall(0)).To(BeEmpty())
+		})
+
+		When("updating the product fails", func() {
+			BeforeEach(func() {
+				marketplace.PutProductStub = nil
+				marketplace.PutProductReturns(nil, errors.New("put product failed"))
+			})
+
+			It("returns an error", func() {
+				err := cmd.DetachOtherCmd.RunE(cmd.DetachOtherCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("put product failed"))
+			})
+		})
+	})
+
+	Describe("DetachChartCmd", func() {
+		var stderr *Buffer
+
+		BeforeEach(func() {
+			product.ChartVersions = []*models.ChartVersion{
+				{AppVersion: "1.1.1", Version: "0.1.0", Repo: &models.Repo{Name: "hyperspace-db"}},
+				{AppVersion: "1.1.1", Version: "0.2.0", Repo: &models.Repo{Name: "hyperspace-db"}},
+				{AppVersion: "1.1.1", Version: "0.1.0", Repo: &models.Repo{Name: "hyperspace-ui"}},
+			}
+			cmd.DetachChartName = "hyperspace-db"
+			cmd.DetachChartVersion = "0.1.0"
+			cmd.DetachChartYes = false
+
+			stderr = NewBuffer()
+			cmd.DetachChartCmd.SetErr(stderr)
+			cmd.DetachChartCmd.SetIn(NewBuffer())
+		})
+
+		It("removes the chart with that name and version", func() {
+			err := cmd.DetachChartCmd.RunE(cmd.DetachChartCmd, []string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			updatedProduct, _ := marketplace.PutProductArgsForCall(0)
+			charts := updatedProduct.GetChartsForVersion("1.1.1")
+			Expect(charts).To(HaveLen(2))
+			Expect(charts[0].Repo.Name).To(Equal("hyperspace-db"))
+			Expect(charts[0].Version).To(Equal("0.2.0"))
+			Expect(charts[1].Repo.Name).To(Equal("hyperspace-ui"))
+			Expect(stderr.Contents()).To(BeEmpty())
+		})
+
+		When("the chart is not attached", func() {
+			BeforeEach(func() {
+				cmd.DetachChartVersion = "0.3.0"
+			})
+
+			It("returns an error", func() {
+				err := cmd.DetachChartCmd.RunE(cmd.DetachChartCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("my-super-product 1.1.1 does not have the chart hyperspace-db 0.3.0"))
+				Expect(marketplace.PutProductCallCount()).To(Equal(0))
+			})
+		})
+
+		When("no chart version is given, and more than one version of the chart is attached", func() {
+			BeforeEach(func() {
+				cmd.DetachChartVersion = ""
+			})
+
+			It("asks for confirmation", func() {
+				cmd.DetachChartCmd.SetIn(BufferWithBytes([]byte("y\n")))
+				err := cmd.DetachChartCmd.RunE(cmd.DetachChartCmd, []string{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stderr).To(Say(`Detach 2 versions of the chart hyperspace-db from my-super-product 1.1.1\? \[y/N\]: `))
+
+				updatedProduct, _ := marketplace.PutProductArgsForCall(0)
+				charts := updatedProduct.GetChartsForVersion("1.1.1")
+				Expect(charts).To(HaveLen(1))
+				Expect(charts[0].Repo.Name).To(Equal("hyperspace-ui"))
+			})
+
+			It("does not detach the charts if the answer is no", func() {
+				cmd.DetachChartCmd.SetIn(BufferWithBytes([]byte("n\n")))
+				err := cmd.DetachChartCmd.RunE(cmd.DetachChartCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("the chart hyperspace-db was not detached"))
+				Expect(marketplace.PutProductCallCount()).To(Equal(0))
+			})
+
+			It("does not ask with --yes", func() {
+				cmd.DetachChartYes = true
+				err := cmd.DetachChartCmd.RunE(cmd.DetachChartCmd, []string{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stderr.Contents()).To(BeEmpty())
+				Expect(marketplace.PutProductCallCount()).To(Equal(1))
+			})
+		})
+	})
+})
@@ -5,10 +5,15 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 	"github.com/vmware-labs/marketplace-cli/v2/pkg"
+	"golang.org/x/term"
 )
 
 var (
@@ -17,6 +22,10 @@ var (
 	DownloadFilter         string
 	DownloadFilename       string
 	DownloadAcceptEULA     bool
+	DownloadResume         bool
+	DownloadAll            bool
+	DownloadOutputDir      string
+	DownloadParallelism    int
 )
 
 func init() {
@@ -29,6 +38,10 @@ func init() {
 	DownloadCmd.Flags().StringVarP(&AssetType, "type", "t", "", "Filter assets by type (one of "+strings.Join(assetTypesList(), ", ")+")")
 	DownloadCmd.Flags().StringVarP(&DownloadFilename, "filename", "f", "", "Output file name")
 	DownloadCmd.Flags().BoolVar(&DownloadAcceptEULA, "accept-eula", false, "Accept the product EULA")
+	DownloadCmd.Flags().BoolVar(&DownloadResume, "resume", false, "Resume a previously interrupted download")
+	DownloadCmd.Flags().BoolVar(&DownloadAll, "all", false, "Download all matching assets")
+	DownloadCmd.Flags().StringVar(&DownloadOutputDir, "output-dir", ".", "Directory to save the assets when using --all")
+	DownloadCmd.Flags().IntVar(&DownloadParallelism, "parallel", 4, "Number of assets to download at the same time when using --all")
 }
 
 func filterAssets(filter string, assets []*pkg.Asset) []*pkg.Asset {
@@ -41,6 +54,17 @@ func filterAssets(filter string, assets []*pkg.Asset) []*pkg.Asset {
 	return filteredAssets
 }
 
+// downloadableAssets removes the assets that cannot be downloaded through the Marketplace, like blueprints
+func downloadableAssets(assets []*pkg.Asset) []*pkg.Asset {
+	var downloadable []*pkg.Asset
+	for _, asset := range assets {
+		if asset.DownloadRequestPayload != nil {
+			downloadable = append(downloadable, asset)
+		}
+	}
+	return downloadable
+}
+
 var DownloadCmd = &cobra.Command{
 	Use:     "download",
 	Short:   "Download an asset from a product",
@@ -60,12 +84,22 @@ var DownloadCmd = &cobra.Command{
 			assetType = assetTypeMapping[AssetType] + " "
 		}
 		var asset *pkg.Asset
-		assets := pkg.GetAssetsByType(assetTypeMapping[AssetType], product, version.Number)
+		assets := downloadableAssets(pkg.GetAssetsByType(assetTypeMapping[AssetType], product, version.Number))
 		if len(assets) == 0 {
 			return fmt.Errorf("product %s %s does not have any downloadable %sassets", product.Slug, version.Number, assetType)
 		}
 
-		if DownloadFilter == "" {
+		if DownloadAll {
+			if DownloadFilename != "" {
+				return fmt.Errorf("--filename cannot be used with --all, please use --output-dir instead")
+			}
+			if DownloadFilter != "" {
+				assets = filterAssets(DownloadFilter, assets)
+				if len(assets) == 0 {
+					return fmt.Errorf("product %s %s does not have any downloadable %sassets that match the filter \"%s\", please adjust the --filter parameter", product.Slug, version.Number, assetType, DownloadFilter)
+				}
+			}
+		} else if DownloadFilter == "" {
 			asset = assets[0]
 			if len(assets) > 1 {
 				_ = Output.RenderAssets(assets)
@@ -84,11 +118,6 @@ var DownloadCmd = &cobra.Command{
 			}
 		}
 
-		filename := asset.Filename
-		if DownloadFilename != "" {
-			filename = DownloadFilename
-		}
-
 		if !DownloadAcceptEULA && !product.EulaDetails.Signed {
 			cmd.PrintErrln("The EULA must be accepted before downloading")
 			if product.EulaDetails.Text != "" {
@@ -99,7 +128,135 @@ var DownloadCmd = &cobra.Command{
 			return fmt.Errorf("please review the EULA and re-run with --accept-eula")
 		}
 
+		if DownloadAll {
+			return downloadAllAssets(cmd, assets)
+		}
+
+		filename := asset.Filename
+		if DownloadFilename != "" {
+			filename = DownloadFilename
+		}
+
 		asset.DownloadRequestPayload.EulaAccepted = DownloadAcceptEULA
-		return Marketplace.Download(filename, asset.DownloadRequestPayload)
+		return Marketplace.Download(filename, asset, DownloadResume)
 	},
 }
+
+func downloadAllAssets(cmd *cobra.Command, assets []*pkg.Asset) error {
+	err := os.MkdirAll(DownloadOutputDir, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create the output directory %s: %w", DownloadOutputDir, err)
+	}
+
+	workers := DownloadParallelism
+	if workers < 1 {
+		workers = 1
+	}
+	printResults := false
+	if workers > 1 {
+		if isTerminal(cmd.ErrOrStderr()) {
+			Marketplace.EnableMultipleProgressBars()
+		} else {
+			// Progress bars can't be redrawn in place outside a terminal, so print a line as each file finishes instead
+			Marketplace.DisableProgressBars()
+			printResults = true
+		}
+	}
+	printLock := sync.Mutex{}
+
+	filenames := uniqueFilenames(assets)
+	results := make([]*pkg.DownloadResult, len(assets))
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				result := &pkg.DownloadResult{
+					Asset:    assets[index],
+					Filename: filenames[index],
+					Status:   pkg.DownloadStatusSucceeded,
+				}
+				err := Marketplace.Download(filepath.Join(DownloadOutputDir, filenames[index]), assets[index], DownloadResume)
+				if err != nil {
+					result.Status = pkg.DownloadStatusFailed
+					result.Error = err.Error()
+				}
+				results[index] = result
+
+				if printResults {
+					printLock.Lock()
+					if result.Status == pkg.DownloadStatusFailed {
+						cmd.PrintErrf("Failed to download %s: %s\n", result.Filename, result.Error)
+					} else {
+						cmd.PrintErrf("Downloaded %s\n", result.Filename)
+					}
+					printLock.Unlock()
+				}
+			}
+		}()
+	}
+
+	for index, asset := range assets {
+		if !asset.Downloadable {
+			reason := "not yet available"
+			if asset.Error != "" {
+				reason = asset.Error
+			}
+			results[index] = &pkg.DownloadResult{
+				Asset:    asset,
+				Filename: filenames[index],
+				Status:   pkg.DownloadStatusSkipped,
+				Error:    reason,
+			}
+			continue
+		}
+
+		asset.DownloadRequestPayload.EulaAccepted = DownloadAcceptEULA
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == pkg.DownloadStatusFailed {
+			failed += 1
+		}
+	}
+
+	Output.PrintHeader(fmt.Sprintf("Downloaded assets to %s:", DownloadOutputDir))
+	err = Output.RenderDownloadResults(results)
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d assets failed to download", failed, len(assets))
+	}
+	return nil
+}
+
+// uniqueFilenames picks a filename for each asset, numbering any that would overwrite another asset
+func uniqueFilenames(assets []*pkg.Asset) []string {
+	used := map[string]bool{}
+	var filenames []string
+	for _, asset := range assets {
+		filename := asset.Filename
+		extension := filepath.Ext(filename)
+		base := strings.TrimSuffix(filename, extension)
+		for count := 2; used[filename]; count++ {
+			filename = fmt.Sprintf("%s-%d%s", base, count, extension)
+		}
+		used[filename] = true
+		filenames = append(filenames, filename)
+	}
+	return filenames
+}
+
+// isTerminal reports whether the output is an interactive terminal, where progress bars can be redrawn in place
+func isTerminal(output io.Writer) bool {
+	file, ok := output.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
@@ -4,7 +4,10 @@
 package cmd_test
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo"
@@ -13,6 +16,7 @@ import (
 	"github.com/vmware-labs/marketplace-cli/v2/cmd"
 	"github.com/vmware-labs/marketplace-cli/v2/cmd/output/outputfakes"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg"
 	"github.com/vmware-labs/marketplace-cli/v2/pkg/pkgfakes"
 	"github.com/vmware-labs/marketplace-cli/v2/test"
 )
@@ -58,6 +62,10 @@ var _ = Describe("DownloadCmd", func() {
 
 		cmd.DownloadFilename = ""
 		cmd.DownloadFilter = ""
+		cmd.DownloadResume = false
+		cmd.DownloadAll = false
+		cmd.DownloadOutputDir = "."
+		cmd.DownloadParallelism = 4
 		cmd.AssetType = ""
 	})
 
@@ -74,12 +82,29 @@ var _ = Describe("DownloadCmd", func() {
 
 		By("downloading the asset", func() {
 			Expect(marketplace.DownloadCallCount()).To(Equal(1))
-			filename, assetPayload := marketplace.DownloadArgsForCall(0)
+			filename, asset, resume := marketplace.DownloadArgsForCall(0)
 			Expect(filename).To(Equal("my-db.ova"))
+			assetPayload := asset.DownloadRequestPayload
 			Expect(assetPayload.ProductId).To(Equal(productId))
 			Expect(assetPayload.AppVersion).To(Equal("1.1.1"))
 			Expect(assetPayload.EulaAccepted).To(BeTrue())
 			Expect(assetPayload.DeploymentFileId).ToNot(BeEmpty())
+			Expect(resume).To(BeFalse())
+		})
+	})
+
+	When("resuming the download", func() {
+		It("passes the resume option along", func() {
+			cmd.DownloadProductSlug = "my-super-product"
+			cmd.DownloadProductVersion = "1.1.1"
+			cmd.DownloadAcceptEULA = true
+			cmd.DownloadResume = true
+			err := cmd.DownloadCmd.RunE(cmd.DownloadCmd, []string{""})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(marketplace.DownloadCallCount()).To(Equal(1))
+			_, _, resume := marketplace.DownloadArgsForCall(0)
+			Expect(resume).To(BeTrue())
 		})
 	})
 
@@ -133,7 +158,7 @@ var _ = Describe("DownloadCmd", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(marketplace.DownloadCallCount()).To(Equal(1))
-			filename, _ := marketplace.DownloadArgsForCall(0)
+			filename, _, _ := marketplace.DownloadArgsForCall(0)
 			Expect(filename).To(Equal("overridden-filename.ova"))
 		})
 	})
@@ -229,7 +254,8 @@ var _ = Describe("DownloadCmd", func() {
 
 			By("downloading the chosen asset", func() {
 				Expect(marketplace.DownloadCallCount()).To(Equal(1))
-				filename, assetPayload := marketplace.DownloadArgsForCall(0)
+				filename, asset, _ := marketplace.DownloadArgsForCall(0)
+				assetPayload := asset.DownloadRequestPayload
 				Expect(filename).To(Equal("bbb.txt"))
 				Expect(assetPayload.ProductId).To(Equal(productId))
 				Expect(assetPayload.AppVersion).To(Equal("3.3.3"))
@@ -293,7 +319,8 @@ var _ = Describe("DownloadCmd", func() {
 
 			By("downloading the chosen asset", func() {
 				Expect(marketplace.DownloadCallCount()).To(Equal(1))
-				filename, assetPayload := marketplace.DownloadArgsForCall(0)
+				filename, asset, _ := marketplace.DownloadArgsForCall(0)
+				assetPayload := asset.DownloadRequestPayload
 				Expect(filename).To(Equal("deploy.sh"))
 				Expect(assetPayload.ProductId).To(Equal(productId))
 				Expect(assetPayload.AppVersion).To(Equal("4.4.4"))
@@ -303,4 +330,120 @@ var _ = Describe("DownloadCmd", func() {
 			})
 		})
 	})
+
+	Context("Downloading all assets", func() {
+		var outputDir string
+
+		BeforeEach(func() {
+			var err error
+			outputDir, err = os.MkdirTemp("", "mkpcli-test-download")
+			Expect(err).ToNot(HaveOccurred())
+
+			product.ProductDeploymentFiles[3].Status = models.DeploymentStatusInactive
+			product.ProductDeploymentFiles = append(product.ProductDeploymentFiles, test.CreateFakeOVA("aaa.txt", "3.3.3"))
+
+			cmd.DownloadProductSlug = "my-super-product"
+			cmd.DownloadProductVersion = "3.3.3"
+			cmd.DownloadAcceptEULA = true
+			cmd.DownloadAll = true
+			cmd.DownloadOutputDir = filepath.Join(outputDir, "assets")
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(outputDir)).To(Succeed())
+		})
+
+		It("downloads every downloadable asset", func() {
+			err := cmd.DownloadCmd.RunE(cmd.DownloadCmd, []string{""})
+			Expect(err).ToNot(HaveOccurred())
+
+			By("creating the output directory", func() {
+				Expect(filepath.Join(outputDir, "assets")).To(BeADirectory())
+			})
+
+			By("downloading each asset to a unique file", func() {
+				Expect(marketplace.DownloadCallCount()).To(Equal(3))
+				var filenames []string
+				for i := 0; i < marketplace.DownloadCallCount(); i++ {
+					filename, asset, _ := marketplace.DownloadArgsForCall(i)
+					Expect(asset.DownloadRequestPayload.EulaAccepted).To(BeTrue())
+					filenames = append(filenames, filename)
+				}
+				Expect(filenames).To(ConsistOf(
+					filepath.Join(outputDir, "assets", "aaa.txt"),
+					filepath.Join(outputDir, "assets", "bbb.txt"),
+					filepath.Join(outputDir, "assets", "aaa-2.txt"),
+				))
+			})
+
+			By("printing a summary", func() {
+				Expect(output.RenderDownloadResultsCallCount()).To(Equal(1))
+				results := output.RenderDownloadResultsArgsForCall(0)
+				Expect(results).To(HaveLen(4))
+				Expect(results[0].Status).To(Equal(pkg.DownloadStatusSucceeded))
+				Expect(results[1].Status).To(Equal(pkg.DownloadStatusSucceeded))
+				Expect(results[2].Status).To(Equal(pkg.DownloadStatusSkipped))
+				Expect(results[2].Asset.DisplayName).To(Equal("ccc.txt"))
+				Expect(results[2].Filename).To(Equal("ccc.txt"))
+				Expect(results[3].Status).To(Equal(pkg.DownloadStatusSucceeded))
+				Expect(results[3].Filename).To(Equal("aaa-2.txt"))
+			})
+		})
+
+		It("prints a line for each finished download instead of progress bars, because stderr is not a terminal", func() {
+			stderr := NewBuffer()
+			cmd.DownloadCmd.SetErr(stderr)
+			err := cmd.DownloadCmd.RunE(cmd.DownloadCmd, []string{""})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(marketplace.DisableProgressBarsCallCount()).To(Equal(1))
+			Expect(stderr.Contents()).To(ContainSubstring("Downloaded aaa.txt\n"))
+			Expect(stderr.Contents()).To(ContainSubstring("Downloaded bbb.txt\n"))
+			Expect(stderr.Contents()).To(ContainSubstring("Downloaded aaa-2.txt\n"))
+		})
+
+		When("downloading one asset at a time", func() {
+			BeforeEach(func() {
+				cmd.DownloadParallelism = 1
+			})
+
+			It("keeps the progress bars", func() {
+				err := cmd.DownloadCmd.RunE(cmd.DownloadCmd, []string{""})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(marketplace.DisableProgressBarsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("some downloads fail", func() {
+			BeforeEach(func() {
+				marketplace.DownloadStub = func(filename string, asset *pkg.Asset, resume bool) error {
+					if asset.DisplayName == "bbb.txt" {
+						return errors.New("download failed")
+					}
+					return nil
+				}
+			})
+
+			It("downloads the rest and returns an error", func() {
+				err := cmd.DownloadCmd.RunE(cmd.DownloadCmd, []string{""})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("1 of 4 assets failed to download"))
+
+				Expect(marketplace.DownloadCallCount()).To(Equal(3))
+				results := output.RenderDownloadResultsArgsForCall(0)
+				Expect(results[1].Status).To(Equal(pkg.DownloadStatusFailed))
+				Expect(results[1].Error).To(Equal("download failed"))
+			})
+		})
+
+		When("a filename is given", func() {
+			It("returns an error", func() {
+				cmd.DownloadFilename = "my-file.txt"
+				err := cmd.DownloadCmd.RunE(cmd.DownloadCmd, []string{""})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("--filename cannot be used with --all, please use --output-dir instead"))
+				Expect(marketplace.DownloadCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
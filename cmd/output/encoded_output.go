@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/vmware-labs/marketplace-cli/v2/internal/csp"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
 	"github.com/vmware-labs/marketplace-cli/v2/pkg"
 	"gopkg.in/yaml.v3"
@@ -70,6 +71,10 @@ func (o *EncodedOutput) RenderContainerImages(images []*models.DockerVersionList
 	return o.Print(images)
 }
 
+func (o *EncodedOutput) RenderBlueprints(blueprints []*models.ProductBlueprintDetails) error {
+	return o.Print(blueprints)
+}
+
 func (o *EncodedOutput) RenderFile(file *models.ProductDeploymentFile) error {
 	return o.Print(file)
 }
@@ -81,3 +86,15 @@ func (o *EncodedOutput) RenderFiles(files []*models.ProductDeploymentFile) error
 func (o *EncodedOutput) RenderAssets(assets []*pkg.Asset) error {
 	return o.Print(assets)
 }
+
+func (o *EncodedOutput) RenderChanges(changes []*pkg.FieldChange) error {
+	return o.Print(changes)
+}
+
+func (o *EncodedOutput) RenderDownloadResults(results []*pkg.DownloadResult) error {
+	return o.Print(results)
+}
+
+func (o *EncodedOutput) RenderIdentity(identity *csp.Identity) error {
+	return o.Print(identity)
+}
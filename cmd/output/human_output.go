@@ -9,8 +9,10 @@ import (
 	"io"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/csp"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
 	"github.com/vmware-labs/marketplace-cli/v2/pkg"
 	"jaytaylor.com/html2text"
@@ -209,6 +211,27 @@ func (o *HumanOutput) RenderContainerImages(images []*models.DockerVersionList)
 	return nil
 }
 
+func (o *HumanOutput) RenderBlueprints(blueprints []*models.ProductBlueprintDetails) error {
+	total := 0
+	table := o.NewTable("Name", "Status", "VRA Version", "Files", "Images", "Prerequisites")
+	for _, blueprint := range blueprints {
+		for _, blueprintFile := range blueprint.BlueprintFiles {
+			total += 1
+			table.Append([]string{
+				blueprintFile.Title,
+				blueprintFile.Status,
+				blueprintFile.VRAVersion,
+				strconv.Itoa(len(blueprintFile.Files)),
+				strconv.Itoa(len(blueprintFile.Images)),
+				strings.Join(blueprint.Prerequisites, ", "),
+			})
+		}
+	}
+	table.Render()
+	o.Printf("Total count: %d\n", total)
+	return nil
+}
+
 func (o *HumanOutput) RenderFile(file *models.ProductDeploymentFile) error {
 	footnotes := ""
 	table := o.NewTable("ID", "Name", "Status", "Size", "Type", "Files", "Downloads")
@@ -312,6 +335,71 @@ func (o *HumanOutput) RenderAssets(assets []*pkg.Asset) error {
 	return nil
 }
 
+func (o *HumanOutput) RenderChanges(changes []*pkg.FieldChange) error {
+	if len(changes) == 0 {
+		o.Println("No changes")
+		return nil
+	}
+
+	table := o.NewTable("Field", "Current", "New")
+	for _, change := range changes {
+		table.Append([]string{change.Field, ChangeValueString(change.Old), ChangeValueString(change.New)})
+	}
+	table.Render()
+	o.Printf("Total count: %d\n", len(changes))
+	return nil
+}
+
+func (o *HumanOutput) RenderDownloadResults(results []*pkg.DownloadResult) error {
+	counts := map[string]int{}
+	table := o.NewTable("Name", "Type", "File", "Status")
+	for _, result := range results {
+		status := result.Status
+		if result.Error != "" {
+			status += ": " + result.Error
+		}
+		table.Append([]string{result.Asset.DisplayName, result.Asset.Type, result.Filename, status})
+		counts[result.Status] += 1
+	}
+	table.Render()
+	o.Printf("Succeeded: %d, Skipped: %d, Failed: %d\n", counts[pkg.DownloadStatusSucceeded], counts[pkg.DownloadStatusSkipped], counts[pkg.DownloadStatusFailed])
+	return nil
+}
+
+func (o *HumanOutput) RenderIdentity(identity *csp.Identity) error {
+	table := o.NewTable("Username", "Org", "Org Owner", "Platform Operator", "Expires")
+	table.Append([]string{
+		identity.Username,
+		identity.ContextName,
+		strconv.FormatBool(identity.OrgOwner),
+		strconv.FormatBool(identity.PlatformOperator),
+		identity.ExpiresAt.Local().Format(time.RFC1123),
+	})
+	table.Render()
+
+	o.Println()
+	o.Printf("Org context: %s\n", identity.Context)
+	o.Println("Permissions:")
+	for _, perm := range identity.Perms {
+		o.Printf("  %s\n", perm)
+	}
+	return nil
+}
+
+func ChangeValueString(value interface{}) string {
+	if value == nil {
+		return "(none)"
+	}
+	if stringValue, ok := value.(string); ok {
+		return stringValue
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
+
 func LatestVersionString(product *models.Product) string {
 	if version := product.GetLatestVersion(); version != nil {
 		return version.Number
@@ -4,6 +4,7 @@
 package output
 
 import (
+	"github.com/vmware-labs/marketplace-cli/v2/internal/csp"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
 	"github.com/vmware-labs/marketplace-cli/v2/pkg"
 )
@@ -26,8 +27,12 @@ type Format interface {
 	RenderChart(chart *models.ChartVersion) error
 	RenderCharts(charts []*models.ChartVersion) error
 	RenderContainerImages(images []*models.DockerVersionList) error
+	RenderBlueprints(blueprints []*models.ProductBlueprintDetails) error
 	RenderFile(file *models.ProductDeploymentFile) error
 	RenderFiles(files []*models.ProductDeploymentFile) error
 
 	RenderAssets(assets []*pkg.Asset) error
+	RenderChanges(changes []*pkg.FieldChange) error
+	RenderDownloadResults(results []*pkg.DownloadResult) error
+	RenderIdentity(identity *csp.Identity) error
 }
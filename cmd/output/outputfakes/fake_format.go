@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/vmware-labs/marketplace-cli/v2/cmd/output"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/csp"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
 	"github.com/vmware-labs/marketplace-cli/v2/pkg"
 )
@@ -26,6 +27,28 @@ type FakeFormat struct {
 	renderAssetsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RenderBlueprintsStub        func([]*models.ProductBlueprintDetails) error
+	renderBlueprintsMutex       sync.RWMutex
+	renderBlueprintsArgsForCall []struct {
+		arg1 []*models.ProductBlueprintDetails
+	}
+	renderBlueprintsReturns struct {
+		result1 error
+	}
+	renderBlueprintsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RenderChangesStub        func([]*pkg.FieldChange) error
+	renderChangesMutex       sync.RWMutex
+	renderChangesArgsForCall []struct {
+		arg1 []*pkg.FieldChange
+	}
+	renderChangesReturns struct {
+		result1 error
+	}
+	renderChangesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RenderChartStub        func(*models.ChartVersion) error
 	renderChartMutex       sync.RWMutex
 	renderChartArgsForCall []struct {
@@ -59,6 +82,17 @@ type FakeFormat struct {
 	renderContainerImagesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RenderDownloadResultsStub        func([]*pkg.DownloadResult) error
+	renderDownloadResultsMutex       sync.RWMutex
+	renderDownloadResultsArgsForCall []struct {
+		arg1 []*pkg.DownloadResult
+	}
+	renderDownloadResultsReturns struct {
+		result1 error
+	}
+	renderDownloadResultsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RenderFileStub        func(*models.ProductDeploymentFile) error
 	renderFileMutex       sync.RWMutex
 	renderFileArgsForCall []struct {
@@ -81,6 +115,17 @@ type FakeFormat struct {
 	renderFilesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RenderIdentityStub        func(*csp.Identity) error
+	renderIdentityMutex       sync.RWMutex
+	renderIdentityArgsForCall []struct {
+		arg1 *csp.Identity
+	}
+	renderIdentityReturns struct {
+		result1 error
+	}
+	renderIdentityReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RenderProductStub        func(*models.Product, *models.Version) error
 	renderProductMutex       sync.RWMutex
 	renderProductArgsForCall []struct {
@@ -124,9 +169,10 @@ func (fake *FakeFormat) PrintHeader(arg1 string) {
 	fake.printHeaderArgsForCall = append(fake.printHeaderArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.PrintHeaderStub
 	fake.recordInvocation("PrintHeader", []interface{}{arg1})
 	fake.printHeaderMutex.Unlock()
-	if fake.PrintHeaderStub != nil {
+	if stub != nil {
 		fake.PrintHeaderStub(arg1)
 	}
 }
@@ -161,15 +207,16 @@ func (fake *FakeFormat) RenderAssets(arg1 []*pkg.Asset) error {
 	fake.renderAssetsArgsForCall = append(fake.renderAssetsArgsForCall, struct {
 		arg1 []*pkg.Asset
 	}{arg1Copy})
+	stub := fake.RenderAssetsStub
+	fakeReturns := fake.renderAssetsReturns
 	fake.recordInvocation("RenderAssets", []interface{}{arg1Copy})
 	fake.renderAssetsMutex.Unlock()
-	if fake.RenderAssetsStub != nil {
-		return fake.RenderAssetsStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.renderAssetsReturns
 	return fakeReturns.result1
 }
 
@@ -215,21 +262,154 @@ func (fake *FakeFormat) RenderAssetsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeFormat) RenderBlueprints(arg1 []*models.ProductBlueprintDetails) error {
+	var arg1Copy []*models.ProductBlueprintDetails
+	if arg1 != nil {
+		arg1Copy = make([]*models.ProductBlueprintDetails, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.renderBlueprintsMutex.Lock()
+	ret, specificReturn := fake.renderBlueprintsReturnsOnCall[len(fake.renderBlueprintsArgsForCall)]
+	fake.renderBlueprintsArgsForCall = append(fake.renderBlueprintsArgsForCall, struct {
+		arg1 []*models.ProductBlueprintDetails
+	}{arg1Copy})
+	stub := fake.RenderBlueprintsStub
+	fakeReturns := fake.renderBlueprintsReturns
+	fake.recordInvocation("RenderBlueprints", []interface{}{arg1Copy})
+	fake.renderBlueprintsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFormat) RenderBlueprintsCallCount() int {
+	fake.renderBlueprintsMutex.RLock()
+	defer fake.renderBlueprintsMutex.RUnlock()
+	return len(fake.renderBlueprintsArgsForCall)
+}
+
+func (fake *FakeFormat) RenderBlueprintsCalls(stub func([]*models.ProductBlueprintDetails) error) {
+	fake.renderBlueprintsMutex.Lock()
+	defer fake.renderBlueprintsMutex.Unlock()
+	fake.RenderBlueprintsStub = stub
+}
+
+func (fake *FakeFormat) RenderBlueprintsArgsForCall(i int) []*models.ProductBlueprintDetails {
+	fake.renderBlueprintsMutex.RLock()
+	defer fake.renderBlueprintsMutex.RUnlock()
+	argsForCall := fake.renderBlueprintsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeFormat) RenderBlueprintsReturns(result1 error) {
+	fake.renderBlueprintsMutex.Lock()
+	defer fake.renderBlueprintsMutex.Unlock()
+	fake.RenderBlueprintsStub = nil
+	fake.renderBlueprintsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFormat) RenderBlueprintsReturnsOnCall(i int, result1 error) {
+	fake.renderBlueprintsMutex.Lock()
+	defer fake.renderBlueprintsMutex.Unlock()
+	fake.RenderBlueprintsStub = nil
+	if fake.renderBlueprintsReturnsOnCall == nil {
+		fake.renderBlueprintsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.renderBlueprintsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFormat) RenderChanges(arg1 []*pkg.FieldChange) error {
+	var arg1Copy []*pkg.FieldChange
+	if arg1 != nil {
+		arg1Copy = make([]*pkg.FieldChange, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.renderChangesMutex.Lock()
+	ret, specificReturn := fake.renderChangesReturnsOnCall[len(fake.renderChangesArgsForCall)]
+	fake.renderChangesArgsForCall = append(fake.renderChangesArgsForCall, struct {
+		arg1 []*pkg.FieldChange
+	}{arg1Copy})
+	stub := fake.RenderChangesStub
+	fakeReturns := fake.renderChangesReturns
+	fake.recordInvocation("RenderChanges", []interface{}{arg1Copy})
+	fake.renderChangesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFormat) RenderChangesCallCount() int {
+	fake.renderChangesMutex.RLock()
+	defer fake.renderChangesMutex.RUnlock()
+	return len(fake.renderChangesArgsForCall)
+}
+
+func (fake *FakeFormat) RenderChangesCalls(stub func([]*pkg.FieldChange) error) {
+	fake.renderChangesMutex.Lock()
+	defer fake.renderChangesMutex.Unlock()
+	fake.RenderChangesStub = stub
+}
+
+func (fake *FakeFormat) RenderChangesArgsForCall(i int) []*pkg.FieldChange {
+	fake.renderChangesMutex.RLock()
+	defer fake.renderChangesMutex.RUnlock()
+	argsForCall := fake.renderChangesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeFormat) RenderChangesReturns(result1 error) {
+	fake.renderChangesMutex.Lock()
+	defer fake.renderChangesMutex.Unlock()
+	fake.RenderChangesStub = nil
+	fake.renderChangesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFormat) RenderChangesReturnsOnCall(i int, result1 error) {
+	fake.renderChangesMutex.Lock()
+	defer fake.renderChangesMutex.Unlock()
+	fake.RenderChangesStub = nil
+	if fake.renderChangesReturnsOnCall == nil {
+		fake.renderChangesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.renderChangesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeFormat) RenderChart(arg1 *models.ChartVersion) error {
 	fake.renderChartMutex.Lock()
 	ret, specificReturn := fake.renderChartReturnsOnCall[len(fake.renderChartArgsForCall)]
 	fake.renderChartArgsForCall = append(fake.renderChartArgsForCall, struct {
 		arg1 *models.ChartVersion
 	}{arg1})
+	stub := fake.RenderChartStub
+	fakeReturns := fake.renderChartReturns
 	fake.recordInvocation("RenderChart", []interface{}{arg1})
 	fake.renderChartMutex.Unlock()
-	if fake.RenderChartStub != nil {
-		return fake.RenderChartStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.renderChartReturns
 	return fakeReturns.result1
 }
 
@@ -286,15 +466,16 @@ func (fake *FakeFormat) RenderCharts(arg1 []*models.ChartVersion) error {
 	fake.renderChartsArgsForCall = append(fake.renderChartsArgsForCall, struct {
 		arg1 []*models.ChartVersion
 	}{arg1Copy})
+	stub := fake.RenderChartsStub
+	fakeReturns := fake.renderChartsReturns
 	fake.recordInvocation("RenderCharts", []interface{}{arg1Copy})
 	fake.renderChartsMutex.Unlock()
-	if fake.RenderChartsStub != nil {
-		return fake.RenderChartsStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.renderChartsReturns
 	return fakeReturns.result1
 }
 
@@ -351,15 +532,16 @@ func (fake *FakeFormat) RenderContainerImages(arg1 []*models.DockerVersionList)
 	fake.renderContainerImagesArgsForCall = append(fake.renderContainerImagesArgsForCall, struct {
 		arg1 []*models.DockerVersionList
 	}{arg1Copy})
+	stub := fake.RenderContainerImagesStub
+	fakeReturns := fake.renderContainerImagesReturns
 	fake.recordInvocation("RenderContainerImages", []interface{}{arg1Copy})
 	fake.renderContainerImagesMutex.Unlock()
-	if fake.RenderContainerImagesStub != nil {
-		return fake.RenderContainerImagesStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.renderContainerImagesReturns
 	return fakeReturns.result1
 }
 
@@ -405,21 +587,88 @@ func (fake *FakeFormat) RenderContainerImagesReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeFormat) RenderDownloadResults(arg1 []*pkg.DownloadResult) error {
+	var arg1Copy []*pkg.DownloadResult
+	if arg1 != nil {
+		arg1Copy = make([]*pkg.DownloadResult, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.renderDownloadResultsMutex.Lock()
+	ret, specificReturn := fake.renderDownloadResultsReturnsOnCall[len(fake.renderDownloadResultsArgsForCall)]
+	fake.renderDownloadResultsArgsForCall = append(fake.renderDownloadResultsArgsForCall, struct {
+		arg1 []*pkg.DownloadResult
+	}{arg1Copy})
+	stub := fake.RenderDownloadResultsStub
+	fakeReturns := fake.renderDownloadResultsReturns
+	fake.recordInvocation("RenderDownloadResults", []interface{}{arg1Copy})
+	fake.renderDownloadResultsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFormat) RenderDownloadResultsCallCount() int {
+	fake.renderDownloadResultsMutex.RLock()
+	defer fake.renderDownloadResultsMutex.RUnlock()
+	return len(fake.renderDownloadResultsArgsForCall)
+}
+
+func (fake *FakeFormat) RenderDownloadResultsCalls(stub func([]*pkg.DownloadResult) error) {
+	fake.renderDownloadResultsMutex.Lock()
+	defer fake.renderDownloadResultsMutex.Unlock()
+	fake.RenderDownloadResultsStub = stub
+}
+
+func (fake *FakeFormat) RenderDownloadResultsArgsForCall(i int) []*pkg.DownloadResult {
+	fake.renderDownloadResultsMutex.RLock()
+	defer fake.renderDownloadResultsMutex.RUnlock()
+	argsForCall := fake.renderDownloadResultsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeFormat) RenderDownloadResultsReturns(result1 error) {
+	fake.renderDownloadResultsMutex.Lock()
+	defer fake.renderDownloadResultsMutex.Unlock()
+	fake.RenderDownloadResultsStub = nil
+	fake.renderDownloadResultsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFormat) RenderDownloadResultsReturnsOnCall(i int, result1 error) {
+	fake.renderDownloadResultsMutex.Lock()
+	defer fake.renderDownloadResultsMutex.Unlock()
+	fake.RenderDownloadResultsStub = nil
+	if fake.renderDownloadResultsReturnsOnCall == nil {
+		fake.renderDownloadResultsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.renderDownloadResultsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeFormat) RenderFile(arg1 *models.ProductDeploymentFile) error {
 	fake.renderFileMutex.Lock()
 	ret, specificReturn := fake.renderFileReturnsOnCall[len(fake.renderFileArgsForCall)]
 	fake.renderFileArgsForCall = append(fake.renderFileArgsForCall, struct {
 		arg1 *models.ProductDeploymentFile
 	}{arg1})
+	stub := fake.RenderFileStub
+	fakeReturns := fake.renderFileReturns
 	fake.recordInvocation("RenderFile", []interface{}{arg1})
 	fake.renderFileMutex.Unlock()
-	if fake.RenderFileStub != nil {
-		return fake.RenderFileStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.renderFileReturns
 	return fakeReturns.result1
 }
 
@@ -476,15 +725,16 @@ func (fake *FakeFormat) RenderFiles(arg1 []*models.ProductDeploymentFile) error
 	fake.renderFilesArgsForCall = append(fake.renderFilesArgsForCall, struct {
 		arg1 []*models.ProductDeploymentFile
 	}{arg1Copy})
+	stub := fake.RenderFilesStub
+	fakeReturns := fake.renderFilesReturns
 	fake.recordInvocation("RenderFiles", []interface{}{arg1Copy})
 	fake.renderFilesMutex.Unlock()
-	if fake.RenderFilesStub != nil {
-		return fake.RenderFilesStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.renderFilesReturns
 	return fakeReturns.result1
 }
 
@@ -530,6 +780,67 @@ func (fake *FakeFormat) RenderFilesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeFormat) RenderIdentity(arg1 *csp.Identity) error {
+	fake.renderIdentityMutex.Lock()
+	ret, specificReturn := fake.renderIdentityReturnsOnCall[len(fake.renderIdentityArgsForCall)]
+	fake.renderIdentityArgsForCall = append(fake.renderIdentityArgsForCall, struct {
+		arg1 *csp.Identity
+	}{arg1})
+	stub := fake.RenderIdentityStub
+	fakeReturns := fake.renderIdentityReturns
+	fake.recordInvocation("RenderIdentity", []interface{}{arg1})
+	fake.renderIdentityMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFormat) RenderIdentityCallCount() int {
+	fake.renderIdentityMutex.RLock()
+	defer fake.renderIdentityMutex.RUnlock()
+	return len(fake.renderIdentityArgsForCall)
+}
+
+func (fake *FakeFormat) RenderIdentityCalls(stub func(*csp.Identity) error) {
+	fake.renderIdentityMutex.Lock()
+	defer fake.renderIdentityMutex.Unlock()
+	fake.RenderIdentityStub = stub
+}
+
+func (fake *FakeFormat) RenderIdentityArgsForCall(i int) *csp.Identity {
+	fake.renderIdentityMutex.RLock()
+	defer fake.renderIdentityMutex.RUnlock()
+	argsForCall := fake.renderIdentityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeFormat) RenderIdentityReturns(result1 error) {
+	fake.renderIdentityMutex.Lock()
+	defer fake.renderIdentityMutex.Unlock()
+	fake.RenderIdentityStub = nil
+	fake.renderIdentityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFormat) RenderIdentityReturnsOnCall(i int, result1 error) {
+	fake.renderIdentityMutex.Lock()
+	defer fake.renderIdentityMutex.Unlock()
+	fake.RenderIdentityStub = nil
+	if fake.renderIdentityReturnsOnCall == nil {
+		fake.renderIdentityReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.renderIdentityReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeFormat) RenderProduct(arg1 *models.Product, arg2 *models.Version) error {
 	fake.renderProductMutex.Lock()
 	ret, specificReturn := fake.renderProductReturnsOnCall[len(fake.renderProductArgsForCall)]
@@ -537,15 +848,16 @@ func (fake *FakeFormat) RenderProduct(arg1 *models.Product, arg2 *models.Version
 		arg1 *models.Product
 		arg2 *models.Version
 	}{arg1, arg2})
+	stub := fake.RenderProductStub
+	fakeReturns := fake.renderProductReturns
 	fake.recordInvocation("RenderProduct", []interface{}{arg1, arg2})
 	fake.renderProductMutex.Unlock()
-	if fake.RenderProductStub != nil {
-		return fake.RenderProductStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.renderProductReturns
 	return fakeReturns.result1
 }
 
@@ -602,15 +914,16 @@ func (fake *FakeFormat) RenderProducts(arg1 []*models.Product) error {
 	fake.renderProductsArgsForCall = append(fake.renderProductsArgsForCall, struct {
 		arg1 []*models.Product
 	}{arg1Copy})
+	stub := fake.RenderProductsStub
+	fakeReturns := fake.renderProductsReturns
 	fake.recordInvocation("RenderProducts", []interface{}{arg1Copy})
 	fake.renderProductsMutex.Unlock()
-	if fake.RenderProductsStub != nil {
-		return fake.RenderProductsStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.renderProductsReturns
 	return fakeReturns.result1
 }
 
@@ -662,15 +975,16 @@ func (fake *FakeFormat) RenderVersions(arg1 *models.Product) error {
 	fake.renderVersionsArgsForCall = append(fake.renderVersionsArgsForCall, struct {
 		arg1 *models.Product
 	}{arg1})
+	stub := fake.RenderVersionsStub
+	fakeReturns := fake.renderVersionsReturns
 	fake.recordInvocation("RenderVersions", []interface{}{arg1})
 	fake.renderVersionsMutex.Unlock()
-	if fake.RenderVersionsStub != nil {
-		return fake.RenderVersionsStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.renderVersionsReturns
 	return fakeReturns.result1
 }
 
@@ -723,16 +1037,24 @@ func (fake *FakeFormat) Invocations() map[string][][]interface{} {
 	defer fake.printHeaderMutex.RUnlock()
 	fake.renderAssetsMutex.RLock()
 	defer fake.renderAssetsMutex.RUnlock()
+	fake.renderBlueprintsMutex.RLock()
+	defer fake.renderBlueprintsMutex.RUnlock()
+	fake.renderChangesMutex.RLock()
+	defer fake.renderChangesMutex.RUnlock()
 	fake.renderChartMutex.RLock()
 	defer fake.renderChartMutex.RUnlock()
 	fake.renderChartsMutex.RLock()
 	defer fake.renderChartsMutex.RUnlock()
 	fake.renderContainerImagesMutex.RLock()
 	defer fake.renderContainerImagesMutex.RUnlock()
+	fake.renderDownloadResultsMutex.RLock()
+	defer fake.renderDownloadResultsMutex.RUnlock()
 	fake.renderFileMutex.RLock()
 	defer fake.renderFileMutex.RUnlock()
 	fake.renderFilesMutex.RLock()
 	defer fake.renderFilesMutex.RUnlock()
+	fake.renderIdentityMutex.RLock()
+	defer fake.renderIdentityMutex.RUnlock()
 	fake.renderProductMutex.RLock()
 	defer fake.renderProductMutex.RUnlock()
 	fake.renderProductsMutex.RLock()
@@ -0,0 +1,159 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+)
+
+var (
+	VersionDetails      string
+	VersionInstructions string
+	VersionTag          string
+	VersionArchiveYes   bool
+)
+
+func init() {
+	ProductCmd.AddCommand(ProductVersionCmd)
+	ProductVersionCmd.AddCommand(CreateVersionCmd, SetVersionCmd, ArchiveVersionCmd)
+
+	CreateVersionCmd.Flags().StringVarP(&ProductSlug, "product", "p", "", "Product slug (required)")
+	_ = CreateVersionCmd.MarkFlagRequired("product")
+	CreateVersionCmd.Flags().StringVarP(&ProductVersion, "product-version", "v", "", "Version number to create (required)")
+	_ = CreateVersionCmd.MarkFlagRequired("product-version")
+	CreateVersionCmd.Flags().StringVar(&VersionDetails, "details", "", "Version details, such as release notes")
+	CreateVersionCmd.Flags().StringVar(&VersionInstructions, "instructions", "", "Version instructions")
+	CreateVersionCmd.Flags().StringVar(&VersionTag, "tag", "", "Version tag")
+
+	SetVersionCmd.Flags().StringVarP(&ProductSlug, "product", "p", "", "Product slug (required)")
+	_ = SetVersionCmd.MarkFlagRequired("product")
+	SetVersionCmd.Flags().StringVarP(&ProductVersion, "product-version", "v", "", "Version number (required)")
+	_ = SetVersionCmd.MarkFlagRequired("product-version")
+	SetVersionCmd.Flags().StringVar(&VersionDetails, "details", "", "Version details, such as release notes")
+	SetVersionCmd.Flags().StringVar(&VersionInstructions, "instructions", "", "Version instructions")
+
+	ArchiveVersionCmd.Flags().StringVarP(&ProductSlug, "product", "p", "", "Product slug (required)")
+	_ = ArchiveVersionCmd.MarkFlagRequired("product")
+	ArchiveVersionCmd.Flags().StringVarP(&ProductVersion, "product-version", "v", "", "Version number to archive (required)")
+	_ = ArchiveVersionCmd.MarkFlagRequired("product-version")
+	ArchiveVersionCmd.Flags().BoolVarP(&VersionArchiveYes, "yes", "y", false, "Archive the version without asking for confirmation")
+}
+
+var ProductVersionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Manage product versions",
+	Long:  "Create, modify and archive the versions of a product in the VMware Marketplace",
+	Args:  cobra.OnlyValidArgs,
+}
+
+var CreateVersionCmd = &cobra.Command{
+	Use:     "create",
+	Short:   "Create a product version",
+	Long:    "Add a new version to a product, without attaching any assets",
+	Example: fmt.Sprintf("%s product version create -p hyperspace-database -v 1.2.3 --details \"Faster queries\"", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: GetRefreshToken,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		product, err := Marketplace.GetProduct(ProductSlug)
+		if err != nil {
+			return err
+		}
+
+		if product.HasVersion(ProductVersion) {
+			return fmt.Errorf("product \"%s\" already has version %s", product.Slug, ProductVersion)
+		}
+
+		version := product.NewVersion(ProductVersion)
+		version.Details = VersionDetails
+		version.Instructions = VersionInstructions
+		version.Tag = VersionTag
+
+		product.PrepForUpdate()
+		updatedProduct, err := Marketplace.PutProduct(product, version.IsNewVersion)
+		if err != nil {
+			return handleDryRun(err)
+		}
+
+		return renderVersions(updatedProduct)
+	},
+}
+
+var SetVersionCmd = &cobra.Command{
+	Use:     "set",
+	Short:   "Modify a product version",
+	Long:    "Modify the details and instructions of a product version",
+	Example: fmt.Sprintf("%s product version set -p hyperspace-database -v 1.2.3 --instructions \"helm install it\"", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: GetRefreshToken,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if VersionDetails == "" && VersionInstructions == "" {
+			return fmt.Errorf("nothing specified to set")
+		}
+		cmd.SilenceUsage = true
+
+		product, version, err := Marketplace.GetProductWithVersion(ProductSlug, ProductVersion)
+		if err != nil {
+			return err
+		}
+
+		if VersionDetails != "" {
+			version.Details = VersionDetails
+		}
+		if VersionInstructions != "" {
+			version.Instructions = VersionInstructions
+		}
+
+		product.PrepForUpdate()
+		updatedProduct, err := Marketplace.PutProduct(product, false)
+		if err != nil {
+			return handleDryRun(err)
+		}
+
+		return renderVersions(updatedProduct)
+	},
+}
+
+var ArchiveVersionCmd = &cobra.Command{
+	Use:     "archive",
+	Short:   "Archive a product version",
+	Long:    "Archive a product version, so that it is no longer available in the VMware Marketplace",
+	Example: fmt.Sprintf("%s product version archive -p hyperspace-database -v 1.2.3", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: GetRefreshToken,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		product, version, err := Marketplace.GetProductWithVersion(ProductSlug, ProductVersion)
+		if err != nil {
+			return err
+		}
+
+		if !VersionArchiveYes {
+			confirmed, err := Confirm(cmd, fmt.Sprintf("Archive version %s of %s?", version.Number, product.DisplayName))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return fmt.Errorf("version %s was not archived", version.Number)
+			}
+		}
+
+		product.PrepForUpdate()
+		updatedProduct, err := Marketplace.ArchiveVersion(product, version)
+		if err != nil {
+			return handleDryRun(err)
+		}
+
+		return renderVersions(updatedProduct)
+	},
+}
+
+func renderVersions(product *models.Product) error {
+	models.Sort(product.AllVersions)
+	Output.PrintHeader(fmt.Sprintf("Versions for %s:", product.DisplayName))
+	return Output.RenderVersions(product)
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package cmd_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	"github.com/vmware-labs/marketplace-cli/v2/cmd"
+	"github.com/vmware-labs/marketplace-cli/v2/cmd/output/outputfakes"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg/pkgfakes"
+	"github.com/vmware-labs/marketplace-cli/v2/test"
+)
+
+var _ = Describe("ProductVersionCmd", func() {
+	var (
+		marketplace *pkgfakes.FakeMarketplaceInterface
+		output      *outputfakes.FakeFormat
+		product     *models.Product
+	)
+
+	BeforeEach(func() {
+		marketplace = &pkgfakes.FakeMarketplaceInterface{}
+		cmd.Marketplace = marketplace
+		output = &outputfakes.FakeFormat{}
+		cmd.Output = output
+
+		product = test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeChart)
+		test.AddVersions(product, "1.0.0")
+		marketplace.GetProductReturns(product, nil)
+		marketplace.GetProductWithVersionReturns(product, product.AllVersions[0], nil)
+		marketplace.PutProductStub = func(product *models.Product, versionUpdate bool) (*models.Product, error) {
+			return product, nil
+		}
+		marketplace.ArchiveVersionStub = func(product *models.Product, version *models.Version) (*models.Product, error) {
+			return product, nil
+		}
+
+		cmd.ProductSlug = "my-super-product"
+		cmd.VersionDetails = ""
+		cmd.VersionInstructions = ""
+		cmd.VersionTag = ""
+		cmd.VersionArchiveYes = false
+	})
+
+	Describe("CreateVersionCmd", func() {
+		BeforeEach(func() {
+			cmd.ProductVersion = "2.0.0"
+			cmd.VersionDetails = "Faster queries"
+			cmd.VersionInstructions = "helm install it"
+			cmd.VersionTag = "stable"
+		})
+
+		It("creates the version", func() {
+			err := cmd.CreateVersionCmd.RunE(cmd.CreateVersionCmd, []string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			By("sending the product with the new version", func() {
+				Expect(marketplace.PutProductCallCount()).To(Equal(1))
+				updatedProduct, versionUpdate := marketplace.PutProductArgsForCall(0)
+				Expect(versionUpdate).To(BeTrue())
+				Expect(updatedProduct.CurrentVersion).To(Equal("2.0.0"))
+				version := updatedProduct.GetVersion("2.0.0")
+				Expect(version).ToNot(BeNil())
+				Expect(version.Details).To(Equal("Faster queries"))
+				Expect(version.Instructions).To(Equal("helm install it"))
+				Expect(version.Tag).To(Equal("stable"))
+			})
+
+			By("outputting the versions", func() {
+				Expect(output.PrintHeaderArgsForCall(0)).To(Equal("Versions for My Super Product:"))
+				Expect(output.RenderVersionsCallCount()).To(Equal(1))
+			})
+		})
+
+		When("the version already exists", func() {
+			BeforeEach(func() {
+				cmd.ProductVersion = "1.0.0"
+			})
+
+			It("returns an error", func() {
+				err := cmd.CreateVersionCmd.RunE(cmd.CreateVersionCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("product \"my-super-product\" already has version 1.0.0"))
+				Expect(marketplace.PutProductCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("SetVersionCmd", func() {
+		BeforeEach(func() {
+			cmd.ProductVersion = "1.0.0"
+			cmd.VersionDetails = "Updated release notes"
+		})
+
+		It("updates the version", func() {
+			err := cmd.SetVersionCmd.RunE(cmd.SetVersionCmd, []string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(marketplace.PutProductCallCount()).To(Equal(1))
+			updatedProduct, versionUpdate := marketplace.PutProductArgsForCall(0)
+			Expect(versionUpdate).To(BeFalse())
+			version := updatedProduct.GetVersion("1.0.0")
+			Expect(version.Details).To(Equal("Updated release notes"))
+			Expect(output.RenderVersionsCallCount()).To(Equal(1))
+		})
+
+		When("nothing is set", func() {
+			BeforeEach(func() {
+				cmd.VersionDetails = ""
+			})
+
+			It("returns an error", func() {
+				err := cmd.SetVersionCmd.RunE(cmd.SetVersionCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("nothing specified to set"))
+			})
+		})
+	})
+
+	Describe("ArchiveVersionCmd", func() {
+		var stderr *Buffer
+
+		BeforeEach(func() {
+			cmd.ProductVersion = "1.0.0"
+			stderr = NewBuffer()
+			cmd.ArchiveVersionCmd.SetErr(stderr)
+		})
+
+		When("the user confirms", func() {
+			BeforeEach(func() {
+				cmd.ArchiveVersionCmd.SetIn(strings.NewReader("y\n"))
+			})
+
+			It("archives the version", func() {
+				err := cmd.ArchiveVersionCmd.RunE(cmd.ArchiveVersionCmd, []string{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stderr).To(Say(`Archive version 1.0.0 of My Super Product\? \[y/N\]: `))
+
+				Expect(marketplace.ArchiveVersionCallCount()).To(Equal(1))
+				updatedProduct, version := marketplace.ArchiveVersionArgsForCall(0)
+				Expect(updatedProduct.Slug).To(Equal("my-super-product"))
+				Expect(version.Number).To(Equal("1.0.0"))
+				Expect(output.RenderVersionsCallCount()).To(Equal(1))
+			})
+		})
+
+		When("the user does not confirm", func() {
+			BeforeEach(func() {
+				cmd.ArchiveVersionCmd.SetIn(strings.NewReader("\n"))
+			})
+
+			It("does not archive the version", func() {
+				err := cmd.ArchiveVersionCmd.RunE(cmd.ArchiveVersionCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("version 1.0.0 was not archived"))
+				Expect(marketplace.ArchiveVersionCallCount()).To(Equal(0))
+			})
+		})
+
+		When("using --yes", func() {
+			BeforeEach(func() {
+				cmd.VersionArchiveYes = true
+			})
+
+			It("does not ask for confirmation", func() {
+				err := cmd.ArchiveVersionCmd.RunE(cmd.ArchiveVersionCmd, []string{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stderr.Contents()).To(BeEmpty())
+				Expect(marketplace.ArchiveVersionCallCount()).To(Equal(1))
+			})
+		})
+
+		When("archiving fails", func() {
+			BeforeEach(func() {
+				cmd.VersionArchiveYes = true
+				marketplace.ArchiveVersionStub = nil
+				marketplace.ArchiveVersionReturns(nil, errors.New("archive version failed"))
+			})
+
+			It("returns an error", func() {
+				err := cmd.ArchiveVersionCmd.RunE(cmd.ArchiveVersionCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("archive version failed"))
+			})
+		})
+	})
+})
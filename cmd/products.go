@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -19,6 +20,34 @@ var (
 	ListProductsOrgId     string
 	ListProductSearchText string
 	SetOSLFile            string
+	SetPatchFile          string
+	SetSummary            string
+	SetDescription        string
+	SetHighlights         []string
+	SetTags               []string
+	SetCategories         []string
+	SetSupportURL         string
+	SetSupportEmails      []string
+	SetSupportPhones      []string
+	SetEULAText           string
+	SetEULAURL            string
+	SetLogoFile           string
+	SetECCN               string
+	SetHTSNumber          string
+	SetCCATSNumber        string
+	SetEncryption         []string
+	SetSourceCodeURL      string
+	CreateSpecFile        string
+	CreateName            string
+	CreateSlug            string
+	CreateSummary         string
+	CreateDescription     string
+	CreateOrgID           string
+	CreateEULAText        string
+	CreateEULAURL         string
+	CreateLogoFile        string
+	ApplySpecFile         string
+	ExportOutputDir       string
 )
 
 func init() {
@@ -28,6 +57,9 @@ func init() {
 	ProductCmd.AddCommand(ListAssetsCmd)
 	ProductCmd.AddCommand(ListProductVersionsCmd)
 	ProductCmd.AddCommand(SetCmd)
+	ProductCmd.AddCommand(ApplyCmd)
+	ProductCmd.AddCommand(CreateProductCmd)
+	ProductCmd.AddCommand(ExportCmd)
 
 	ListProductsCmd.Flags().StringVar(&ListProductSearchText, "search-text", "", "Filter product list by text")
 	ListProductsCmd.Flags().BoolVarP(&ListProductsAllOrgs, "all-orgs", "a", false, "Show published products from all organizations")
@@ -50,6 +82,43 @@ func init() {
 	SetCmd.Flags().StringVarP(&ProductVersion, "product-version", "v", "", "Product version (required)")
 	_ = SetCmd.MarkFlagRequired("product-version")
 	SetCmd.Flags().StringVar(&SetOSLFile, "osl-file", "", "File with OSL disclosures")
+	SetCmd.Flags().StringVarP(&SetPatchFile, "patch-file", "f", "", "YAML or JSON file with product fields to set, applied before the other parameters (see \"product apply\")")
+	SetCmd.Flags().StringVar(&SetSummary, "summary", "", "Product summary")
+	SetCmd.Flags().StringVar(&SetDescription, "description", "", "Product description")
+	SetCmd.Flags().StringArrayVar(&SetHighlights, "highlight", nil, "Product highlight, replaces the existing highlights (can be repeated)")
+	SetCmd.Flags().StringArrayVar(&SetTags, "tag", nil, "Product tag, replaces the existing tags (can be repeated)")
+	SetCmd.Flags().StringArrayVar(&SetCategories, "category", nil, "Product category, replaces the existing categories (can be repeated)")
+	SetCmd.Flags().StringVar(&SetSupportURL, "support-url", "", "Support URL")
+	SetCmd.Flags().StringSliceVar(&SetSupportEmails, "support-email", nil, "Support email address, replaces the existing addresses (can be repeated)")
+	SetCmd.Flags().StringSliceVar(&SetSupportPhones, "support-phone", nil, "Support phone number, replaces the existing numbers (can be repeated)")
+	SetCmd.Flags().StringVar(&SetEULAText, "eula-text", "", "EULA text")
+	SetCmd.Flags().StringVar(&SetEULAURL, "eula-url", "", "EULA URL")
+	SetCmd.Flags().StringVar(&SetLogoFile, "logo", "", "Image file to upload as the product logo")
+	SetCmd.Flags().StringVar(&SetECCN, "eccn", "", "Export Control Classification Number (ECCN)")
+	SetCmd.Flags().StringVar(&SetHTSNumber, "hts-number", "", "Harmonized Tariff Schedule (HTS) number")
+	SetCmd.Flags().StringVar(&SetCCATSNumber, "ccats-number", "", "Commodity Classification Automated Tracking System (CCATS) number")
+	SetCmd.Flags().StringSliceVar(&SetEncryption, "encryption", nil, "Encryption used by the product, replaces the existing list (can be repeated)")
+	SetCmd.Flags().StringVar(&SetSourceCodeURL, "source-code-url", "", "URL of the source code package")
+
+	CreateProductCmd.Flags().StringVarP(&CreateSpecFile, "file", "f", "", "YAML or JSON file with the product spec, which the other parameters override")
+	CreateProductCmd.Flags().StringVar(&CreateName, "name", "", "Product display name (required, unless set in the product spec)")
+	CreateProductCmd.Flags().StringVar(&CreateSlug, "slug", "", "Product slug (default is assigned by the Marketplace)")
+	CreateProductCmd.Flags().StringVarP(&SolutionType, "type", "t", "", "Product type (required, unless set in the product spec. One of "+strings.Join(solutionTypesList(), ", ")+")")
+	CreateProductCmd.Flags().StringVar(&CreateSummary, "summary", "", "Product summary")
+	CreateProductCmd.Flags().StringVar(&CreateDescription, "description", "", "Product description")
+	CreateProductCmd.Flags().StringVar(&CreateOrgID, "org-id", "", "Publisher organization id (default to the organization of the CSP API token)")
+	CreateProductCmd.Flags().StringVar(&CreateEULAText, "eula-text", "", "EULA text")
+	CreateProductCmd.Flags().StringVar(&CreateEULAURL, "eula-url", "", "EULA URL")
+	CreateProductCmd.Flags().StringVar(&CreateLogoFile, "logo", "", "Image file to upload as the product logo")
+
+	ApplyCmd.Flags().StringVarP(&ApplySpecFile, "file", "f", "", "YAML or JSON file with the product spec (required)")
+	_ = ApplyCmd.MarkFlagRequired("file")
+	ApplyCmd.Flags().StringVarP(&ProductSlug, "product", "p", "", "Product slug (default to the slug in the product spec)")
+	ApplyCmd.Flags().StringVarP(&ProductVersion, "product-version", "v", "", "Product version (default to latest version)")
+
+	ExportCmd.Flags().StringVarP(&ProductSlug, "product", "p", "", "Product slug (required)")
+	_ = ExportCmd.MarkFlagRequired("product")
+	ExportCmd.Flags().StringVarP(&ExportOutputDir, "output-dir", "d", "", "Directory to write the exported files (default to the product slug)")
 }
 
 var ProductCmd = &cobra.Command{
@@ -170,43 +239,357 @@ var ListProductVersionsCmd = &cobra.Command{
 }
 
 var SetCmd = &cobra.Command{
-	Use:     "set",
-	Short:   "Modify product details",
-	Long:    "Modify fields in a given product",
+	Use:   "set",
+	Short: "Modify product details",
+	Long: "Modify fields in a given product, from parameters or a patch file.\n" +
+		"Parameters that take lists replace the whole list in the product.",
+	Example: fmt.Sprintf("%s product set -p hyperspace-database -v 1.2.3 --summary \"The best database\" --tag database --tag nosql --logo logo.png", AppName),
 	Args:    cobra.NoArgs,
 	PreRunE: GetRefreshToken,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if SetOSLFile == "" {
+		if !productSetFlagsGiven() {
 			return fmt.Errorf("nothing specified to set")
 		}
 		cmd.SilenceUsage = true
 
-		product, _, err := Marketplace.GetProductWithVersion(ProductSlug, ProductVersion)
+		product, version, err := Marketplace.GetProductWithVersion(ProductSlug, ProductVersion)
 		if err != nil {
 			return err
 		}
+
+		if SetPatchFile != "" {
+			spec, err := pkg.LoadProductSpec(SetPatchFile)
+			if err != nil {
+				return err
+			}
+			product, err = spec.ApplyTo(product)
+			if err != nil {
+				return err
+			}
+		}
 		product.PrepForUpdate()
 
-		if SetOSLFile != "" {
+		if SetOSLFile != "" || SetLogoFile != "" {
 			uploader, err := Marketplace.GetUploader(product.PublisherDetails.OrgId)
 			if err != nil {
 				return err
 			}
-			_, oslUrl, err := uploader.UploadMediaFile(SetOSLFile)
+
+			if SetOSLFile != "" {
+				_, oslUrl, err := uploader.UploadMediaFile(SetOSLFile)
+				if err != nil {
+					return err
+				}
+				if product.OpenSourceDisclosure == nil {
+					product.OpenSourceDisclosure = &models.OpenSourceDisclosureURLS{}
+				}
+				product.OpenSourceDisclosure.LicenseDisclosureURL = oslUrl
+			}
+
+			if SetLogoFile != "" {
+				_, logoUrl, err := uploader.UploadMediaFile(SetLogoFile)
+				if err != nil {
+					return err
+				}
+				product.Logo = logoUrl
+				product.ProductLogo = &models.Logo{URL: logoUrl}
+			}
+		}
+
+		applyProductSetFlags(product)
+
+		updatedProduct, err := Marketplace.PutProduct(product, false)
+		if err != nil {
+			return handleDryRun(err)
+		}
+
+		return Output.RenderProduct(updatedProduct, version)
+	},
+}
+
+func productSetFlagsGiven() bool {
+	for _, value := range []string{SetOSLFile, SetPatchFile, SetSummary, SetDescription, SetSupportURL, SetEULAText, SetEULAURL, SetLogoFile, SetECCN, SetHTSNumber, SetCCATSNumber, SetSourceCodeURL} {
+		if value != "" {
+			return true
+		}
+	}
+	for _, values := range [][]string{SetHighlights, SetTags, SetCategories, SetSupportEmails, SetSupportPhones, SetEncryption} {
+		if values != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// applyProductSetFlags updates the product with the values given to "product set", other than the files to upload
+func applyProductSetFlags(product *models.Product) {
+	if SetSummary != "" || SetDescription != "" {
+		if product.Description == nil {
+			product.Description = &models.Description{}
+		}
+		if SetSummary != "" {
+			product.Description.Summary = SetSummary
+		}
+		if SetDescription != "" {
+			product.Description.Description = SetDescription
+		}
+	}
+
+	if SetHighlights != nil {
+		product.Highlights = SetHighlights
+	}
+	if SetTags != nil {
+		product.Tags = SetTags
+	}
+	if SetCategories != nil {
+		product.Categories = SetCategories
+	}
+
+	if SetSupportURL != "" || SetSupportEmails != nil || SetSupportPhones != nil {
+		if product.SupportDetails == nil {
+			product.SupportDetails = &models.SupportDetails{}
+		}
+		if SetSupportURL != "" {
+			product.SupportDetails.Url = SetSupportURL
+		}
+		if SetSupportEmails != nil {
+			product.SupportDetails.Email = SetSupportEmails
+		}
+		if SetSupportPhones != nil {
+			product.SupportDetails.PhoneNumber = SetSupportPhones
+		}
+	}
+
+	if SetEULAText != "" || SetEULAURL != "" {
+		if product.EulaDetails == nil {
+			product.EulaDetails = &models.EULADetails{}
+		}
+		if SetEULAText != "" {
+			product.EulaDetails.Text = SetEULAText
+		}
+		if SetEULAURL != "" {
+			product.EulaDetails.Url = SetEULAURL
+		}
+	}
+
+	if SetECCN != "" || SetHTSNumber != "" || SetCCATSNumber != "" {
+		if product.ExportCompliance == nil {
+			product.ExportCompliance = &models.ProductExportCompliance{}
+		}
+		if SetECCN != "" {
+			product.ExportCompliance.Eccn = SetECCN
+		}
+		if SetHTSNumber != "" {
+			product.ExportCompliance.HtsNumber = SetHTSNumber
+		}
+		if SetCCATSNumber != "" {
+			product.ExportCompliance.CcatsNumber = SetCCATSNumber
+		}
+	}
+
+	if SetEncryption != nil {
+		if product.EncryptionDetails == nil {
+			product.EncryptionDetails = &models.ProductEncryptionDetails{}
+		}
+		product.EncryptionDetails.List = SetEncryption
+		product.Encryption = &models.ProductEncryption{List: map[string]bool{}}
+		for _, key := range SetEncryption {
+			product.Encryption.List[key] = true
+		}
+	}
+
+	if SetSourceCodeURL != "" {
+		if product.OpenSourceDisclosure == nil {
+			product.OpenSourceDisclosure = &models.OpenSourceDisclosureURLS{}
+		}
+		product.OpenSourceDisclosure.SourceCodePackageURL = SetSourceCodeURL
+	}
+}
+
+var CreateProductCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a product",
+	Long: "Create a new product in the VMware Marketplace, from parameters or a product spec.\n" +
+		"The spec uses the same field names as the product data (see \"product get -o yaml\").",
+	Example: fmt.Sprintf("%s product create --name \"Hyperspace Database\" --type chart --summary \"The best database\" --eula-text \"Use it wisely\" --logo logo.png", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: RunSerially(ValidateSolutionType, GetRefreshToken),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		product := &models.Product{}
+		if CreateSpecFile != "" {
+			spec, err := pkg.LoadProductSpec(CreateSpecFile)
 			if err != nil {
 				return err
 			}
+			product, err = spec.ApplyTo(product)
+			if err != nil {
+				return err
+			}
+		}
+		applyProductCreateFlags(product)
 
-			product.OpenSourceDisclosure.LicenseDisclosureURL = oslUrl
+		if product.DisplayName == "" {
+			return fmt.Errorf("the product name is required, please use the --name parameter")
+		}
+		if product.SolutionType == "" {
+			return fmt.Errorf("the product type is required, please use the --type parameter")
+		}
+		if product.PublisherDetails == nil || product.PublisherDetails.OrgId == "" {
+			return fmt.Errorf("the publisher organization is required, please use the --org-id parameter")
+		}
+
+		if CreateLogoFile != "" {
+			uploader, err := Marketplace.GetUploader(product.PublisherDetails.OrgId)
+			if err != nil {
+				return err
+			}
+			_, logoUrl, err := uploader.UploadMediaFile(CreateLogoFile)
+			if err != nil {
+				return err
+			}
+			product.Logo = logoUrl
+			product.ProductLogo = &models.Logo{URL: logoUrl}
+		}
+
+		createdProduct, err := Marketplace.CreateProduct(product)
+		if err != nil {
+			return handleDryRun(err)
+		}
+
+		Output.PrintHeader(fmt.Sprintf("Created product %s with slug %s and ID %s:", createdProduct.DisplayName, createdProduct.Slug, createdProduct.ProductId))
+		return Output.RenderProduct(createdProduct, nil)
+	},
+}
+
+// applyProductCreateFlags sets the fields of the new product that were given as parameters to "product create"
+func applyProductCreateFlags(product *models.Product) {
+	if CreateName != "" {
+		product.DisplayName = CreateName
+	}
+	if CreateSlug != "" {
+		product.Slug = CreateSlug
+	}
+	if SolutionType != "" {
+		product.SolutionType = solutionTypeMapping[SolutionType]
+	}
+
+	if product.Description == nil {
+		product.Description = &models.Description{}
+	}
+	if CreateSummary != "" {
+		product.Description.Summary = CreateSummary
+	}
+	if CreateDescription != "" {
+		product.Description.Description = CreateDescription
+	}
+
+	if product.PublisherDetails == nil {
+		product.PublisherDetails = &models.Publisher{}
+	}
+	if CreateOrgID != "" {
+		product.PublisherDetails.OrgId = CreateOrgID
+	} else if product.PublisherDetails.OrgId == "" && Claims != nil {
+		// The CSP context name is the organization id
+		product.PublisherDetails.OrgId = Claims.ContextName
+	}
+
+	if product.EulaDetails == nil {
+		product.EulaDetails = &models.EULADetails{}
+	}
+	if CreateEULAText != "" {
+		product.EulaDetails.Text = CreateEULAText
+	}
+	if CreateEULAURL != "" {
+		product.EulaDetails.Url = CreateEULAURL
+	}
+}
+
+var ApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a product spec",
+	Long: "Update a product to match the fields in a product spec.\n" +
+		"The spec uses the same field names as the product data (see \"product get -o yaml\"). Only the fields in the spec are changed.",
+	Example: fmt.Sprintf("%s product apply -f hyperspace-database.yaml", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: GetRefreshToken,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		spec, err := pkg.LoadProductSpec(ApplySpecFile)
+		if err != nil {
+			return err
+		}
+
+		slug := ProductSlug
+		if slug == "" {
+			slug = spec.Slug()
+		}
+		if slug == "" {
+			return fmt.Errorf("no product slug given, please set the slug in the product spec or use the --product parameter")
+		}
+
+		product, version, err := Marketplace.GetProductWithVersion(slug, ProductVersion)
+		if err != nil {
+			return err
+		}
+
+		updatedProduct, err := spec.ApplyTo(product)
+		if err != nil {
+			return err
 		}
 
-		_, err = Marketplace.PutProduct(product, false)
+		changes, err := pkg.DiffProducts(product, updatedProduct)
 		if err != nil {
 			return err
 		}
 
-		// TODO: what do we render?
+		Output.PrintHeader(fmt.Sprintf("Changes for %s %s:", product.DisplayName, version.Number))
+		err = Output.RenderChanges(changes)
+		if err != nil || len(changes) == 0 {
+			return err
+		}
+
+		updatedProduct.PrepForUpdate()
+		_, err = Marketplace.PutProduct(updatedProduct, false)
+		var dryRun *pkg.DryRunError
+		if errors.As(err, &dryRun) {
+			// The changes have already been shown
+			return nil
+		}
+		return err
+	},
+}
+
+var ExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a product and its versions",
+	Long: "Save a product and the details for all of its versions as a directory of YAML files.\n" +
+		"The product file can be edited and used with \"product apply\".",
+	Example: fmt.Sprintf("%s product export -p hyperspace-database --output-dir ./hyperspace-database", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: GetRefreshToken,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		export, err := Marketplace.ExportProduct(ProductSlug)
+		if err != nil {
+			return err
+		}
+
+		outputDir := ExportOutputDir
+		if outputDir == "" {
+			outputDir = export.Product.Slug
+		}
+
+		files, err := export.WriteTo(outputDir)
+		if err != nil {
+			return err
+		}
 
-		return nil
+		Output.PrintHeader(fmt.Sprintf("Exported %s to %d files in %s", export.Product.DisplayName, len(files), outputDir))
+		models.Sort(export.Product.AllVersions)
+		return Output.RenderVersions(export.Product)
 	},
 }
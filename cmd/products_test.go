@@ -5,12 +5,16 @@ package cmd_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/vmware-labs/marketplace-cli/v2/cmd"
 	"github.com/vmware-labs/marketplace-cli/v2/cmd/output/outputfakes"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/internalfakes"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg"
 	"github.com/vmware-labs/marketplace-cli/v2/pkg/pkgfakes"
 	"github.com/vmware-labs/marketplace-cli/v2/test"
 )
@@ -271,4 +275,435 @@ var _ = Describe("Products", func() {
 			})
 		})
 	})
+
+	Describe("SetCmd", func() {
+		var (
+			product  *models.Product
+			uploader *internalfakes.FakeUploader
+		)
+
+		BeforeEach(func() {
+			product = test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeChart)
+			test.AddVersions(product, "1.2.3")
+			marketplace.GetProductWithVersionReturns(product, product.AllVersions[0], nil)
+			marketplace.PutProductStub = func(product *models.Product, versionUpdate bool) (*models.Product, error) {
+				return product, nil
+			}
+
+			uploader = &internalfakes.FakeUploader{}
+			uploader.UploadMediaFileStub = func(path string) (string, string, error) {
+				return filepath.Base(path), "https://example.com/" + filepath.Base(path), nil
+			}
+			marketplace.GetUploaderReturns(uploader, nil)
+
+			cmd.ProductSlug = "my-super-product"
+			cmd.ProductVersion = "1.2.3"
+			cmd.SetOSLFile = ""
+			cmd.SetPatchFile = ""
+			cmd.SetSummary = "A great product"
+			cmd.SetDescription = ""
+			cmd.SetHighlights = []string{"Fast, and reliable", "Small"}
+			cmd.SetTags = []string{"database"}
+			cmd.SetCategories = nil
+			cmd.SetSupportURL = "https://example.com/support"
+			cmd.SetSupportEmails = []string{"support@example.com"}
+			cmd.SetSupportPhones = nil
+			cmd.SetEULAText = ""
+			cmd.SetEULAURL = ""
+			cmd.SetLogoFile = "/path/to/logo.png"
+			cmd.SetECCN = "5D992"
+			cmd.SetHTSNumber = ""
+			cmd.SetCCATSNumber = ""
+			cmd.SetEncryption = []string{"userAuthEncryption", "dataAtRestEncryption"}
+			cmd.SetSourceCodeURL = ""
+		})
+
+		It("keeps commas in highlights, tags and categories", func() {
+			err := cmd.SetCmd.ParseFlags([]string{
+				"--highlight", "Fast, and reliable",
+				"--tag", "database, sql",
+				"--tag", "cache",
+				"--category", "Databases, Storage",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cmd.SetHighlights).To(Equal([]string{"Fast, and reliable"}))
+			Expect(cmd.SetTags).To(Equal([]string{"database, sql", "cache"}))
+			Expect(cmd.SetCategories).To(Equal([]string{"Databases, Storage"}))
+		})
+
+		It("updates the product and renders it", func() {
+			err := cmd.SetCmd.RunE(cmd.SetCmd, []string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			By("uploading the logo", func() {
+				Expect(uploader.UploadMediaFileCallCount()).To(Equal(1))
+				Expect(uploader.UploadMediaFileArgsForCall(0)).To(Equal("/path/to/logo.png"))
+			})
+
+			By("sending the updated product", func() {
+				Expect(marketplace.PutProductCallCount()).To(Equal(1))
+				updatedProduct, versionUpdate := marketplace.PutProductArgsForCall(0)
+				Expect(versionUpdate).To(BeFalse())
+				Expect(updatedProduct.Description.Summary).To(Equal("A great product"))
+				Expect(updatedProduct.Highlights).To(Equal([]string{"Fast, and reliable", "Small"}))
+				Expect(updatedProduct.Tags).To(Equal([]string{"database"}))
+				Expect(updatedProduct.SupportDetails.Url).To(Equal("https://example.com/support"))
+				Expect(updatedProduct.SupportDetails.Email).To(Equal([]string{"support@example.com"}))
+				Expect(updatedProduct.ProductLogo.URL).To(Equal("https://example.com/logo.png"))
+				Expect(updatedProduct.Logo).To(Equal("https://example.com/logo.png"))
+				Expect(updatedProduct.ExportCompliance.Eccn).To(Equal("5D992"))
+				Expect(updatedProduct.EncryptionDetails.List).To(Equal([]string{"userAuthEncryption", "dataAtRestEncryption"}))
+				Expect(updatedProduct.Encryption.List).To(Equal(map[string]bool{"userAuthEncryption": true, "dataAtRestEncryption": true}))
+				Expect(updatedProduct.EulaDetails.Text).To(Equal("This is the EULA text"))
+			})
+
+			By("rendering the updated product", func() {
+				Expect(output.RenderProductCallCount()).To(Equal(1))
+				renderedProduct, version := output.RenderProductArgsForCall(0)
+				Expect(renderedProduct.Description.Summary).To(Equal("A great product"))
+				Expect(version.Number).To(Equal("1.2.3"))
+			})
+		})
+
+		When("using a patch file", func() {
+			var patchFile string
+
+			BeforeEach(func() {
+				file, err := os.CreateTemp("", "mkpcli-test-patch-*.yaml")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = file.WriteString("description:\n  summary: From the patch\n  description: A longer description\ncategoriesList: [Databases]\n")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(file.Close()).To(Succeed())
+				patchFile = file.Name()
+				cmd.SetPatchFile = patchFile
+			})
+
+			AfterEach(func() {
+				Expect(os.Remove(patchFile)).To(Succeed())
+			})
+
+			It("applies the patch file, then the parameters", func() {
+				err := cmd.SetCmd.RunE(cmd.SetCmd, []string{})
+				Expect(err).ToNot(HaveOccurred())
+
+				updatedProduct, _ := marketplace.PutProductArgsForCall(0)
+				Expect(updatedProduct.Description.Summary).To(Equal("A great product"))
+				Expect(updatedProduct.Description.Description).To(Equal("A longer description"))
+				Expect(updatedProduct.Categories).To(Equal([]string{"Databases"}))
+			})
+		})
+
+		When("nothing is set", func() {
+			BeforeEach(func() {
+				cmd.SetSummary = ""
+				cmd.SetHighlights = nil
+				cmd.SetTags = nil
+				cmd.SetSupportURL = ""
+				cmd.SetSupportEmails = nil
+				cmd.SetLogoFile = ""
+				cmd.SetECCN = ""
+				cmd.SetEncryption = nil
+			})
+
+			It("returns an error", func() {
+				err := cmd.SetCmd.RunE(cmd.SetCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("nothing specified to set"))
+				Expect(marketplace.PutProductCallCount()).To(Equal(0))
+			})
+		})
+
+		When("uploading the logo fails", func() {
+			BeforeEach(func() {
+				uploader.UploadMediaFileStub = nil
+				uploader.UploadMediaFileReturns("", "", fmt.Errorf("upload media file failed"))
+			})
+
+			It("returns an error", func() {
+				err := cmd.SetCmd.RunE(cmd.SetCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("upload media file failed"))
+				Expect(marketplace.PutProductCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("CreateProductCmd", func() {
+		var uploader *internalfakes.FakeUploader
+
+		BeforeEach(func() {
+			marketplace.CreateProductStub = func(product *models.Product) (*models.Product, error) {
+				return test.CreateFakeProduct("c2a43a52-0a22-4dd6-9fd0-0ac3e6b06e25", product.DisplayName, "my-super-product", product.SolutionType), nil
+			}
+
+			uploader = &internalfakes.FakeUploader{}
+			uploader.UploadMediaFileReturns("logo.png", "https://example.com/logo.png", nil)
+			marketplace.GetUploaderReturns(uploader, nil)
+
+			cmd.CreateSpecFile = ""
+			cmd.CreateName = "My Super Product"
+			cmd.CreateSlug = ""
+			cmd.SolutionType = "chart"
+			cmd.CreateSummary = "A great product"
+			cmd.CreateDescription = ""
+			cmd.CreateOrgID = "my-org-id"
+			cmd.CreateEULAText = "Use it wisely"
+			cmd.CreateEULAURL = ""
+			cmd.CreateLogoFile = "/path/to/logo.png"
+		})
+
+		It("creates the product", func() {
+			err := cmd.CreateProductCmd.RunE(cmd.CreateProductCmd, []string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			By("uploading the logo", func() {
+				Expect(marketplace.GetUploaderCallCount()).To(Equal(1))
+				Expect(marketplace.GetUploaderArgsForCall(0)).To(Equal("my-org-id"))
+				Expect(uploader.UploadMediaFileArgsForCall(0)).To(Equal("/path/to/logo.png"))
+			})
+
+			By("sending the new product", func() {
+				Expect(marketplace.CreateProductCallCount()).To(Equal(1))
+				product := marketplace.CreateProductArgsForCall(0)
+				Expect(product.DisplayName).To(Equal("My Super Product"))
+				Expect(product.SolutionType).To(Equal(models.SolutionTypeChart))
+				Expect(product.Description.Summary).To(Equal("A great product"))
+				Expect(product.PublisherDetails.OrgId).To(Equal("my-org-id"))
+				Expect(product.EulaDetails.Text).To(Equal("Use it wisely"))
+				Expect(product.ProductLogo.URL).To(Equal("https://example.com/logo.png"))
+			})
+
+			By("outputting the slug and product id", func() {
+				Expect(output.PrintHeaderCallCount()).To(Equal(1))
+				Expect(output.PrintHeaderArgsForCall(0)).To(Equal("Created product My Super Product with slug my-super-product and ID c2a43a52-0a22-4dd6-9fd0-0ac3e6b06e25:"))
+				Expect(output.RenderProductCallCount()).To(Equal(1))
+			})
+		})
+
+		When("using a product spec", func() {
+			var specFile string
+
+			BeforeEach(func() {
+				file, err := os.CreateTemp("", "mkpcli-test-spec-*.yaml")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = file.WriteString("displayname: From the spec\nslug: from-the-spec\nsolutiontype: OVA\n")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(file.Close()).To(Succeed())
+				specFile = file.Name()
+
+				cmd.CreateSpecFile = specFile
+				cmd.SolutionType = ""
+			})
+
+			AfterEach(func() {
+				Expect(os.Remove(specFile)).To(Succeed())
+			})
+
+			It("uses the spec, overridden by the parameters", func() {
+				err := cmd.CreateProductCmd.RunE(cmd.CreateProductCmd, []string{})
+				Expect(err).ToNot(HaveOccurred())
+
+				product := marketplace.CreateProductArgsForCall(0)
+				Expect(product.DisplayName).To(Equal("My Super Product"))
+				Expect(product.Slug).To(Equal("from-the-spec"))
+				Expect(product.SolutionType).To(Equal(models.SolutionTypeOVA))
+			})
+		})
+
+		When("the product type is missing", func() {
+			BeforeEach(func() {
+				cmd.SolutionType = ""
+			})
+
+			It("returns an error", func() {
+				err := cmd.CreateProductCmd.RunE(cmd.CreateProductCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("the product type is required, please use the --type parameter"))
+				Expect(marketplace.CreateProductCallCount()).To(Equal(0))
+			})
+		})
+
+		When("creating the product fails", func() {
+			BeforeEach(func() {
+				marketplace.CreateProductStub = nil
+				marketplace.CreateProductReturns(nil, fmt.Errorf("create product failed"))
+			})
+
+			It("returns an error", func() {
+				err := cmd.CreateProductCmd.RunE(cmd.CreateProductCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("create product failed"))
+			})
+		})
+	})
+
+	Describe("ApplyCmd", func() {
+		var (
+			product  *models.Product
+			specFile string
+		)
+
+		BeforeEach(func() {
+			product = test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeChart)
+			test.AddVersions(product, "1.2.3")
+			marketplace.GetProductWithVersionReturns(product, product.AllVersions[0], nil)
+
+			file, err := os.CreateTemp("", "mkpcli-test-spec-*.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = file.WriteString("slug: my-super-product\ndisplayname: My Amazing Product\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+			specFile = file.Name()
+
+			cmd.ProductSlug = ""
+			cmd.ProductVersion = ""
+			cmd.ApplySpecFile = specFile
+		})
+
+		AfterEach(func() {
+			Expect(os.Remove(specFile)).To(Succeed())
+		})
+
+		It("updates the product and prints the changes", func() {
+			err := cmd.ApplyCmd.RunE(cmd.ApplyCmd, []string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			By("getting the product named in the spec", func() {
+				Expect(marketplace.GetProductWithVersionCallCount()).To(Equal(1))
+				slug, version := marketplace.GetProductWithVersionArgsForCall(0)
+				Expect(slug).To(Equal("my-super-product"))
+				Expect(version).To(Equal(""))
+			})
+
+			By("printing the changes", func() {
+				Expect(output.PrintHeaderCallCount()).To(Equal(1))
+				Expect(output.PrintHeaderArgsForCall(0)).To(Equal("Changes for My Super Product 1.2.3:"))
+				Expect(output.RenderChangesCallCount()).To(Equal(1))
+				changes := output.RenderChangesArgsForCall(0)
+				Expect(changes).To(HaveLen(1))
+				Expect(changes[0].Field).To(Equal("displayname"))
+				Expect(changes[0].Old).To(Equal("My Super Product"))
+				Expect(changes[0].New).To(Equal("My Amazing Product"))
+			})
+
+			By("sending the updated product", func() {
+				Expect(marketplace.PutProductCallCount()).To(Equal(1))
+				updatedProduct, versionUpdate := marketplace.PutProductArgsForCall(0)
+				Expect(updatedProduct.ProductId).To(Equal(product.ProductId))
+				Expect(updatedProduct.DisplayName).To(Equal("My Amazing Product"))
+				Expect(versionUpdate).To(BeFalse())
+			})
+		})
+
+		When("the spec does not change anything", func() {
+			BeforeEach(func() {
+				Expect(os.WriteFile(specFile, []byte("slug: my-super-product\ndisplayname: My Super Product\n"), 0600)).To(Succeed())
+			})
+
+			It("does not update the product", func() {
+				err := cmd.ApplyCmd.RunE(cmd.ApplyCmd, []string{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output.RenderChangesCallCount()).To(Equal(1))
+				Expect(output.RenderChangesArgsForCall(0)).To(BeEmpty())
+				Expect(marketplace.PutProductCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the spec has no slug", func() {
+			BeforeEach(func() {
+				Expect(os.WriteFile(specFile, []byte("displayname: My Amazing Product\n"), 0600)).To(Succeed())
+			})
+
+			It("returns an error", func() {
+				err := cmd.ApplyCmd.RunE(cmd.ApplyCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("no product slug given, please set the slug in the product spec or use the --product parameter"))
+			})
+
+			It("uses the product parameter", func() {
+				cmd.ProductSlug = "my-super-product"
+				err := cmd.ApplyCmd.RunE(cmd.ApplyCmd, []string{})
+				Expect(err).ToNot(HaveOccurred())
+				slug, _ := marketplace.GetProductWithVersionArgsForCall(0)
+				Expect(slug).To(Equal("my-super-product"))
+			})
+		})
+
+		When("in dry run mode", func() {
+			BeforeEach(func() {
+				marketplace.PutProductReturns(nil, &pkg.DryRunError{Product: product})
+			})
+
+			It("only prints the changes", func() {
+				err := cmd.ApplyCmd.RunE(cmd.ApplyCmd, []string{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output.RenderChangesCallCount()).To(Equal(1))
+			})
+		})
+
+		When("updating the product fails", func() {
+			BeforeEach(func() {
+				marketplace.PutProductReturns(nil, fmt.Errorf("put product failed"))
+			})
+
+			It("returns an error", func() {
+				err := cmd.ApplyCmd.RunE(cmd.ApplyCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("put product failed"))
+			})
+		})
+	})
+
+	Describe("ExportCmd", func() {
+		var exportDir string
+
+		BeforeEach(func() {
+			product := test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeChart)
+			test.AddVersions(product, "1.2.3")
+			marketplace.ExportProductReturns(&pkg.ProductExport{
+				Product: product,
+				Versions: map[string]*models.VersionSpecificProductDetails{
+					"1.2.3": {EulaURL: "https://example.com/eula.txt"},
+				},
+			}, nil)
+
+			var err error
+			exportDir, err = os.MkdirTemp("", "mkpcli-test-export")
+			Expect(err).ToNot(HaveOccurred())
+
+			cmd.ProductSlug = "my-super-product"
+			cmd.ExportOutputDir = exportDir
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(exportDir)).To(Succeed())
+		})
+
+		It("writes the product to the output directory", func() {
+			err := cmd.ExportCmd.RunE(cmd.ExportCmd, []string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(marketplace.ExportProductCallCount()).To(Equal(1))
+			Expect(marketplace.ExportProductArgsForCall(0)).To(Equal("my-super-product"))
+
+			Expect(filepath.Join(exportDir, "product.yaml")).To(BeAnExistingFile())
+			Expect(filepath.Join(exportDir, "versions", "1.2.3.yaml")).To(BeAnExistingFile())
+
+			Expect(output.PrintHeaderCallCount()).To(Equal(1))
+			Expect(output.PrintHeaderArgsForCall(0)).To(Equal("Exported My Super Product to 2 files in " + exportDir))
+			Expect(output.RenderVersionsCallCount()).To(Equal(1))
+		})
+
+		When("exporting the product fails", func() {
+			BeforeEach(func() {
+				marketplace.ExportProductReturns(nil, fmt.Errorf("export product failed"))
+			})
+
+			It("returns an error", func() {
+				err := cmd.ExportCmd.RunE(cmd.ExportCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("export product failed"))
+			})
+		})
+	})
 })
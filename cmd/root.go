@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/vmware-labs/marketplace-cli/v2/cmd/output"
+	"github.com/vmware-labs/marketplace-cli/v2/internal"
 	"github.com/vmware-labs/marketplace-cli/v2/pkg"
 )
 
@@ -46,6 +47,7 @@ var rootCmd = &cobra.Command{
 	Long: fmt.Sprintf(`%s is a CLI interface for the VMware Marketplace,
 enabling users to view, get, and manage their Marketplace products.`, AppName),
 	PersistentPreRunE: RunSerially(
+		LoadProfile,
 		func(cmd *cobra.Command, args []string) error {
 			Client = pkg.NewClient(
 				os.Stderr,
@@ -55,18 +57,24 @@ enabling users to view, get, and manage their Marketplace products.`, AppName),
 			)
 
 			Marketplace = &pkg.Marketplace{
-				Host:          viper.GetString("marketplace.host"),
-				APIHost:       viper.GetString("marketplace.api-host"),
-				UIHost:        viper.GetString("marketplace.ui-host"),
-				StorageBucket: viper.GetString("marketplace.storage.bucket"),
-				StorageRegion: viper.GetString("marketplace.storage.region"),
-				Client:        Client,
-				Output:        os.Stderr,
+				Host:              viper.GetString("marketplace.host"),
+				APIHost:           viper.GetString("marketplace.api-host"),
+				UIHost:            viper.GetString("marketplace.ui-host"),
+				StorageBucket:     viper.GetString("marketplace.storage.bucket"),
+				StorageRegion:     viper.GetString("marketplace.storage.region"),
+				UploadPartSize:    viper.GetInt64("marketplace.upload.part-size") * 1024 * 1024,
+				UploadConcurrency: viper.GetInt("marketplace.upload.concurrency"),
+				HashAlgorithm:     strings.ToUpper(viper.GetString("marketplace.upload.hash-algorithm")),
+				Client:            Client,
+				Output:            os.Stderr,
 			}
 
 			if viper.GetBool("marketplace.strict-decoding") {
 				Marketplace.EnableStrictDecoding()
 			}
+			if viper.GetBool("dry-run") {
+				Marketplace.EnableDryRun()
+			}
 			return nil
 		},
 		ValidateOutputFormatFlag,
@@ -74,6 +82,16 @@ enabling users to view, get, and manage their Marketplace products.`, AppName),
 }
 
 func init() {
+	viper.SetDefault("config-file", defaultConfigFilePath())
+	_ = viper.BindEnv("config-file", "MKPCLI_CONFIG")
+	rootCmd.PersistentFlags().String("config", "", "Path to the config file (default \"~/.config/mkpcli/config.yaml\") [$MKPCLI_CONFIG]")
+	_ = rootCmd.PersistentFlags().MarkHidden("config")
+	_ = viper.BindPFlag("config-file", rootCmd.PersistentFlags().Lookup("config"))
+
+	_ = viper.BindEnv("profile", "MKPCLI_PROFILE")
+	rootCmd.PersistentFlags().String("profile", "", "Name of the profile in the config file to use [$MKPCLI_PROFILE]")
+	_ = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
+
 	viper.SetDefault("debugging.enabled", false)
 	_ = viper.BindEnv("debugging.enabled", "MKPCLI_DEBUG")
 	rootCmd.PersistentFlags().Bool("debug", false, "Enable debug output [$MKPCLI_DEBUG}")
@@ -99,6 +117,9 @@ func init() {
 	_ = rootCmd.PersistentFlags().MarkHidden("csp-host")
 	_ = viper.BindPFlag("csp.host", rootCmd.PersistentFlags().Lookup("csp-host"))
 
+	viper.SetDefault("csp.token-cache", defaultTokenCachePath())
+	_ = viper.BindEnv("csp.token-cache", "MKPCLI_TOKEN_CACHE")
+
 	_ = viper.BindEnv("marketplace.host", "MKPCLI_HOST")
 	_ = viper.BindEnv("marketplace.api-host", "MKPCLI_API_HOST")
 	_ = viper.BindEnv("marketplace.ui-host", "MKPCLI_UI_HOST")
@@ -119,9 +140,22 @@ func init() {
 		viper.SetDefault("marketplace.storage.region", "us-west-2")
 	}
 
+	// The upload part size is in megabytes
+	viper.SetDefault("marketplace.upload.part-size", internal.DefaultUploadPartSize/1024/1024)
+	_ = viper.BindEnv("marketplace.upload.part-size", "MKPCLI_UPLOAD_PART_SIZE")
+	viper.SetDefault("marketplace.upload.concurrency", internal.DefaultUploadConcurrency)
+	_ = viper.BindEnv("marketplace.upload.concurrency", "MKPCLI_UPLOAD_CONCURRENCY")
+	viper.SetDefault("marketplace.upload.hash-algorithm", pkg.DefaultHashAlgorithm)
+	_ = viper.BindEnv("marketplace.upload.hash-algorithm", "MKPCLI_HASH_ALGO")
+
 	viper.SetDefault("marketplace.strict-decoding", false)
 	_ = viper.BindEnv("marketplace.strict-decoding", "MKPCLI_STRICT_DECODING")
 
+	viper.SetDefault("dry-run", false)
+	_ = viper.BindEnv("dry-run", "MKPCLI_DRY_RUN")
+	rootCmd.PersistentFlags().Bool("dry-run", false, "Show the changes that would be made to the product instead of updating it. Files are checked and hashed, but not uploaded. [$MKPCLI_DRY_RUN]")
+	_ = viper.BindPFlag("dry-run", rootCmd.PersistentFlags().Lookup("dry-run"))
+
 	viper.SetDefault("output_format", output.FormatHuman)
 	_ = viper.BindEnv("output_format", "MKPCLI_OUTPUT")
 	rootCmd.PersistentFlags().StringP("output", "o", output.FormatHuman, fmt.Sprintf("Output format. One of %s. [$MKPCLI_OUTPUT]", strings.Join(output.SupportedOutputs, "|")))
@@ -4,12 +4,16 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/vmware-labs/marketplace-cli/v2/cmd/output"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
 	"github.com/vmware-labs/marketplace-cli/v2/pkg"
 )
 
@@ -20,11 +24,12 @@ var (
 
 	AssetType        string
 	assetTypeMapping = map[string]string{
-		"other":    pkg.AssetTypeOther,
-		"chart":    pkg.AssetTypeChart,
-		"image":    pkg.AssetTypeContainerImage,
-		"metafile": pkg.AssetTypeMetaFile,
-		"vm":       pkg.AssetTypeVM,
+		"blueprint": pkg.AssetTypeBlueprint,
+		"other":     pkg.AssetTypeOther,
+		"chart":     pkg.AssetTypeChart,
+		"image":     pkg.AssetTypeContainerImage,
+		"metafile":  pkg.AssetTypeMetaFile,
+		"vm":        pkg.AssetTypeVM,
 	}
 	MetaFileType        string
 	metaFileTypeMapping = map[string]string{
@@ -32,6 +37,14 @@ var (
 		"config": pkg.MetaFileTypeConfig,
 		"other":  pkg.MetaFileTypeOther,
 	}
+	SolutionType        string
+	solutionTypeMapping = map[string]string{
+		"chart": models.SolutionTypeChart,
+		"image": models.SolutionTypeImage,
+		"iso":   models.SolutionTypeISO,
+		"ova":   models.SolutionTypeOVA,
+		"other": models.SolutionTypeOthers,
+	}
 )
 
 func assetTypesList() []string {
@@ -71,3 +84,45 @@ func ValidateMetaFileType(cmd *cobra.Command, args []string) error {
 	}
 	return fmt.Errorf("Unknown meta file type: %s\nPlease use one of %s", MetaFileType, strings.Join(metaFileTypesList(), ", "))
 }
+
+func solutionTypesList() []string {
+	var solutionTypes []string
+	for solutionType := range solutionTypeMapping {
+		solutionTypes = append(solutionTypes, solutionType)
+	}
+	sort.Strings(solutionTypes)
+	return solutionTypes
+}
+
+func ValidateSolutionType(cmd *cobra.Command, args []string) error {
+	if SolutionType == "" {
+		return nil
+	}
+	if solutionTypeMapping[SolutionType] != "" {
+		return nil
+	}
+	return fmt.Errorf("Unknown solution type: %s\nPlease use one of %s", SolutionType, strings.Join(solutionTypesList(), ", "))
+}
+
+// handleDryRun renders the changes that would have been made if err is from a dry run, otherwise it returns err
+func handleDryRun(err error) error {
+	var dryRun *pkg.DryRunError
+	if !errors.As(err, &dryRun) {
+		return err
+	}
+
+	Output.PrintHeader(fmt.Sprintf("Changes that would be made to %s (dry run):", dryRun.Product.DisplayName))
+	return Output.RenderChanges(dryRun.Changes)
+}
+
+// Confirm asks the user a yes or no question, and returns true only if they answered yes
+func Confirm(cmd *cobra.Command, question string) (bool, error) {
+	cmd.PrintErrf("%s [y/N]: ", question)
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read the answer: %w", err)
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
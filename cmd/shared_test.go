@@ -28,7 +28,7 @@ var _ = Describe("ValidateAssetTypeFilter", func() {
 			cmd.AssetType = "dogfood"
 			err := cmd.ValidateAssetTypeFilter(nil, nil)
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(Equal("Unknown asset type: dogfood\nPlease use one of chart, image, metafile, other, vm"))
+			Expect(err.Error()).To(Equal("Unknown asset type: dogfood\nPlease use one of blueprint, chart, image, metafile, other, vm"))
 		})
 	})
 })
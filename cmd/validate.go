@@ -0,0 +1,66 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	ValidateChartURL       string
+	ValidateProductVersion string
+)
+
+func init() {
+	rootCmd.AddCommand(ValidateCmd)
+	ValidateCmd.AddCommand(ValidateChartCmd)
+	ValidateChartCmd.SetOut(ValidateChartCmd.OutOrStdout())
+
+	ValidateChartCmd.Flags().StringVarP(&ValidateChartURL, "chart", "c", "", "Path to to chart, either local tgz, public URL, or OCI reference (oci://registry/repository/chart:version) (required)")
+	_ = ValidateChartCmd.MarkFlagRequired("chart")
+	ValidateChartCmd.Flags().StringVarP(&ValidateProductVersion, "product-version", "v", "", "Product version that the chart's appVersion should match")
+}
+
+var ValidateCmd = &cobra.Command{
+	Use:       "validate",
+	Short:     "Validate assets",
+	Long:      "Validate assets before attaching them to a product in the VMware Marketplace",
+	Args:      cobra.OnlyValidArgs,
+	ValidArgs: []string{ValidateChartCmd.Use},
+}
+
+var ValidateChartCmd = &cobra.Command{
+	Use:   "chart",
+	Short: "Validate a chart",
+	Long: "Lints a Helm Chart and validates its values against values.schema.json.\n" +
+		"Warns if its values do not reference any container images, or if a product version is given and the chart's appVersion does not match it.",
+	Example: fmt.Sprintf("%s validate chart --chart hyperspace-db-1.2.3.tgz --product-version 1.2.3", AppName),
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		chartURL, err := url.Parse(ValidateChartURL)
+		if err != nil {
+			return fmt.Errorf("failed to parse chart URL: %w", err)
+		}
+
+		warnings, err := Marketplace.ValidateChart(chartURL, ValidateProductVersion)
+		printChartWarnings(cmd, warnings)
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("Chart %s is valid\n", ValidateChartURL)
+		return nil
+	},
+}
+
+// printChartWarnings prints the warnings found while validating a chart, which do not stop it from being attached
+func printChartWarnings(cmd *cobra.Command, warnings []string) {
+	for _, warning := range warnings {
+		cmd.PrintErrf("Warning: %s\n", warning)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	"github.com/vmware-labs/marketplace-cli/v2/cmd"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg/pkgfakes"
+)
+
+var _ = Describe("ValidateChartCmd", func() {
+	var (
+		marketplace *pkgfakes.FakeMarketplaceInterface
+		stdout      *Buffer
+		stderr      *Buffer
+	)
+
+	BeforeEach(func() {
+		marketplace = &pkgfakes.FakeMarketplaceInterface{}
+		cmd.Marketplace = marketplace
+		stdout = NewBuffer()
+		cmd.ValidateChartCmd.SetOut(stdout)
+		stderr = NewBuffer()
+		cmd.ValidateChartCmd.SetErr(stderr)
+
+		cmd.ValidateChartURL = "oci://registry.example.com/charts/my-chart:1.0.0"
+		cmd.ValidateProductVersion = "1.0.0"
+	})
+
+	It("validates the chart", func() {
+		err := cmd.ValidateChartCmd.RunE(cmd.ValidateChartCmd, []string{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(marketplace.ValidateChartCallCount()).To(Equal(1))
+		chartUrl, productVersion := marketplace.ValidateChartArgsForCall(0)
+		Expect(chartUrl.String()).To(Equal("oci://registry.example.com/charts/my-chart:1.0.0"))
+		Expect(productVersion).To(Equal("1.0.0"))
+
+		Expect(stdout).To(Say("Chart oci://registry.example.com/charts/my-chart:1.0.0 is valid"))
+	})
+
+	When("there are warnings", func() {
+		BeforeEach(func() {
+			marketplace.ValidateChartReturns([]string{"values.yaml does not reference any container images"}, nil)
+		})
+
+		It("prints them, and the chart is still valid", func() {
+			err := cmd.ValidateChartCmd.RunE(cmd.ValidateChartCmd, []string{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stderr).To(Say("Warning: values.yaml does not reference any container images"))
+			Expect(stdout).To(Say("Chart oci://registry.example.com/charts/my-chart:1.0.0 is valid"))
+		})
+	})
+
+	When("the chart is not valid", func() {
+		BeforeEach(func() {
+			marketplace.ValidateChartReturns(nil, &pkg.ChartValidationError{
+				Chart:    "oci://registry.example.com/charts/my-chart:1.0.0",
+				Problems: []string{"[ERROR] templates/: parse error"},
+			})
+		})
+
+		It("returns an error", func() {
+			err := cmd.ValidateChartCmd.RunE(cmd.ValidateChartCmd, []string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("chart oci://registry.example.com/charts/my-chart:1.0.0 is not valid:\n  [ERROR] templates/: parse error"))
+		})
+	})
+})
@@ -0,0 +1,129 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg"
+)
+
+var (
+	WaitProductSlug    string
+	WaitProductVersion string
+	WaitTimeout        time.Duration
+
+	// WaitPollInterval is how long to wait before checking the assets again. It doubles after every check, up to WaitMaxPollInterval.
+	WaitPollInterval    = 5 * time.Second
+	WaitMaxPollInterval = 1 * time.Minute
+)
+
+const DefaultWaitTimeout = 30 * time.Minute
+
+func init() {
+	rootCmd.AddCommand(WaitCmd)
+
+	WaitCmd.Flags().StringVarP(&WaitProductSlug, "product", "p", "", "Product slug (required)")
+	_ = WaitCmd.MarkFlagRequired("product")
+	WaitCmd.Flags().StringVarP(&WaitProductVersion, "product-version", "v", "", "Product version (default to latest version)")
+	WaitCmd.Flags().StringVarP(&AssetType, "type", "t", "", "Only wait for assets of this type (one of "+strings.Join(assetTypesList(), ", ")+")")
+	WaitCmd.Flags().DurationVar(&WaitTimeout, "timeout", DefaultWaitTimeout, "How long to wait for the assets to be processed")
+}
+
+var WaitCmd = &cobra.Command{
+	Use:     "wait",
+	Short:   "Wait for assets to be processed",
+	Long:    "Wait until the assets attached to a product have finished processing in the VMware Marketplace. Fails if any asset could not be processed.",
+	Example: fmt.Sprintf("%s wait -p hyperspace-database-chart1 -v 1.2.3 --type chart --timeout 10m", AppName),
+	Args:    cobra.NoArgs,
+	PreRunE: RunSerially(ValidateAssetTypeFilter, GetRefreshToken),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		product, version, err := waitForAssets(cmd, WaitProductSlug, WaitProductVersion, assetTypeMapping[AssetType], nil, WaitTimeout)
+		if err != nil {
+			return err
+		}
+
+		Output.PrintHeader(fmt.Sprintf("Assets for %s %s:", product.DisplayName, version.Number))
+		return Output.RenderAssets(pkg.GetAssetsByType(assetTypeMapping[AssetType], product, version.Number))
+	},
+}
+
+// waitForAssets polls the product until none of its assets of the given type are still being processed.
+// If names are given, only the assets with those names are waited for.
+func waitForAssets(cmd *cobra.Command, slug, versionNumber, assetType string, names []string, timeout time.Duration) (*models.Product, *models.Version, error) {
+	deadline := time.Now().Add(timeout)
+	interval := WaitPollInterval
+	for {
+		product, version, err := Marketplace.GetProductWithVersion(slug, versionNumber)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		typeName := ""
+		if assetType != "" {
+			typeName = assetType + " "
+		}
+		assets := pkg.GetAssetsByType(assetType, product, version.Number)
+		if len(names) > 0 {
+			assets = assetsNamed(assets, names)
+			if len(assets) == 0 {
+				return nil, nil, fmt.Errorf("product %s %s does not have the %sasset %s", product.Slug, version.Number, typeName, strings.Join(names, ", "))
+			}
+		}
+		if len(assets) == 0 {
+			return nil, nil, fmt.Errorf("product %s %s does not have any %sassets", product.Slug, version.Number, typeName)
+		}
+
+		var pending, failed []string
+		for _, asset := range assets {
+			if asset.Processing {
+				pending = append(pending, asset.DisplayName)
+			} else if !asset.Downloadable && asset.Error != "" {
+				failed = append(failed, fmt.Sprintf("%s: %s", asset.DisplayName, asset.Error))
+			}
+		}
+
+		if len(failed) > 0 {
+			return nil, nil, fmt.Errorf("%d of %d assets for %s %s failed processing:\n%s", len(failed), len(assets), product.Slug, version.Number, strings.Join(failed, "\n"))
+		}
+		if len(pending) == 0 {
+			return product, version, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, nil, fmt.Errorf("timed out after %s waiting for assets for %s %s to be processed: %s", timeout, product.Slug, version.Number, strings.Join(pending, ", "))
+		}
+
+		cmd.PrintErrf("Waiting for %d of %d assets for %s %s to be processed...\n", len(pending), len(assets), product.Slug, version.Number)
+		if interval > remaining {
+			interval = remaining
+		}
+		time.Sleep(interval)
+
+		interval *= 2
+		if interval > WaitMaxPollInterval {
+			interval = WaitMaxPollInterval
+		}
+	}
+}
+
+// assetsNamed returns the assets whose display name is one of the names
+func assetsNamed(assets []*pkg.Asset, names []string) []*pkg.Asset {
+	var named []*pkg.Asset
+	for _, asset := range assets {
+		for _, name := range names {
+			if asset.DisplayName == name {
+				named = append(named, asset)
+				break
+			}
+		}
+	}
+	return named
+}
@@ -0,0 +1,228 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package cmd_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	"github.com/vmware-labs/marketplace-cli/v2/cmd"
+	"github.com/vmware-labs/marketplace-cli/v2/cmd/output/outputfakes"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg/pkgfakes"
+	"github.com/vmware-labs/marketplace-cli/v2/test"
+)
+
+var _ = Describe("WaitCmd", func() {
+	var (
+		marketplace *pkgfakes.FakeMarketplaceInterface
+		output      *outputfakes.FakeFormat
+		stderr      *Buffer
+		product     *models.Product
+		chart       *models.ChartVersion
+		checks      int
+		processedAt int
+
+		originalPollInterval time.Duration
+	)
+
+	BeforeEach(func() {
+		originalPollInterval = cmd.WaitPollInterval
+		cmd.WaitPollInterval = time.Millisecond
+
+		output = &outputfakes.FakeFormat{}
+		cmd.Output = output
+		stderr = NewBuffer()
+		cmd.WaitCmd.SetErr(stderr)
+
+		product = test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeChart)
+		test.AddVersions(product, "1.1.1")
+		chart = &models.ChartVersion{
+			AppVersion: "1.1.1",
+			Version:    "1.0.0",
+			HelmTarUrl: "https://example.com/my-chart.tgz",
+		}
+		product.ChartVersions = append(product.ChartVersions, chart)
+
+		checks = 0
+		processedAt = 3
+		marketplace = &pkgfakes.FakeMarketplaceInterface{}
+		marketplace.GetProductWithVersionStub = func(slug string, version string) (*models.Product, *models.Version, error) {
+			checks += 1
+			if checks == processedAt {
+				chart.IsUpdatedInMarketplaceRegistry = true
+			}
+			return product, &models.Version{Number: "1.1.1"}, nil
+		}
+		cmd.Marketplace = marketplace
+
+		cmd.WaitProductSlug = "my-super-product"
+		cmd.WaitProductVersion = "1.1.1"
+		cmd.WaitTimeout = time.Minute
+		cmd.AssetType = ""
+	})
+
+	AfterEach(func() {
+		cmd.WaitPollInterval = originalPollInterval
+	})
+
+	It("waits until the assets are processed", func() {
+		err := cmd.WaitCmd.RunE(cmd.WaitCmd, []string{})
+		Expect(err).ToNot(HaveOccurred())
+
+		By("polling the product until the chart is available", func() {
+			Expect(marketplace.GetProductWithVersionCallCount()).To(Equal(3))
+			slug, version := marketplace.GetProductWithVersionArgsForCall(0)
+			Expect(slug).To(Equal("my-super-product"))
+			Expect(version).To(Equal("1.1.1"))
+			Expect(stderr).To(Say("Waiting for 1 of 1 assets for my-super-product 1.1.1 to be processed..."))
+		})
+
+		By("outputting the assets", func() {
+			Expect(output.PrintHeaderCallCount()).To(Equal(1))
+			Expect(output.PrintHeaderArgsForCall(0)).To(Equal("Assets for My Super Product 1.1.1:"))
+			Expect(output.RenderAssetsCallCount()).To(Equal(1))
+			assets := output.RenderAssetsArgsForCall(0)
+			Expect(assets).To(HaveLen(1))
+			Expect(assets[0].Downloadable).To(BeTrue())
+		})
+	})
+
+	When("there are no assets of the given type", func() {
+		It("returns an error", func() {
+			cmd.AssetType = "metafile"
+			err := cmd.WaitCmd.RunE(cmd.WaitCmd, []string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("product my-super-product 1.1.1 does not have any MetaFile assets"))
+		})
+	})
+
+	When("an asset fails processing", func() {
+		BeforeEach(func() {
+			processedAt = 0
+			marketplace.GetProductWithVersionStub = func(slug string, version string) (*models.Product, *models.Version, error) {
+				checks += 1
+				if checks == 2 {
+					chart.ProcessingError = "chart is not valid"
+				}
+				return product, &models.Version{Number: "1.1.1"}, nil
+			}
+		})
+
+		It("returns the processing error", func() {
+			err := cmd.WaitCmd.RunE(cmd.WaitCmd, []string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("1 of 1 assets for my-super-product 1.1.1 failed processing:\nhttps://example.com/my-chart.tgz: chart is not valid"))
+			Expect(marketplace.GetProductWithVersionCallCount()).To(Equal(2))
+		})
+	})
+
+	When("the assets are not processed in time", func() {
+		BeforeEach(func() {
+			processedAt = 0
+			cmd.WaitTimeout = 10 * time.Millisecond
+		})
+
+		It("returns an error", func() {
+			err := cmd.WaitCmd.RunE(cmd.WaitCmd, []string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("timed out after 10ms waiting for assets for my-super-product 1.1.1 to be processed: https://example.com/my-chart.tgz"))
+		})
+	})
+
+	When("getting the product fails", func() {
+		BeforeEach(func() {
+			marketplace.GetProductWithVersionStub = nil
+			marketplace.GetProductWithVersionReturns(nil, nil, errors.New("get product failed"))
+		})
+
+		It("returns an error", func() {
+			err := cmd.WaitCmd.RunE(cmd.WaitCmd, []string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("get product failed"))
+		})
+	})
+
+	Describe("attach --wait", func() {
+		BeforeEach(func() {
+			updatedProduct := test.CreateFakeProduct(product.ProductId, "My Super Product", "my-super-product", models.SolutionTypeChart)
+			test.AddVersions(updatedProduct, "1.1.1")
+			marketplace.AttachPublicChartReturns(updatedProduct, nil)
+
+			cmd.AttachProductSlug = "my-super-product"
+			cmd.AttachProductVersion = "1.1.1"
+			cmd.AttachChartURL = "https://example.com/my-chart.tgz"
+			cmd.AttachInstructions = "helm install it"
+			cmd.AttachCreateVersion = false
+			cmd.AttachPCAFile = ""
+			cmd.AttachWait = true
+			cmd.AttachWaitTimeout = time.Minute
+			cmd.AttachChartCmd.SetErr(stderr)
+		})
+
+		AfterEach(func() {
+			cmd.AttachWait = false
+			cmd.AttachWaitTimeout = cmd.DefaultWaitTimeout
+		})
+
+		It("waits for the attached chart to be processed", func() {
+			err := cmd.AttachChartCmd.RunE(cmd.AttachChartCmd, []string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(marketplace.AttachPublicChartCallCount()).To(Equal(1))
+			Expect(marketplace.GetProductWithVersionCallCount()).To(Equal(3))
+			Expect(stderr).To(Say("Waiting for 1 of 1 assets for my-super-product 1.1.1 to be processed..."))
+
+			Expect(output.RenderChartsCallCount()).To(Equal(1))
+			charts := output.RenderChartsArgsForCall(0)
+			Expect(charts).To(HaveLen(1))
+			Expect(charts[0].IsUpdatedInMarketplaceRegistry).To(BeTrue())
+		})
+
+		When("another chart on the version failed processing", func() {
+			BeforeEach(func() {
+				product.ChartVersions = append(product.ChartVersions, &models.ChartVersion{
+					AppVersion:      "1.1.1",
+					Version:         "0.9.0",
+					HelmTarUrl:      "https://example.com/my-old-chart.tgz",
+					ProcessingError: "chart is not valid",
+				})
+			})
+
+			It("only waits for the attached chart", func() {
+				err := cmd.AttachChartCmd.RunE(cmd.AttachChartCmd, []string{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(marketplace.GetProductWithVersionCallCount()).To(Equal(3))
+			})
+		})
+
+		When("the attached chart is not processed before the wait timeout", func() {
+			BeforeEach(func() {
+				processedAt = 0
+				cmd.AttachWaitTimeout = 10 * time.Millisecond
+			})
+
+			It("returns an error", func() {
+				err := cmd.AttachChartCmd.RunE(cmd.AttachChartCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("timed out after 10ms waiting for assets for my-super-product 1.1.1 to be processed: https://example.com/my-chart.tgz"))
+			})
+		})
+
+		When("the attached chart is not on the product", func() {
+			BeforeEach(func() {
+				cmd.AttachChartURL = "https://example.com/my-other-chart.tgz"
+			})
+
+			It("returns an error", func() {
+				err := cmd.AttachChartCmd.RunE(cmd.AttachChartCmd, []string{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("product my-super-product 1.1.1 does not have the Chart asset https://example.com/my-other-chart.tgz"))
+			})
+		})
+	})
+})
@@ -6,6 +6,7 @@ package csp
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 )
@@ -66,3 +67,26 @@ func (claims *Claims) IsPlatformOperator() bool {
 
 	return false
 }
+
+// Identity is the summary of who the token belongs to and what they can do
+type Identity struct {
+	Username         string    `json:"username"`
+	Context          string    `json:"context"`
+	ContextName      string    `json:"contextname"`
+	Perms            []string  `json:"perms"`
+	OrgOwner         bool      `json:"orgowner"`
+	PlatformOperator bool      `json:"platformoperator"`
+	ExpiresAt        time.Time `json:"expiresat"`
+}
+
+func (claims *Claims) GetIdentity() *Identity {
+	return &Identity{
+		Username:         claims.GetQualifiedUsername(),
+		Context:          claims.Context,
+		ContextName:      claims.ContextName,
+		Perms:            claims.Perms,
+		OrgOwner:         claims.IsOrgOwner(),
+		PlatformOperator: claims.IsPlatformOperator(),
+		ExpiresAt:        time.Unix(claims.ExpiresAt, 0),
+	}
+}
@@ -126,4 +126,28 @@ var _ = Describe("CSP Claims", func() {
 			))
 		})
 	})
+
+	Describe("GetIdentity", func() {
+		It("summarizes the claims", func() {
+			claims := &csp.Claims{
+				StandardClaims: jwt.StandardClaims{
+					ExpiresAt: 1700000000,
+				},
+				ContextName: "my-org-id",
+				Context:     "my-org-context",
+				Username:    "john",
+				Domain:      "example.com",
+				Perms:       []string{csp.RolePlatformOperator},
+			}
+
+			identity := claims.GetIdentity()
+			Expect(identity.Username).To(Equal("john@example.com"))
+			Expect(identity.ContextName).To(Equal("my-org-id"))
+			Expect(identity.Context).To(Equal("my-org-context"))
+			Expect(identity.Perms).To(ConsistOf(csp.RolePlatformOperator))
+			Expect(identity.OrgOwner).To(BeFalse())
+			Expect(identity.PlatformOperator).To(BeTrue())
+			Expect(identity.ExpiresAt.Unix()).To(Equal(int64(1700000000)))
+		})
+	})
 })
@@ -0,0 +1,137 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package csp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TokenExpiryWindow is how long before it expires that a cached token is no longer used
+var TokenExpiryWindow = 5 * time.Minute
+
+// TokenCache stores redeemed access tokens in a file, so that they can be reused by later commands.
+// Tokens are keyed by a hash of the API token and the CSP host, so the API token itself is never written.
+type TokenCache struct {
+	Path string
+}
+
+type cachedToken struct {
+	Token  string  `json:"token"`
+	Claims *Claims `json:"claims"`
+}
+
+func NewTokenCache(path string) *TokenCache {
+	return &TokenCache{Path: path}
+}
+
+func tokenCacheKey(apiToken, cspHost string) string {
+	sum := sha256.Sum256([]byte(cspHost + "\n" + apiToken))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *TokenCache) load() (map[string]*cachedToken, error) {
+	tokens := map[string]*cachedToken{}
+	contents, err := os.ReadFile(c.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the token cache %s: %w", c.Path, err)
+	}
+
+	err = json.Unmarshal(contents, &tokens)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the token cache %s: %w", c.Path, err)
+	}
+	return tokens, nil
+}
+
+// Get returns the cached claims for the API token, or nil if there are none that are still valid
+func (c *TokenCache) Get(apiToken, cspHost string) *Claims {
+	tokens, err := c.load()
+	if err != nil {
+		return nil
+	}
+
+	cached := tokens[tokenCacheKey(apiToken, cspHost)]
+	if cached == nil || cached.Claims == nil || cached.Token == "" {
+		return nil
+	}
+	if time.Now().Add(TokenExpiryWindow).Unix() >= cached.Claims.ExpiresAt {
+		return nil
+	}
+
+	cached.Claims.Token = cached.Token
+	return cached.Claims
+}
+
+func (c *TokenCache) Put(apiToken, cspHost string, claims *Claims) error {
+	tokens, err := c.load()
+	if err != nil {
+		// Replace a cache that cannot be read
+		tokens = map[string]*cachedToken{}
+	}
+
+	now := time.Now().Unix()
+	for key, cached := range tokens {
+		if cached.Claims == nil || cached.Claims.ExpiresAt <= now {
+			delete(tokens, key)
+		}
+	}
+	tokens[tokenCacheKey(apiToken, cspHost)] = &cachedToken{
+		Token:  claims.Token,
+		Claims: claims,
+	}
+
+	contents, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("failed to encode the token cache: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(c.Path), 0700)
+	if err != nil {
+		return fmt.Errorf("failed to create the directory for the token cache: %w", err)
+	}
+
+	// Write to a temporary file first, so that a partially written cache is never read
+	file, err := os.CreateTemp(filepath.Dir(c.Path), filepath.Base(c.Path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write the token cache %s: %w", c.Path, err)
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(contents)
+	if err == nil {
+		err = file.Chmod(0600)
+	}
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write the token cache %s: %w", c.Path, err)
+	}
+
+	err = os.Rename(file.Name(), c.Path)
+	if err != nil {
+		return fmt.Errorf("failed to write the token cache %s: %w", c.Path, err)
+	}
+	return nil
+}
+
+// Clear removes all cached tokens
+func (c *TokenCache) Clear() error {
+	err := os.Remove(c.Path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove the token cache %s: %w", c.Path, err)
+	}
+	return nil
+}
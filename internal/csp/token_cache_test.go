@@ -0,0 +1,119 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package csp_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/csp"
+)
+
+var _ = Describe("TokenCache", func() {
+	var (
+		cacheDir   string
+		cachePath  string
+		tokenCache *csp.TokenCache
+		claims     *csp.Claims
+	)
+
+	BeforeEach(func() {
+		var err error
+		cacheDir, err = os.MkdirTemp("", "mkpcli-test-token-cache")
+		Expect(err).ToNot(HaveOccurred())
+		cachePath = filepath.Join(cacheDir, "mkpcli", "tokens.json")
+		tokenCache = csp.NewTokenCache(cachePath)
+
+		claims = &csp.Claims{
+			StandardClaims: jwt.StandardClaims{
+				ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			},
+			Username: "john@example.com",
+			Token:    "my-access-token",
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(cacheDir)).To(Succeed())
+	})
+
+	It("stores and returns tokens", func() {
+		Expect(tokenCache.Put("my-api-token", "csp.example.com", claims)).To(Succeed())
+
+		cached := tokenCache.Get("my-api-token", "csp.example.com")
+		Expect(cached).ToNot(BeNil())
+		Expect(cached.Token).To(Equal("my-access-token"))
+		Expect(cached.Username).To(Equal("john@example.com"))
+
+		By("keying the tokens by API token and CSP host", func() {
+			Expect(tokenCache.Get("other-api-token", "csp.example.com")).To(BeNil())
+			Expect(tokenCache.Get("my-api-token", "other-csp.example.com")).To(BeNil())
+		})
+
+		By("not storing the API token", func() {
+			contents, err := os.ReadFile(cachePath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).ToNot(ContainSubstring("my-api-token"))
+		})
+
+		By("only allowing the user to read the cache", func() {
+			info, err := os.Stat(cachePath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+			info, err = os.Stat(filepath.Dir(cachePath))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0700)))
+		})
+	})
+
+	When("the token is about to expire", func() {
+		BeforeEach(func() {
+			claims.ExpiresAt = time.Now().Add(csp.TokenExpiryWindow / 2).Unix()
+		})
+
+		It("is not returned", func() {
+			Expect(tokenCache.Put("my-api-token", "csp.example.com", claims)).To(Succeed())
+			Expect(tokenCache.Get("my-api-token", "csp.example.com")).To(BeNil())
+		})
+	})
+
+	When("the cache file does not exist", func() {
+		It("returns nothing", func() {
+			Expect(tokenCache.Get("my-api-token", "csp.example.com")).To(BeNil())
+		})
+	})
+
+	When("the cache file is not valid", func() {
+		BeforeEach(func() {
+			Expect(os.MkdirAll(filepath.Dir(cachePath), 0700)).To(Succeed())
+			Expect(os.WriteFile(cachePath, []byte("this is not json"), 0600)).To(Succeed())
+		})
+
+		It("returns nothing and is replaced on the next write", func() {
+			Expect(tokenCache.Get("my-api-token", "csp.example.com")).To(BeNil())
+			Expect(tokenCache.Put("my-api-token", "csp.example.com", claims)).To(Succeed())
+			Expect(tokenCache.Get("my-api-token", "csp.example.com")).ToNot(BeNil())
+		})
+	})
+
+	Describe("Clear", func() {
+		It("removes the cached tokens", func() {
+			Expect(tokenCache.Put("my-api-token", "csp.example.com", claims)).To(Succeed())
+			Expect(tokenCache.Clear()).To(Succeed())
+			Expect(cachePath).ToNot(BeAnExistingFile())
+			Expect(tokenCache.Get("my-api-token", "csp.example.com")).To(BeNil())
+		})
+
+		When("there is no cache", func() {
+			It("does nothing", func() {
+				Expect(tokenCache.Clear()).To(Succeed())
+			})
+		})
+	})
+})
@@ -0,0 +1,64 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package internal
+
+import (
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// DryRunUploadURL is the start of the URL given to files that are not uploaded in a dry run
+const DryRunUploadURL = "<not uploaded in dry run>"
+
+// DryRunUploader reads files as if it were uploading them, so that they are checked and hashed, but does not upload them
+type DryRunUploader struct{}
+
+func NewDryRunUploader() *DryRunUploader {
+	return &DryRunUploader{}
+}
+
+func (u *DryRunUploader) UploadMediaFile(filePath string) (string, string, error) {
+	return u.UploadMediaFileWithHash(filePath, nil)
+}
+
+func (u *DryRunUploader) UploadMetaFile(filePath string) (string, string, error) {
+	return u.UploadMetaFileWithHash(filePath, nil)
+}
+
+func (u *DryRunUploader) UploadProductFile(filePath string) (string, string, error) {
+	return u.UploadProductFileWithHash(filePath, nil)
+}
+
+func (u *DryRunUploader) UploadMediaFileWithHash(filePath string, hasher hash.Hash) (string, string, error) {
+	return u.upload(filePath, FolderMediaFiles, hasher)
+}
+
+func (u *DryRunUploader) UploadMetaFileWithHash(filePath string, hasher hash.Hash) (string, string, error) {
+	return u.upload(filePath, FolderMetaFiles, hasher)
+}
+
+func (u *DryRunUploader) UploadProductFileWithHash(filePath string, hasher hash.Hash) (string, string, error) {
+	return u.upload(filePath, FolderProductFiles, hasher)
+}
+
+func (u *DryRunUploader) upload(filePath, folder string, hasher hash.Hash) (string, string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	if hasher != nil {
+		_, err = io.Copy(hasher, file)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+	}
+
+	filename := filepath.Base(filePath)
+	return filename, fmt.Sprintf("%s/%s/%s", DryRunUploadURL, folder, filename), nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package internal_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vmware-labs/marketplace-cli/v2/internal"
+)
+
+var _ = Describe("DryRunUploader", func() {
+	var (
+		file     *os.File
+		uploader *internal.DryRunUploader
+	)
+
+	BeforeEach(func() {
+		var err error
+		file, err = os.CreateTemp("", "mkpcli-test-dry-run-uploader-*.iso")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = file.WriteString("file contents")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		uploader = internal.NewDryRunUploader()
+	})
+
+	AfterEach(func() {
+		Expect(os.Remove(file.Name())).To(Succeed())
+	})
+
+	It("hashes the file and returns a placeholder URL", func() {
+		hasher := sha256.New()
+		filename, fileUrl, err := uploader.UploadProductFileWithHash(file.Name(), hasher)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filename).To(Equal(filepath.Base(file.Name())))
+		Expect(fileUrl).To(Equal("<not uploaded in dry run>/marketplace-product-files/" + filepath.Base(file.Name())))
+		Expect(hex.EncodeToString(hasher.Sum(nil))).To(Equal("7bb6f9f7a47a63e684925af3608c059edcc371eb81188c48c9714896fb1091fd"))
+	})
+
+	When("the file does not exist", func() {
+		It("returns an error", func() {
+			_, _, err := uploader.UploadMediaFile("/this/file/does/not/exist.png")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("failed to open /this/file/does/not/exist.png: open /this/file/does/not/exist.png: no such file or directory"))
+		})
+	})
+})
@@ -2,6 +2,7 @@
 package internalfakes
 
 import (
+	"hash"
 	"sync"
 
 	"github.com/vmware-labs/marketplace-cli/v2/internal"
@@ -23,6 +24,22 @@ type FakeUploader struct {
 		result2 string
 		result3 error
 	}
+	UploadMediaFileWithHashStub        func(string, hash.Hash) (string, string, error)
+	uploadMediaFileWithHashMutex       sync.RWMutex
+	uploadMediaFileWithHashArgsForCall []struct {
+		arg1 string
+		arg2 hash.Hash
+	}
+	uploadMediaFileWithHashReturns struct {
+		result1 string
+		result2 string
+		result3 error
+	}
+	uploadMediaFileWithHashReturnsOnCall map[int]struct {
+		result1 string
+		result2 string
+		result3 error
+	}
 	UploadMetaFileStub        func(string) (string, string, error)
 	uploadMetaFileMutex       sync.RWMutex
 	uploadMetaFileArgsForCall []struct {
@@ -38,6 +55,22 @@ type FakeUploader struct {
 		result2 string
 		result3 error
 	}
+	UploadMetaFileWithHashStub        func(string, hash.Hash) (string, string, error)
+	uploadMetaFileWithHashMutex       sync.RWMutex
+	uploadMetaFileWithHashArgsForCall []struct {
+		arg1 string
+		arg2 hash.Hash
+	}
+	uploadMetaFileWithHashReturns struct {
+		result1 string
+		result2 string
+		result3 error
+	}
+	uploadMetaFileWithHashReturnsOnCall map[int]struct {
+		result1 string
+		result2 string
+		result3 error
+	}
 	UploadProductFileStub        func(string) (string, string, error)
 	uploadProductFileMutex       sync.RWMutex
 	uploadProductFileArgsForCall []struct {
@@ -53,6 +86,22 @@ type FakeUploader struct {
 		result2 string
 		result3 error
 	}
+	UploadProductFileWithHashStub        func(string, hash.Hash) (string, string, error)
+	uploadProductFileWithHashMutex       sync.RWMutex
+	uploadProductFileWithHashArgsForCall []struct {
+		arg1 string
+		arg2 hash.Hash
+	}
+	uploadProductFileWithHashReturns struct {
+		result1 string
+		result2 string
+		result3 error
+	}
+	uploadProductFileWithHashReturnsOnCall map[int]struct {
+		result1 string
+		result2 string
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -124,6 +173,74 @@ func (fake *FakeUploader) UploadMediaFileReturnsOnCall(i int, result1 string, re
 	}{result1, result2, result3}
 }
 
+func (fake *FakeUploader) UploadMediaFileWithHash(arg1 string, arg2 hash.Hash) (string, string, error) {
+	fake.uploadMediaFileWithHashMutex.Lock()
+	ret, specificReturn := fake.uploadMediaFileWithHashReturnsOnCall[len(fake.uploadMediaFileWithHashArgsForCall)]
+	fake.uploadMediaFileWithHashArgsForCall = append(fake.uploadMediaFileWithHashArgsForCall, struct {
+		arg1 string
+		arg2 hash.Hash
+	}{arg1, arg2})
+	stub := fake.UploadMediaFileWithHashStub
+	fakeReturns := fake.uploadMediaFileWithHashReturns
+	fake.recordInvocation("UploadMediaFileWithHash", []interface{}{arg1, arg2})
+	fake.uploadMediaFileWithHashMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeUploader) UploadMediaFileWithHashCallCount() int {
+	fake.uploadMediaFileWithHashMutex.RLock()
+	defer fake.uploadMediaFileWithHashMutex.RUnlock()
+	return len(fake.uploadMediaFileWithHashArgsForCall)
+}
+
+func (fake *FakeUploader) UploadMediaFileWithHashCalls(stub func(string, hash.Hash) (string, string, error)) {
+	fake.uploadMediaFileWithHashMutex.Lock()
+	defer fake.uploadMediaFileWithHashMutex.Unlock()
+	fake.UploadMediaFileWithHashStub = stub
+}
+
+func (fake *FakeUploader) UploadMediaFileWithHashArgsForCall(i int) (string, hash.Hash) {
+	fake.uploadMediaFileWithHashMutex.RLock()
+	defer fake.uploadMediaFileWithHashMutex.RUnlock()
+	argsForCall := fake.uploadMediaFileWithHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUploader) UploadMediaFileWithHashReturns(result1 string, result2 string, result3 error) {
+	fake.uploadMediaFileWithHashMutex.Lock()
+	defer fake.uploadMediaFileWithHashMutex.Unlock()
+	fake.UploadMediaFileWithHashStub = nil
+	fake.uploadMediaFileWithHashReturns = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeUploader) UploadMediaFileWithHashReturnsOnCall(i int, result1 string, result2 string, result3 error) {
+	fake.uploadMediaFileWithHashMutex.Lock()
+	defer fake.uploadMediaFileWithHashMutex.Unlock()
+	fake.UploadMediaFileWithHashStub = nil
+	if fake.uploadMediaFileWithHashReturnsOnCall == nil {
+		fake.uploadMediaFileWithHashReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 string
+			result3 error
+		})
+	}
+	fake.uploadMediaFileWithHashReturnsOnCall[i] = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeUploader) UploadMetaFile(arg1 string) (string, string, error) {
 	fake.uploadMetaFileMutex.Lock()
 	ret, specificReturn := fake.uploadMetaFileReturnsOnCall[len(fake.uploadMetaFileArgsForCall)]
@@ -191,6 +308,74 @@ func (fake *FakeUploader) UploadMetaFileReturnsOnCall(i int, result1 string, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeUploader) UploadMetaFileWithHash(arg1 string, arg2 hash.Hash) (string, string, error) {
+	fake.uploadMetaFileWithHashMutex.Lock()
+	ret, specificReturn := fake.uploadMetaFileWithHashReturnsOnCall[len(fake.uploadMetaFileWithHashArgsForCall)]
+	fake.uploadMetaFileWithHashArgsForCall = append(fake.uploadMetaFileWithHashArgsForCall, struct {
+		arg1 string
+		arg2 hash.Hash
+	}{arg1, arg2})
+	stub := fake.UploadMetaFileWithHashStub
+	fakeReturns := fake.uploadMetaFileWithHashReturns
+	fake.recordInvocation("UploadMetaFileWithHash", []interface{}{arg1, arg2})
+	fake.uploadMetaFileWithHashMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeUploader) UploadMetaFileWithHashCallCount() int {
+	fake.uploadMetaFileWithHashMutex.RLock()
+	defer fake.uploadMetaFileWithHashMutex.RUnlock()
+	return len(fake.uploadMetaFileWithHashArgsForCall)
+}
+
+func (fake *FakeUploader) UploadMetaFileWithHashCalls(stub func(string, hash.Hash) (string, string, error)) {
+	fake.uploadMetaFileWithHashMutex.Lock()
+	defer fake.uploadMetaFileWithHashMutex.Unlock()
+	fake.UploadMetaFileWithHashStub = stub
+}
+
+func (fake *FakeUploader) UploadMetaFileWithHashArgsForCall(i int) (string, hash.Hash) {
+	fake.uploadMetaFileWithHashMutex.RLock()
+	defer fake.uploadMetaFileWithHashMutex.RUnlock()
+	argsForCall := fake.uploadMetaFileWithHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUploader) UploadMetaFileWithHashReturns(result1 string, result2 string, result3 error) {
+	fake.uploadMetaFileWithHashMutex.Lock()
+	defer fake.uploadMetaFileWithHashMutex.Unlock()
+	fake.UploadMetaFileWithHashStub = nil
+	fake.uploadMetaFileWithHashReturns = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeUploader) UploadMetaFileWithHashReturnsOnCall(i int, result1 string, result2 string, result3 error) {
+	fake.uploadMetaFileWithHashMutex.Lock()
+	defer fake.uploadMetaFileWithHashMutex.Unlock()
+	fake.UploadMetaFileWithHashStub = nil
+	if fake.uploadMetaFileWithHashReturnsOnCall == nil {
+		fake.uploadMetaFileWithHashReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 string
+			result3 error
+		})
+	}
+	fake.uploadMetaFileWithHashReturnsOnCall[i] = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeUploader) UploadProductFile(arg1 string) (string, string, error) {
 	fake.uploadProductFileMutex.Lock()
 	ret, specificReturn := fake.uploadProductFileReturnsOnCall[len(fake.uploadProductFileArgsForCall)]
@@ -258,15 +443,89 @@ func (fake *FakeUploader) UploadProductFileReturnsOnCall(i int, result1 string,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeUploader) UploadProductFileWithHash(arg1 string, arg2 hash.Hash) (string, string, error) {
+	fake.uploadProductFileWithHashMutex.Lock()
+	ret, specificReturn := fake.uploadProductFileWithHashReturnsOnCall[len(fake.uploadProductFileWithHashArgsForCall)]
+	fake.uploadProductFileWithHashArgsForCall = append(fake.uploadProductFileWithHashArgsForCall, struct {
+		arg1 string
+		arg2 hash.Hash
+	}{arg1, arg2})
+	stub := fake.UploadProductFileWithHashStub
+	fakeReturns := fake.uploadProductFileWithHashReturns
+	fake.recordInvocation("UploadProductFileWithHash", []interface{}{arg1, arg2})
+	fake.uploadProductFileWithHashMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeUploader) UploadProductFileWithHashCallCount() int {
+	fake.uploadProductFileWithHashMutex.RLock()
+	defer fake.uploadProductFileWithHashMutex.RUnlock()
+	return len(fake.uploadProductFileWithHashArgsForCall)
+}
+
+func (fake *FakeUploader) UploadProductFileWithHashCalls(stub func(string, hash.Hash) (string, string, error)) {
+	fake.uploadProductFileWithHashMutex.Lock()
+	defer fake.uploadProductFileWithHashMutex.Unlock()
+	fake.UploadProductFileWithHashStub = stub
+}
+
+func (fake *FakeUploader) UploadProductFileWithHashArgsForCall(i int) (string, hash.Hash) {
+	fake.uploadProductFileWithHashMutex.RLock()
+	defer fake.uploadProductFileWithHashMutex.RUnlock()
+	argsForCall := fake.uploadProductFileWithHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeUploader) UploadProductFileWithHashReturns(result1 string, result2 string, result3 error) {
+	fake.uploadProductFileWithHashMutex.Lock()
+	defer fake.uploadProductFileWithHashMutex.Unlock()
+	fake.UploadProductFileWithHashStub = nil
+	fake.uploadProductFileWithHashReturns = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeUploader) UploadProductFileWithHashReturnsOnCall(i int, result1 string, result2 string, result3 error) {
+	fake.uploadProductFileWithHashMutex.Lock()
+	defer fake.uploadProductFileWithHashMutex.Unlock()
+	fake.UploadProductFileWithHashStub = nil
+	if fake.uploadProductFileWithHashReturnsOnCall == nil {
+		fake.uploadProductFileWithHashReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 string
+			result3 error
+		})
+	}
+	fake.uploadProductFileWithHashReturnsOnCall[i] = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeUploader) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.uploadMediaFileMutex.RLock()
 	defer fake.uploadMediaFileMutex.RUnlock()
+	fake.uploadMediaFileWithHashMutex.RLock()
+	defer fake.uploadMediaFileWithHashMutex.RUnlock()
 	fake.uploadMetaFileMutex.RLock()
 	defer fake.uploadMetaFileMutex.RUnlock()
+	fake.uploadMetaFileWithHashMutex.RLock()
+	defer fake.uploadMetaFileWithHashMutex.RUnlock()
 	fake.uploadProductFileMutex.RLock()
 	defer fake.uploadProductFileMutex.RUnlock()
+	fake.uploadProductFileWithHashMutex.RLock()
+	defer fake.uploadProductFileWithHashMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
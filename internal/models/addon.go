@@ -21,6 +21,7 @@ type AddOnFile struct {
 	DownloadCount    int64  `json:"downloadcount"`
 	IsRedirectURL    bool   `json:"isredirecturl"`
 	IsThirdPartyURL  bool   `json:"isthirdpartyurl"`
+	ThirdPartyURL    string `json:"thirdpartyurl,omitempty"`
 	Size             int64  `json:"size"`
 }
 
@@ -37,3 +38,18 @@ func (product *Product) GetAddonFilesForVersion(version string) []*AddOnFile {
 	}
 	return files
 }
+
+// RemoveAddonFile removes the add-on files with the given name from the version
+func (product *Product) RemoveAddonFile(version, name string) bool {
+	var files []*AddOnFile
+	for _, addonFile := range product.AddOnFiles {
+		if addonFile.AppVersion == version && addonFile.Name == name {
+			continue
+		}
+		files = append(files, addonFile)
+	}
+
+	removed := len(files) != len(product.AddOnFiles)
+	product.AddOnFiles = files
+	return removed
+}
@@ -24,6 +24,8 @@ type BlueprintFile struct {
 	FileID                 string  `json:"fileid"`
 	Title                  string  `json:"string"`
 	URL                    string  `json:"url"`
+	HashType               string  `json:"hashtype,omitempty"`
+	HashValue              string  `json:"hashvalue,omitempty"`
 	Status                 string  `json:"status"`
 	Metadata               string  `json:"metadata"`
 	Images                 []Image `json:"imagesList"`
@@ -38,3 +40,17 @@ type ProductBlueprintDetails struct {
 	BlueprintFiles []BlueprintFile `json:"blueprintfilesList"`
 	Prerequisites  []string        `json:"prerequisitesList"`
 }
+
+func (product *Product) GetBlueprintsForVersion(version string) []*ProductBlueprintDetails {
+	var blueprints []*ProductBlueprintDetails
+	versionObj := product.GetVersion(version)
+
+	if versionObj != nil {
+		for _, blueprint := range product.Blueprints {
+			if blueprint.Version == versionObj.Number {
+				blueprints = append(blueprints, blueprint)
+			}
+		}
+	}
+	return blueprints
+}
@@ -55,3 +55,35 @@ func (product *Product) GetChart(chartId string) *ChartVersion {
 	}
 	return nil
 }
+
+// GetChartsByName returns the charts for the version with the given name. If chartVersion is given, only charts with that version are returned.
+func (product *Product) GetChartsByName(version, name, chartVersion string) []*ChartVersion {
+	var charts []*ChartVersion
+	for _, chart := range product.ChartVersions {
+		if chart.matches(version, name, chartVersion) {
+			charts = append(charts, chart)
+		}
+	}
+	return charts
+}
+
+// RemoveCharts removes the charts for the version with the given name. If chartVersion is given, only charts with that version are removed.
+func (product *Product) RemoveCharts(version, name, chartVersion string) bool {
+	var charts []*ChartVersion
+	for _, chart := range product.ChartVersions {
+		if chart.matches(version, name, chartVersion) {
+			continue
+		}
+		charts = append(charts, chart)
+	}
+
+	removed := len(charts) != len(product.ChartVersions)
+	product.ChartVersions = charts
+	return removed
+}
+
+func (chart *ChartVersion) matches(version, name, chartVersion string) bool {
+	return chart.AppVersion == version &&
+		chart.Repo != nil && chart.Repo.Name == name &&
+		(chartVersion == "" || chart.Version == chartVersion)
+}
@@ -99,3 +99,41 @@ func (product *Product) GetContainerImagesForVersion(version string) []*DockerVe
 	}
 	return images
 }
+
+// RemoveContainerImage removes the image tag from the version, along with any image or version entry left without tags
+func (product *Product) RemoveContainerImage(version, imageURL, tag string) bool {
+	if !product.HasContainerImage(version, imageURL, tag) {
+		return false
+	}
+
+	var dockerLinkVersions []*DockerVersionList
+	for _, dockerVersionLink := range product.DockerLinkVersions {
+		if dockerVersionLink.AppVersion == version {
+			var dockerURLs []*DockerURLDetails
+			for _, dockerUrl := range dockerVersionLink.DockerURLs {
+				if dockerUrl.Url == imageURL && dockerUrl.HasTag(tag) {
+					var imageTags []*DockerImageTag
+					for _, imageTag := range dockerUrl.ImageTags {
+						if imageTag.Tag != tag {
+							imageTags = append(imageTags, imageTag)
+						}
+					}
+					dockerUrl.ImageTags = imageTags
+					if len(imageTags) == 0 {
+						continue
+					}
+				}
+				dockerURLs = append(dockerURLs, dockerUrl)
+			}
+
+			if len(dockerURLs) == 0 && len(dockerVersionLink.DockerURLs) > 0 {
+				continue
+			}
+			dockerVersionLink.DockerURLs = dockerURLs
+		}
+		dockerLinkVersions = append(dockerLinkVersions, dockerVersionLink)
+	}
+
+	product.DockerLinkVersions = dockerLinkVersions
+	return true
+}
@@ -52,3 +52,35 @@ func (product *Product) GetMetaFilesForVersion(version string) []*MetaFile {
 
 	return metafiles
 }
+
+// RemoveMetaFile removes the meta file objects with the given name from the version, along with any meta file left empty
+func (product *Product) RemoveMetaFile(version, filename string) bool {
+	removed := false
+	var metafiles []*MetaFile
+	for _, metafile := range product.MetaFiles {
+		if metafile.AppVersion != version {
+			metafiles = append(metafiles, metafile)
+			continue
+		}
+
+		var objects []*MetaFileObject
+		for _, object := range metafile.Objects {
+			if object.FileName != filename {
+				objects = append(objects, object)
+			}
+		}
+		if len(objects) == len(metafile.Objects) {
+			metafiles = append(metafiles, metafile)
+			continue
+		}
+
+		removed = true
+		metafile.Objects = objects
+		if len(metafile.Objects) > 0 {
+			metafiles = append(metafiles, metafile)
+		}
+	}
+
+	product.MetaFiles = metafiles
+	return removed
+}
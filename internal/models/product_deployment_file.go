@@ -74,3 +74,18 @@ func (f *ProductDeploymentFile) CalculateSize() int64 {
 	}
 	return size
 }
+
+// RemoveFile removes the deployment files with the given name from the version
+func (product *Product) RemoveFile(version, name string) bool {
+	var files []*ProductDeploymentFile
+	for _, file := range product.ProductDeploymentFiles {
+		if file.AppVersion == version && file.Name == name {
+			continue
+		}
+		files = append(files, file)
+	}
+
+	removed := len(files) != len(product.ProductDeploymentFiles)
+	product.ProductDeploymentFiles = files
+	return removed
+}
@@ -43,3 +43,86 @@ var _ = Describe("PrepForUpdate", func() {
 		})
 	})
 })
+
+var _ = Describe("RemoveContainerImage", func() {
+	var product *models.Product
+
+	BeforeEach(func() {
+		product = test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeImage)
+		test.AddVersions(product, "1.0.0", "2.0.0")
+		test.AddContainerImages(product, "1.0.0", "docker run it",
+			test.CreateFakeContainerImage("hyperspace-database", "1.0.0", "latest"),
+			test.CreateFakeContainerImage("hyperspace-client", "1.0.0"),
+		)
+		test.AddContainerImages(product, "2.0.0", "docker run it",
+			test.CreateFakeContainerImage("hyperspace-database", "1.0.0"),
+		)
+	})
+
+	It("removes the tag from the version", func() {
+		Expect(product.RemoveContainerImage("1.0.0", "hyperspace-database", "latest")).To(BeTrue())
+		Expect(product.HasContainerImage("1.0.0", "hyperspace-database", "latest")).To(BeFalse())
+		Expect(product.HasContainerImage("1.0.0", "hyperspace-database", "1.0.0")).To(BeTrue())
+		Expect(product.HasContainerImage("2.0.0", "hyperspace-database", "1.0.0")).To(BeTrue())
+	})
+
+	It("removes images and versions that are left without tags", func() {
+		Expect(product.RemoveContainerImage("1.0.0", "hyperspace-client", "1.0.0")).To(BeTrue())
+		Expect(product.GetContainerImagesForVersion("1.0.0")[0].DockerURLs).To(HaveLen(1))
+
+		Expect(product.RemoveContainerImage("2.0.0", "hyperspace-database", "1.0.0")).To(BeTrue())
+		Expect(product.GetContainerImagesForVersion("2.0.0")).To(BeEmpty())
+	})
+
+	It("returns false if the image is not attached", func() {
+		Expect(product.RemoveContainerImage("2.0.0", "hyperspace-database", "latest")).To(BeFalse())
+		Expect(product.DockerLinkVersions).To(HaveLen(2))
+	})
+})
+
+var _ = Describe("RemoveMetaFile", func() {
+	It("removes the meta file from the version", func() {
+		product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeOVA)
+		test.AddVersions(product, "1.0.0", "2.0.0")
+		product.MetaFiles = []*models.MetaFile{
+			test.CreateFakeMetaFile("deploy.sh", "0.0.1", "1.0.0"),
+			test.CreateFakeMetaFile("deploy.sh", "0.0.1", "2.0.0"),
+		}
+
+		Expect(product.RemoveMetaFile("1.0.0", "deploy.sh")).To(BeTrue())
+		Expect(product.GetMetaFilesForVersion("1.0.0")).To(BeEmpty())
+		Expect(product.GetMetaFilesForVersion("2.0.0")).To(HaveLen(1))
+
+		Expect(product.RemoveMetaFile("1.0.0", "deploy.sh")).To(BeFalse())
+	})
+})
+
+var _ = Describe("RemoveCharts", func() {
+	var product *models.Product
+
+	BeforeEach(func() {
+		product = test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeChart)
+		test.AddVersions(product, "1.0.0")
+		product.ChartVersions = []*models.ChartVersion{
+			{AppVersion: "1.0.0", Version: "0.1.0", Repo: &models.Repo{Name: "hyperspace-db"}},
+			{AppVersion: "1.0.0", Version: "0.2.0", Repo: &models.Repo{Name: "hyperspace-db"}},
+			{AppVersion: "1.0.0", Version: "0.1.0", Repo: &models.Repo{Name: "hyperspace-ui"}},
+		}
+	})
+
+	It("removes the charts with the name from the version", func() {
+		Expect(product.GetChartsByName("1.0.0", "hyperspace-db", "")).To(HaveLen(2))
+		Expect(product.RemoveCharts("1.0.0", "hyperspace-db", "")).To(BeTrue())
+		Expect(product.GetChartsForVersion("1.0.0")).To(HaveLen(1))
+		Expect(product.GetChartsByName("1.0.0", "hyperspace-ui", "")).To(HaveLen(1))
+
+		Expect(product.RemoveCharts("1.0.0", "hyperspace-db", "")).To(BeFalse())
+	})
+
+	It("only removes the chart with the name and version, if a version is given", func() {
+		Expect(product.RemoveCharts("1.0.0", "hyperspace-db", "0.2.0")).To(BeTrue())
+		charts := product.GetChartsByName("1.0.0", "hyperspace-db", "")
+		Expect(charts).To(HaveLen(1))
+		Expect(charts[0].Version).To(Equal("0.1.0"))
+	})
+})
@@ -0,0 +1,72 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package internal
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// MultiProgressBar draws several progress bars at the same time, each on its own line of the output.
+// It redraws every line whenever one of the bars changes, so the output needs to be a terminal.
+type MultiProgressBar struct {
+	output io.Writer
+	lock   sync.Mutex
+	lines  []string
+	drawn  int
+}
+
+func NewMultiProgressBar(output io.Writer) *MultiProgressBar {
+	return &MultiProgressBar{
+		output: output,
+	}
+}
+
+// AddLine returns the output for a new progress bar, which is drawn below the existing ones
+func (m *MultiProgressBar) AddLine() io.Writer {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.lines = append(m.lines, "")
+	return &multiProgressBarLine{
+		parent: m,
+		index:  len(m.lines) - 1,
+	}
+}
+
+func (m *MultiProgressBar) update(index int, line string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.lines[index] = line
+
+	if m.drawn > 0 {
+		_, _ = fmt.Fprintf(m.output, "\033[%dA", m.drawn)
+	}
+	for _, line := range m.lines {
+		_, _ = fmt.Fprintf(m.output, "\r\033[2K%s\n", line)
+	}
+	m.drawn = len(m.lines)
+}
+
+type multiProgressBarLine struct {
+	parent *MultiProgressBar
+	index  int
+}
+
+// Write keeps the latest text that the progress bar rendered, and ignores the carriage returns and blank
+// padding that it uses to redraw itself in place
+func (l *multiProgressBarLine) Write(p []byte) (int, error) {
+	text := strings.ReplaceAll(string(p), "\033[2K", "")
+	segments := strings.FieldsFunc(text, func(r rune) bool {
+		return r == '\r' || r == '\n'
+	})
+	for i := len(segments) - 1; i >= 0; i-- {
+		if strings.TrimSpace(segments[i]) != "" {
+			l.parent.update(l.index, strings.TrimRight(segments[i], " "))
+			break
+		}
+	}
+	return len(p), nil
+}
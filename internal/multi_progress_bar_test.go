@@ -0,0 +1,47 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package internal_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	"github.com/vmware-labs/marketplace-cli/v2/internal"
+)
+
+var _ = Describe("MultiProgressBar", func() {
+	var (
+		output   *Buffer
+		multiBar *internal.MultiProgressBar
+	)
+
+	BeforeEach(func() {
+		output = NewBuffer()
+		multiBar = internal.NewMultiProgressBar(output)
+	})
+
+	It("draws each progress bar on its own line", func() {
+		first := multiBar.AddLine()
+		second := multiBar.AddLine()
+
+		_, err := fmt.Fprint(first, "\rDownloading aaa.txt  10%")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(output.Contents())).To(Equal("\r\033[2KDownloading aaa.txt  10%\n\r\033[2K\n"))
+
+		_, err = fmt.Fprint(second, "\rDownloading bbb.txt  50%")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(output).To(Say("\033\\[2A\r\033\\[2KDownloading aaa.txt  10%\n\r\033\\[2KDownloading bbb.txt  50%\n"))
+	})
+
+	It("ignores the blank padding that progress bars use to clear themselves", func() {
+		line := multiBar.AddLine()
+		_, _ = fmt.Fprint(line, "\rDownloading aaa.txt 100%")
+		_, _ = fmt.Fprint(line, "\r                        \r")
+		_, _ = fmt.Fprintln(line, "")
+
+		Expect(string(output.Contents())).To(Equal("\r\033[2KDownloading aaa.txt 100%\n"))
+	})
+})
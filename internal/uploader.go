@@ -4,18 +4,21 @@
 package internal
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
@@ -24,6 +27,17 @@ const (
 	FolderMediaFiles   = "media-files"
 	FolderMetaFiles    = "meta-files"
 	FolderProductFiles = "marketplace-product-files"
+
+	DefaultUploadPartSize    int64 = 32 * 1024 * 1024
+	MinimumUploadPartSize    int64 = 5 * 1024 * 1024
+	DefaultUploadConcurrency       = 4
+	MaxUploadParts                 = 10000
+)
+
+var (
+	// UploadPartAttempts is how many times a single part of a multipart upload is tried before giving up
+	UploadPartAttempts = 3
+	UploadRetryDelay   = 2 * time.Second
 )
 
 func now() string {
@@ -35,26 +49,35 @@ type Uploader interface {
 	UploadMediaFile(filePath string) (string, string, error)
 	UploadMetaFile(filePath string) (string, string, error)
 	UploadProductFile(filePath string) (string, string, error)
+
+	// The WithHash variants also write the file to the hasher as it is read for the upload, so the file is only read once
+	UploadMediaFileWithHash(filePath string, hasher hash.Hash) (string, string, error)
+	UploadMetaFileWithHash(filePath string, hasher hash.Hash) (string, string, error)
+	UploadProductFileWithHash(filePath string, hasher hash.Hash) (string, string, error)
 }
 
 type S3Uploader struct {
-	bucket string
-	region string
-	orgID  string
-	client S3Client
-	output io.Writer
+	bucket      string
+	region      string
+	orgID       string
+	client      S3Client
+	output      io.Writer
+	partSize    int64
+	concurrency int
 }
 
 //go:generate counterfeiter . S3Client
 type S3Client interface {
 	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
 }
 
-func NewS3Client(region string, creds aws.Credentials) S3Client {
+func NewS3Client(region string, credentialsProvider aws.CredentialsProvider) S3Client {
 	s3Config, err := config.LoadDefaultConfig(context.Background(),
-		config.WithCredentialsProvider(credentials.StaticCredentialsProvider{
-			Value: creds,
-		}),
+		config.WithCredentialsProvider(credentialsProvider),
 		config.WithRegion(region),
 	)
 	if err != nil {
@@ -66,66 +89,252 @@ func NewS3Client(region string, creds aws.Credentials) S3Client {
 
 func NewS3Uploader(bucket, region, orgID string, client S3Client, output io.Writer) *S3Uploader {
 	return &S3Uploader{
-		bucket: bucket,
-		orgID:  orgID,
-		region: region,
-		client: client,
-		output: output,
+		bucket:      bucket,
+		orgID:       orgID,
+		region:      region,
+		client:      client,
+		output:      output,
+		partSize:    DefaultUploadPartSize,
+		concurrency: DefaultUploadConcurrency,
+	}
+}
+
+// SetPartSize sets the size of each part for files that are uploaded in multiple parts.
+// Files that fit in a single part are uploaded in one request.
+func (u *S3Uploader) SetPartSize(partSize int64) {
+	if partSize < MinimumUploadPartSize {
+		partSize = MinimumUploadPartSize
 	}
+	u.partSize = partSize
+}
+
+// SetConcurrency sets how many parts are uploaded at the same time
+func (u *S3Uploader) SetConcurrency(concurrency int) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	u.concurrency = concurrency
 }
 
 func (u *S3Uploader) UploadMediaFile(filePath string) (string, string, error) {
+	return u.UploadMediaFileWithHash(filePath, nil)
+}
+
+func (u *S3Uploader) UploadMetaFile(filePath string) (string, string, error) {
+	return u.UploadMetaFileWithHash(filePath, nil)
+}
+
+func (u *S3Uploader) UploadProductFile(filePath string) (string, string, error) {
+	return u.UploadProductFileWithHash(filePath, nil)
+}
+
+func (u *S3Uploader) UploadMediaFileWithHash(filePath string, hasher hash.Hash) (string, string, error) {
 	filename := filepath.Base(filePath)
 	key := path.Join(u.orgID, FolderMediaFiles, now(), filename)
 	url := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", u.bucket, u.region, key)
-	err := u.upload(filePath, key, types.ObjectCannedACLPublicRead)
+	err := u.upload(filePath, key, types.ObjectCannedACLPublicRead, hasher)
 	return filename, url, err
 }
 
-func (u *S3Uploader) UploadMetaFile(filePath string) (string, string, error) {
+func (u *S3Uploader) UploadMetaFileWithHash(filePath string, hasher hash.Hash) (string, string, error) {
 	filename := filepath.Base(filePath)
 	datestamp := now()
 	key := path.Join(u.orgID, FolderMetaFiles, datestamp, filename)
 	url := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", u.bucket, u.region, key)
-	err := u.upload(filePath, key, types.ObjectCannedACLPrivate)
+	err := u.upload(filePath, key, types.ObjectCannedACLPrivate, hasher)
 	return filename, url, err
 }
 
-func (u *S3Uploader) UploadProductFile(filePath string) (string, string, error) {
+func (u *S3Uploader) UploadProductFileWithHash(filePath string, hasher hash.Hash) (string, string, error) {
 	filename := filepath.Base(filePath)
 	datestamp := now()
 	key := path.Join(u.orgID, FolderProductFiles, datestamp, filename)
 	url := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", u.bucket, u.region, key)
-	err := u.upload(filePath, key, types.ObjectCannedACLPrivate)
+	err := u.upload(filePath, key, types.ObjectCannedACLPrivate, hasher)
 	return filename, url, err
 }
 
-func (u *S3Uploader) upload(filePath, key string, acl types.ObjectCannedACL) error {
+func (u *S3Uploader) upload(filePath, key string, acl types.ObjectCannedACL, hasher hash.Hash) error {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to open %s: %w", filePath, err)
 	}
+	defer file.Close()
+
 	stat, err := file.Stat()
 	if err != nil {
 		return fmt.Errorf("failed to get info for %s: %w", filePath, err)
 	}
 
+	var source io.Reader = file
+	if hasher != nil {
+		source = io.TeeReader(file, hasher)
+	}
+
 	progressBar := MakeProgressBar(fmt.Sprintf("Uploading %s", path.Base(file.Name())), stat.Size(), u.output)
-	_, err = u.client.PutObject(context.Background(), &s3.PutObjectInput{
-		ACL:           acl,
-		Bucket:        aws.String(u.bucket),
-		Key:           aws.String(key),
-		Body:          progressBar.WrapReader(file),
-		ContentLength: stat.Size(),
-	})
+	if stat.Size() > u.partSize {
+		// Parts are read faster than they are uploaded, so the progress bar only moves as each part finishes
+		err = u.multipartUpload(source, stat.Size(), key, acl, progressBar.WrapWriter(io.Discard))
+	} else {
+		_, err = u.client.PutObject(context.Background(), &s3.PutObjectInput{
+			ACL:           acl,
+			Bucket:        aws.String(u.bucket),
+			Key:           aws.String(key),
+			Body:          progressBar.WrapReader(source),
+			ContentLength: stat.Size(),
+		})
+	}
 	if err != nil {
 		return fmt.Errorf("failed to upload file: %w", err)
 	}
+	return nil
+}
+
+type uploadPart struct {
+	number int32
+	data   []byte
+}
+
+// multipartUpload uploads the file in parts, aborting the upload if any part fails so that no parts are left behind.
+// The data of each part is written to progress once that part has been uploaded.
+func (u *S3Uploader) multipartUpload(source io.Reader, size int64, key string, acl types.ObjectCannedACL, progress io.Writer) error {
+	partSize := u.partSize
+	if size > partSize*MaxUploadParts {
+		partSize = (size + MaxUploadParts - 1) / MaxUploadParts
+	}
 
-	err = file.Close()
+	upload, err := u.client.CreateMultipartUpload(context.Background(), &s3.CreateMultipartUploadInput{
+		ACL:    acl,
+		Bucket: aws.String(u.bucket),
+		Key:    aws.String(key),
+	})
 	if err != nil {
-		return fmt.Errorf("failed to close file: %w", err)
+		return fmt.Errorf("failed to start multipart upload: %w", err)
 	}
 
-	return nil
+	parts, err := u.uploadParts(source, partSize, key, upload.UploadId, progress)
+	if err == nil {
+		_, err = u.client.CompleteMultipartUpload(context.Background(), &s3.CompleteMultipartUploadInput{
+			Bucket:   aws.String(u.bucket),
+			Key:      aws.String(key),
+			UploadId: upload.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: parts,
+			},
+		})
+		if err == nil {
+			return nil
+		}
+		err = fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+
+	_, abortErr := u.client.AbortMultipartUpload(context.Background(), &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(u.bucket),
+		Key:      aws.String(key),
+		UploadId: upload.UploadId,
+	})
+	if abortErr != nil {
+		return fmt.Errorf("%w (also failed to abort the multipart upload: %s)", err, abortErr.Error())
+	}
+	return err
+}
+
+func (u *S3Uploader) uploadParts(source io.Reader, partSize int64, key string, uploadID *string, progress io.Writer) ([]types.CompletedPart, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		lock      sync.Mutex
+		completed []types.CompletedPart
+		uploadErr error
+	)
+	parts := make(chan *uploadPart)
+	wg := sync.WaitGroup{}
+	for i := 0; i < u.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for part := range parts {
+				if ctx.Err() != nil {
+					continue
+				}
+				completedPart, err := u.uploadPart(ctx, key, uploadID, part)
+
+				lock.Lock()
+				if err != nil {
+					if uploadErr == nil {
+						uploadErr = err
+					}
+					cancel()
+				} else {
+					completed = append(completed, completedPart)
+					_, _ = progress.Write(part.data)
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+	var readErr error
+	for number := int32(1); ctx.Err() == nil; number++ {
+		data := make([]byte, partSize)
+		length, err := io.ReadFull(source, data)
+		if length > 0 {
+			parts <- &uploadPart{number: number, data: data[:length]}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			readErr = fmt.Errorf("failed to read part %d: %w", number, err)
+			break
+		}
+	}
+	close(parts)
+	wg.Wait()
+
+	if readErr != nil {
+		return nil, readErr
+	}
+	if uploadErr != nil {
+		return nil, uploadErr
+	}
+
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[i].PartNumber < completed[j].PartNumber
+	})
+	return completed, nil
+}
+
+func (u *S3Uploader) uploadPart(ctx context.Context, key string, uploadID *string, part *uploadPart) (types.CompletedPart, error) {
+	var err error
+	for attempt := 1; attempt <= UploadPartAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return types.CompletedPart{}, fmt.Errorf("failed to upload part %d: %w", part.number, err)
+			case <-time.After(UploadRetryDelay):
+			}
+		}
+
+		var output *s3.UploadPartOutput
+		output, err = u.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        aws.String(u.bucket),
+			Key:           aws.String(key),
+			UploadId:      uploadID,
+			PartNumber:    part.number,
+			Body:          bytes.NewReader(part.data),
+			ContentLength: int64(len(part.data)),
+		})
+		if err == nil {
+			return types.CompletedPart{
+				ETag:       output.ETag,
+				PartNumber: part.number,
+			}, nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return types.CompletedPart{}, fmt.Errorf("failed to upload part %d: %w", part.number, err)
 }
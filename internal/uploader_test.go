@@ -4,10 +4,17 @@
 package internal_test
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gbytes"
@@ -178,4 +185,216 @@ var _ = Describe("Uploader", func() {
 			})
 		})
 	})
+
+	Describe("Multipart uploads", func() {
+		var (
+			largeFile      *os.File
+			output         *Buffer
+			progressWriter *bytes.Buffer
+			uploader       *internal.S3Uploader
+		)
+
+		BeforeEach(func() {
+			var err error
+			largeFile, err = os.CreateTemp("", "mkpcli-test-uploader-large-file-*.iso")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = largeFile.Write(make([]byte, 2*internal.MinimumUploadPartSize+1024))
+			Expect(err).ToNot(HaveOccurred())
+
+			client.CreateMultipartUploadReturns(&s3.CreateMultipartUploadOutput{
+				UploadId: aws.String("my-upload-id"),
+			}, nil)
+			client.UploadPartStub = func(ctx context.Context, input *s3.UploadPartInput, f ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+				return &s3.UploadPartOutput{
+					ETag: aws.String(fmt.Sprintf("etag-%d", input.PartNumber)),
+				}, nil
+			}
+
+			progressWriter = &bytes.Buffer{}
+			progressBar.WrapWriterStub = func(_ io.Writer) io.Writer { return progressWriter }
+
+			internal.UploadRetryDelay = 0
+			output = NewBuffer()
+			uploader = internal.NewS3Uploader("my-bucket", "my-region", "my-org", client, output)
+			uploader.SetPartSize(internal.MinimumUploadPartSize)
+		})
+
+		AfterEach(func() {
+			Expect(os.Remove(largeFile.Name())).To(Succeed())
+		})
+
+		It("uploads the file in parts", func() {
+			_, fileUrl, err := uploader.UploadProductFile(largeFile.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fileUrl).To(MatchRegexp("^https://my-bucket.s3.my-region.amazonaws.com/my-org/marketplace-product-files/[0-9]+/mkpcli-test-uploader-large-file-[0-9]+.iso$"))
+
+			By("starting a multipart upload", func() {
+				Expect(client.PutObjectCallCount()).To(Equal(0))
+				Expect(client.CreateMultipartUploadCallCount()).To(Equal(1))
+				_, input, _ := client.CreateMultipartUploadArgsForCall(0)
+				Expect(*input.Bucket).To(Equal("my-bucket"))
+				Expect(*input.Key).To(MatchRegexp("^my-org/marketplace-product-files/[0-9]+/mkpcli-test-uploader-large-file-[0-9]+.iso$"))
+				Expect(input.ACL).To(Equal(types.ObjectCannedACLPrivate))
+			})
+
+			By("uploading each part", func() {
+				Expect(client.UploadPartCallCount()).To(Equal(3))
+				sizes := map[int32]int64{}
+				for i := 0; i < client.UploadPartCallCount(); i++ {
+					_, input, _ := client.UploadPartArgsForCall(i)
+					Expect(*input.UploadId).To(Equal("my-upload-id"))
+					sizes[input.PartNumber] = input.ContentLength
+				}
+				Expect(sizes).To(Equal(map[int32]int64{
+					1: internal.MinimumUploadPartSize,
+					2: internal.MinimumUploadPartSize,
+					3: 1024,
+				}))
+			})
+
+			By("completing the upload with the parts in order", func() {
+				Expect(client.CompleteMultipartUploadCallCount()).To(Equal(1))
+				_, input, _ := client.CompleteMultipartUploadArgsForCall(0)
+				Expect(*input.UploadId).To(Equal("my-upload-id"))
+				Expect(input.MultipartUpload.Parts).To(HaveLen(3))
+				for i, part := range input.MultipartUpload.Parts {
+					Expect(part.PartNumber).To(Equal(int32(i + 1)))
+					Expect(*part.ETag).To(Equal(fmt.Sprintf("etag-%d", i+1)))
+				}
+				Expect(client.AbortMultipartUploadCallCount()).To(Equal(0))
+			})
+
+			By("updating a single progress bar as each part finishes", func() {
+				Expect(progressBarMaker.CallCount()).To(Equal(1))
+				_, size, _ := progressBarMaker.ArgsForCall(0)
+				Expect(size).To(Equal(2*internal.MinimumUploadPartSize + 1024))
+				Expect(progressBar.WrapReaderCallCount()).To(Equal(0))
+				Expect(progressBar.WrapWriterCallCount()).To(Equal(1))
+				Expect(progressWriter.Len()).To(Equal(int(2*internal.MinimumUploadPartSize + 1024)))
+			})
+		})
+
+		When("uploading a part fails once", func() {
+			BeforeEach(func() {
+				failed := false
+				client.UploadPartStub = func(ctx context.Context, input *s3.UploadPartInput, f ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+					if input.PartNumber == 2 && !failed {
+						failed = true
+						return nil, errors.New("upload part failed")
+					}
+					return &s3.UploadPartOutput{
+						ETag: aws.String(fmt.Sprintf("etag-%d", input.PartNumber)),
+					}, nil
+				}
+			})
+
+			It("retries the part", func() {
+				_, _, err := uploader.UploadProductFile(largeFile.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.UploadPartCallCount()).To(Equal(4))
+				Expect(client.CompleteMultipartUploadCallCount()).To(Equal(1))
+				Expect(progressWriter.Len()).To(Equal(int(2*internal.MinimumUploadPartSize + 1024)))
+			})
+		})
+
+		When("uploading a part keeps failing", func() {
+			BeforeEach(func() {
+				client.UploadPartStub = nil
+				client.UploadPartReturns(nil, errors.New("upload part failed"))
+				uploader.SetConcurrency(1)
+			})
+
+			It("aborts the upload and returns an error", func() {
+				_, _, err := uploader.UploadProductFile(largeFile.Name())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("failed to upload file: failed to upload part 1: upload part failed"))
+
+				Expect(client.UploadPartCallCount()).To(Equal(internal.UploadPartAttempts))
+				Expect(client.CompleteMultipartUploadCallCount()).To(Equal(0))
+				Expect(client.AbortMultipartUploadCallCount()).To(Equal(1))
+				_, input, _ := client.AbortMultipartUploadArgsForCall(0)
+				Expect(*input.UploadId).To(Equal("my-upload-id"))
+			})
+
+			When("aborting the upload fails", func() {
+				BeforeEach(func() {
+					client.AbortMultipartUploadReturns(nil, errors.New("abort failed"))
+				})
+
+				It("returns both errors", func() {
+					_, _, err := uploader.UploadProductFile(largeFile.Name())
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("failed to upload file: failed to upload part 1: upload part failed (also failed to abort the multipart upload: abort failed)"))
+				})
+			})
+		})
+
+		When("another part fails while a part is waiting to be retried", func() {
+			BeforeEach(func() {
+				internal.UploadRetryDelay = 10 * time.Millisecond
+				partOneAttempts := 0
+				partTwoAttempts := 0
+				lastAttempt := make(chan bool)
+				partTwoFailed := make(chan bool)
+				client.UploadPartStub = func(ctx context.Context, input *s3.UploadPartInput, f ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+					if input.PartNumber == 1 {
+						partOneAttempts += 1
+						if partOneAttempts == internal.UploadPartAttempts {
+							// Part 2 is retried after part 1 has failed for the last time
+							internal.UploadRetryDelay = time.Second
+							close(lastAttempt)
+							<-partTwoFailed
+							time.Sleep(10 * time.Millisecond)
+						}
+						return nil, errors.New("upload part 1 failed")
+					}
+					if input.PartNumber == 2 {
+						partTwoAttempts += 1
+						if partTwoAttempts == 1 {
+							<-lastAttempt
+							close(partTwoFailed)
+						}
+						return nil, errors.New("upload part 2 failed")
+					}
+					return &s3.UploadPartOutput{
+						ETag: aws.String(fmt.Sprintf("etag-%d", input.PartNumber)),
+					}, nil
+				}
+				uploader.SetConcurrency(2)
+			})
+
+			It("stops waiting to retry the part", func() {
+				_, _, err := uploader.UploadProductFile(largeFile.Name())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("failed to upload file: failed to upload part 1: upload part 1 failed"))
+				Expect(client.UploadPartCallCount()).To(Equal(internal.UploadPartAttempts + 1))
+			})
+		})
+
+		When("completing the upload fails", func() {
+			BeforeEach(func() {
+				client.CompleteMultipartUploadReturns(nil, errors.New("complete failed"))
+			})
+
+			It("aborts the upload and returns an error", func() {
+				_, _, err := uploader.UploadProductFile(largeFile.Name())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("failed to upload file: failed to complete multipart upload: complete failed"))
+				Expect(client.AbortMultipartUploadCallCount()).To(Equal(1))
+			})
+		})
+
+		When("starting the upload fails", func() {
+			BeforeEach(func() {
+				client.CreateMultipartUploadReturns(nil, errors.New("create failed"))
+			})
+
+			It("returns an error", func() {
+				_, _, err := uploader.UploadProductFile(largeFile.Name())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("failed to upload file: failed to start multipart upload: create failed"))
+				Expect(client.UploadPartCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
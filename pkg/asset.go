@@ -17,9 +17,12 @@ type Asset struct {
 	Size                   int64                   `json:"size"`
 	Downloadable           bool                    `json:"downloadable"`
 	Downloads              int64                   `json:"downloads"`
+	HashDigest             string                  `json:"hashdigest,omitempty"`
+	HashAlgorithm          string                  `json:"hashalgo,omitempty"`
 	DownloadRequestPayload *DownloadRequestPayload `json:"-"`
 	Error                  string                  `json:"error,omitempty"`
 	Status                 string                  `json:"status,omitempty"`
+	Processing             bool                    `json:"-"`
 }
 
 const (
@@ -28,6 +31,7 @@ const (
 	AssetTypeChart          = "Chart"
 	AssetTypeContainerImage = "Container Image"
 	AssetTypeMetaFile       = "MetaFile"
+	AssetTypeBlueprint      = "Blueprint"
 )
 
 func GetAssets(product *models.Product, version string) []*Asset {
@@ -38,59 +42,68 @@ func GetAssets(product *models.Product, version string) []*Asset {
 
 	for _, otherFile := range product.GetAddonFilesForVersion(version) {
 		assets = append(assets, &Asset{
-			DisplayName:  otherFile.Name,
-			Filename:     otherFile.Name,
-			Version:      version,
-			Type:         AssetTypeOther,
-			Size:         otherFile.Size,
-			Downloads:    otherFile.DownloadCount,
-			Downloadable: otherFile.Status != models.DeploymentStatusInactive,
+			DisplayName:   otherFile.Name,
+			Filename:      otherFile.Name,
+			Version:       version,
+			Type:          AssetTypeOther,
+			Size:          otherFile.Size,
+			Downloads:     otherFile.DownloadCount,
+			Downloadable:  otherFile.Status != models.DeploymentStatusInactive,
+			HashDigest:    otherFile.HashDigest,
+			HashAlgorithm: otherFile.HashAlgorithm,
 			DownloadRequestPayload: &DownloadRequestPayload{
 				ProductId:   product.ProductId,
 				AppVersion:  version,
 				IsAddonFile: true,
 				AddonFileId: otherFile.ID,
 			},
-			Error:  otherFile.DeploymentStatus,
-			Status: otherFile.Status,
+			Error:      otherFile.DeploymentStatus,
+			Status:     otherFile.Status,
+			Processing: otherFile.Status == models.DeploymentStatusNotProcessed,
 		})
 	}
 
 	for _, file := range product.GetFilesForVersion(version) {
 		assets = append(assets, &Asset{
-			DisplayName:  file.Name,
-			Filename:     file.Name,
-			Version:      version,
-			Type:         AssetTypeVM,
-			Size:         file.CalculateSize(),
-			Downloads:    file.DownloadCount,
-			Downloadable: file.Status != models.DeploymentStatusInactive,
+			DisplayName:   file.Name,
+			Filename:      file.Name,
+			Version:       version,
+			Type:          AssetTypeVM,
+			Size:          file.CalculateSize(),
+			Downloads:     file.DownloadCount,
+			Downloadable:  file.Status != models.DeploymentStatusInactive,
+			HashDigest:    file.HashDigest,
+			HashAlgorithm: file.HashAlgo,
 			DownloadRequestPayload: &DownloadRequestPayload{
 				ProductId:        product.ProductId,
 				AppVersion:       version,
 				DeploymentFileId: file.FileID,
 			},
-			Error:  file.Comment,
-			Status: file.Status,
+			Error:      file.Comment,
+			Status:     file.Status,
+			Processing: file.Status == models.DeploymentStatusNotProcessed,
 		})
 	}
 
 	for _, chart := range product.GetChartsForVersion(version) {
 		assets = append(assets, &Asset{
-			DisplayName:  chart.HelmTarUrl,
-			Filename:     "chart.tgz",
-			Version:      chart.Version,
-			Type:         AssetTypeChart,
-			Size:         chart.Size,
-			Downloadable: chart.IsUpdatedInMarketplaceRegistry,
-			Downloads:    chart.DownloadCount,
+			DisplayName:   chart.HelmTarUrl,
+			Filename:      "chart.tgz",
+			Version:       chart.Version,
+			Type:          AssetTypeChart,
+			Size:          chart.Size,
+			Downloadable:  chart.IsUpdatedInMarketplaceRegistry,
+			Downloads:     chart.DownloadCount,
+			HashDigest:    chart.HashDigest,
+			HashAlgorithm: chart.HashAlgorithm,
 			DownloadRequestPayload: &DownloadRequestPayload{
 				ProductId:    product.ProductId,
 				AppVersion:   version,
 				ChartVersion: chart.Version,
 			},
-			Error:  chart.ProcessingError,
-			Status: chart.Status,
+			Error:      chart.ProcessingError,
+			Status:     chart.Status,
+			Processing: !chart.IsUpdatedInMarketplaceRegistry && chart.ProcessingError == "",
 		})
 	}
 
@@ -114,32 +127,51 @@ func GetAssets(product *models.Product, version string) []*Asset {
 							DockerUrlId:         imageURL.ID,
 							ImageTagId:          tag.ID,
 						},
-						Error:  tag.ProcessingError,
-						Status: containerImage.Status,
+						Error:      tag.ProcessingError,
+						Status:     containerImage.Status,
+						Processing: !tag.IsUpdatedInMarketplaceRegistry && tag.ProcessingError == "",
 					})
 				}
 			}
 		}
 	}
 
+	// Blueprints are not downloaded through the Marketplace, so they do not have a download request payload
+	for _, blueprint := range product.GetBlueprintsForVersion(version) {
+		for _, blueprintFile := range blueprint.BlueprintFiles {
+			assets = append(assets, &Asset{
+				DisplayName:  blueprintFile.Title,
+				Filename:     blueprintFile.Title,
+				Version:      blueprintFile.VRAVersion,
+				Type:         AssetTypeBlueprint,
+				Downloadable: blueprintFile.Status != models.DeploymentStatusInactive,
+				Status:       blueprintFile.Status,
+				Processing:   blueprintFile.Status == models.DeploymentStatusNotProcessed,
+			})
+		}
+	}
+
 	for _, metafile := range product.GetMetaFilesForVersion(version) {
 		for _, object := range metafile.Objects {
 			assets = append(assets, &Asset{
-				DisplayName:  object.FileName,
-				Filename:     object.FileName,
-				Version:      metafile.Version,
-				Type:         AssetTypeMetaFile,
-				Size:         object.Size,
-				Downloads:    object.DownloadCount,
-				Downloadable: object.IsFileBackedUp, // Is this valid?
+				DisplayName:   object.FileName,
+				Filename:      object.FileName,
+				Version:       metafile.Version,
+				Type:          AssetTypeMetaFile,
+				Size:          object.Size,
+				Downloads:     object.DownloadCount,
+				Downloadable:  object.IsFileBackedUp, // Is this valid?
+				HashDigest:    object.HashDigest,
+				HashAlgorithm: object.HashAlgorithm,
 				DownloadRequestPayload: &DownloadRequestPayload{
 					ProductId:        product.ProductId,
 					AppVersion:       version,
 					MetaFileID:       metafile.ID,
 					MetaFileObjectID: object.FileID,
 				},
-				Error:  object.ProcessingError,
-				Status: metafile.Status,
+				Error:      object.ProcessingError,
+				Status:     metafile.Status,
+				Processing: metafile.Status == models.DeploymentStatusNotProcessed && object.ProcessingError == "",
 			})
 		}
 	}
@@ -52,6 +52,8 @@ var _ = Describe("Asset", func() {
 				Expect(strconv.FormatInt(assets[0].Size, 10)).To(Equal("1000"))
 				Expect(strconv.FormatInt(assets[0].Downloads, 10)).To(Equal("5"))
 				Expect(assets[0].Downloadable).To(BeTrue())
+				Expect(assets[0].HashDigest).To(Equal("B32D37F785AA865CF6B36EEDC65D4A81AEEC10DF6BF028CDF2F77679D2583937"))
+				Expect(assets[0].HashAlgorithm).To(Equal("SHA256"))
 
 				Expect(assets[0].DownloadRequestPayload.ProductId).To(Equal(product.ProductId))
 				Expect(assets[0].DownloadRequestPayload.AppVersion).To(Equal("1"))
@@ -98,6 +100,18 @@ var _ = Describe("Asset", func() {
 				Expect(assets[0].DownloadRequestPayload.ProductId).To(Equal(product.ProductId))
 				Expect(assets[0].DownloadRequestPayload.AppVersion).To(Equal("1"))
 				Expect(assets[0].DownloadRequestPayload.ChartVersion).To(Equal("1.0.0"))
+				Expect(assets[0].Processing).To(BeFalse())
+			})
+
+			When("the chart is still being processed", func() {
+				BeforeEach(func() {
+					chart.IsUpdatedInMarketplaceRegistry = false
+				})
+
+				It("marks the asset as processing", func() {
+					assets := pkg.GetAssets(product, "1")
+					Expect(assets[0].Processing).To(BeTrue())
+				})
 			})
 		})
 
@@ -149,6 +163,27 @@ var _ = Describe("Asset", func() {
 			})
 		})
 
+		Context("Blueprint", func() {
+			var product *models.Product
+			BeforeEach(func() {
+				product = test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeOthers)
+				test.AddVersions(product, "1")
+				product.Blueprints = append(product.Blueprints, test.CreateFakeBlueprint("hyperspace-database.zip", "1"))
+			})
+
+			It("returns the blueprint", func() {
+				assets := pkg.GetAssets(product, "1")
+				Expect(assets).To(HaveLen(1))
+
+				Expect(assets[0].DisplayName).To(Equal("hyperspace-database.zip"))
+				Expect(assets[0].Filename).To(Equal("hyperspace-database.zip"))
+				Expect(assets[0].Version).To(Equal("8.11"))
+				Expect(assets[0].Type).To(Equal("Blueprint"))
+				Expect(assets[0].Downloadable).To(BeTrue())
+				Expect(assets[0].DownloadRequestPayload).To(BeNil())
+			})
+		})
+
 		Context("VM and MetaFile", func() {
 			var (
 				product  *models.Product
@@ -198,6 +233,29 @@ var _ = Describe("Asset", func() {
 					Expect(assets[1].DownloadRequestPayload.MetaFileObjectID).To(Equal(metafile.Objects[0].FileID))
 				})
 			})
+
+			When("the meta file has not been backed up", func() {
+				BeforeEach(func() {
+					metafile.Status = models.DeploymentStatusActive
+					metafile.Objects[0].IsFileBackedUp = false
+				})
+
+				It("is not marked as processing", func() {
+					assets := pkg.GetAssetsByType(pkg.AssetTypeMetaFile, product, "1")
+					Expect(assets[0].Processing).To(BeFalse())
+				})
+
+				When("the meta file is not processed yet", func() {
+					BeforeEach(func() {
+						metafile.Status = models.DeploymentStatusNotProcessed
+					})
+
+					It("is marked as processing", func() {
+						assets := pkg.GetAssetsByType(pkg.AssetTypeMetaFile, product, "1")
+						Expect(assets[0].Processing).To(BeTrue())
+					})
+				})
+			})
 		})
 	})
 
@@ -0,0 +1,90 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package pkg
+
+import (
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+)
+
+// Blueprint is a vRealize Automation blueprint to attach to a product, along with its supporting files and images
+type Blueprint struct {
+	File          string
+	Files         []string
+	Images        []string
+	VRAVersion    string
+	Instructions  string
+	Prerequisites []string
+}
+
+func (m *Marketplace) AttachBlueprint(blueprint *Blueprint, product *models.Product, version *models.Version) (*models.Product, error) {
+	uploader, err := m.GetUploader(product.PublisherDetails.OrgId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blueprint files are always hashed with SHA1, which is the only algorithm the Marketplace is known to accept for them
+	filename, fileUrl, hashString, err := uploadAndHash(blueprint.File, models.HashAlgoSHA1, uploader.UploadProductFileWithHash)
+	if err != nil {
+		return nil, err
+	}
+	blueprintFile := models.BlueprintFile{
+		Title:                  filename,
+		URL:                    fileUrl,
+		HashType:               models.HashAlgoSHA1,
+		HashValue:              hashString,
+		Images:                 []models.Image{},
+		Files:                  []models.File{},
+		VRAVersion:             blueprint.VRAVersion,
+		DeploymentInstructions: blueprint.Instructions,
+	}
+
+	for _, file := range blueprint.Files {
+		filename, fileUrl, hashString, err := uploadAndHash(file, models.HashAlgoSHA1, uploader.UploadProductFileWithHash)
+		if err != nil {
+			return nil, err
+		}
+		blueprintFile.Files = append(blueprintFile.Files, models.File{
+			Title:     filename,
+			URL:       fileUrl,
+			HashType:  models.HashAlgoSHA1,
+			HashValue: hashString,
+		})
+	}
+
+	for _, image := range blueprint.Images {
+		_, imageUrl, hashString, err := uploadAndHash(image, models.HashAlgoSHA1, uploader.UploadMediaFileWithHash)
+		if err != nil {
+			return nil, err
+		}
+		blueprintFile.Images = append(blueprintFile.Images, models.Image{
+			URL:       imageUrl,
+			HashType:  models.HashAlgoSHA1,
+			HashValue: hashString,
+		})
+	}
+
+	prerequisites := blueprint.Prerequisites
+	if prerequisites == nil {
+		prerequisites = []string{}
+	}
+
+	product.PrepForUpdate()
+	// Blueprints for other versions are kept, and a version that already has blueprints gets this one added to them
+	for _, details := range product.Blueprints {
+		if details.Version == version.Number {
+			details.Instructions = blueprint.Instructions
+			details.BlueprintFiles = append(details.BlueprintFiles, blueprintFile)
+			details.Prerequisites = prerequisites
+			return m.PutProduct(product, version.IsNewVersion)
+		}
+	}
+	product.Blueprints = append(product.Blueprints, &models.ProductBlueprintDetails{
+		Version:        version.Number,
+		Instructions:   blueprint.Instructions,
+		BlueprintFiles: []models.BlueprintFile{blueprintFile},
+		Prerequisites:  prerequisites,
+	})
+
+	return m.PutProduct(product, version.IsNewVersion)
+}
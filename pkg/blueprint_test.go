@@ -0,0 +1,196 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package pkg_test
+
+import (
+	"errors"
+	"hash"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/internalfakes"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg/pkgfakes"
+	"github.com/vmware-labs/marketplace-cli/v2/test"
+)
+
+var _ = Describe("Blueprint", func() {
+	var (
+		httpClient  *pkgfakes.FakeHTTPClient
+		marketplace *pkg.Marketplace
+		uploader    *internalfakes.FakeUploader
+	)
+
+	BeforeEach(func() {
+		viper.Set("csp.refresh-token", "secrets")
+		httpClient = &pkgfakes.FakeHTTPClient{}
+		marketplace = &pkg.Marketplace{
+			Client: httpClient,
+			Host:   "marketplace.vmware.example",
+		}
+		uploader = &internalfakes.FakeUploader{}
+		marketplace.SetUploader(uploader)
+	})
+
+	Describe("AttachBlueprint", func() {
+		var (
+			blueprint *pkg.Blueprint
+			files     []string
+		)
+
+		BeforeEach(func() {
+			files = []string{}
+			for _, name := range []string{"blueprint-*.zip", "readme-*.txt", "diagram-*.png"} {
+				file, err := os.CreateTemp("", name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(file.Close()).To(Succeed())
+				files = append(files, file.Name())
+			}
+			blueprint = &pkg.Blueprint{
+				File:          files[0],
+				Files:         []string{files[1]},
+				Images:        []string{files[2]},
+				VRAVersion:    "8.11",
+				Instructions:  "Import the blueprint",
+				Prerequisites: []string{"vRealize Automation 8.11"},
+			}
+
+			uploader.UploadProductFileWithHashStub = func(filePath string, _ hash.Hash) (string, string, error) {
+				if filePath == files[0] {
+					return "blueprint.zip", "https://example.com/blueprint.zip", nil
+				}
+				return "readme.txt", "https://example.com/readme.txt", nil
+			}
+			uploader.UploadMediaFileWithHashReturns("diagram.png", "https://example.com/diagram.png", nil)
+			httpClient.PutStub = PutProductEchoResponse
+		})
+
+		AfterEach(func() {
+			for _, file := range files {
+				Expect(os.Remove(file)).To(Succeed())
+			}
+		})
+
+		It("uploads and attaches the blueprint", func() {
+			product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeOthers)
+			test.AddVersions(product, "1.2.3")
+
+			updatedProduct, err := marketplace.AttachBlueprint(blueprint, product, &models.Version{Number: "1.2.3"})
+			Expect(err).ToNot(HaveOccurred())
+
+			By("uploading the blueprint, supporting files and images", func() {
+				Expect(uploader.UploadProductFileWithHashCallCount()).To(Equal(2))
+				blueprintFile, _ := uploader.UploadProductFileWithHashArgsForCall(0)
+				Expect(blueprintFile).To(Equal(files[0]))
+				supportingFile, _ := uploader.UploadProductFileWithHashArgsForCall(1)
+				Expect(supportingFile).To(Equal(files[1]))
+				Expect(uploader.UploadMediaFileWithHashCallCount()).To(Equal(1))
+				image, _ := uploader.UploadMediaFileWithHashArgsForCall(0)
+				Expect(image).To(Equal(files[2]))
+			})
+
+			By("updating the product in the marketplace", func() {
+				blueprints := updatedProduct.GetBlueprintsForVersion("1.2.3")
+				Expect(blueprints).To(HaveLen(1))
+				Expect(blueprints[0].Instructions).To(Equal("Import the blueprint"))
+				Expect(blueprints[0].Prerequisites).To(Equal([]string{"vRealize Automation 8.11"}))
+				Expect(blueprints[0].BlueprintFiles).To(HaveLen(1))
+
+				blueprintFile := blueprints[0].BlueprintFiles[0]
+				Expect(blueprintFile.Title).To(Equal("blueprint.zip"))
+				Expect(blueprintFile.URL).To(Equal("https://example.com/blueprint.zip"))
+				Expect(blueprintFile.HashType).To(Equal("SHA1"))
+				Expect(blueprintFile.HashValue).To(Equal("da39a3ee5e6b4b0d3255bfef95601890afd80709"))
+				Expect(blueprintFile.VRAVersion).To(Equal("8.11"))
+				Expect(blueprintFile.DeploymentInstructions).To(Equal("Import the blueprint"))
+
+				Expect(blueprintFile.Files).To(HaveLen(1))
+				Expect(blueprintFile.Files[0].Title).To(Equal("readme.txt"))
+				Expect(blueprintFile.Files[0].URL).To(Equal("https://example.com/readme.txt"))
+				Expect(blueprintFile.Files[0].HashType).To(Equal("SHA1"))
+				Expect(blueprintFile.Files[0].HashValue).To(Equal("da39a3ee5e6b4b0d3255bfef95601890afd80709"))
+
+				Expect(blueprintFile.Images).To(HaveLen(1))
+				Expect(blueprintFile.Images[0].URL).To(Equal("https://example.com/diagram.png"))
+				Expect(blueprintFile.Images[0].HashType).To(Equal("SHA1"))
+				Expect(blueprintFile.Images[0].HashValue).To(Equal("da39a3ee5e6b4b0d3255bfef95601890afd80709"))
+			})
+		})
+
+		When("the product already has blueprints", func() {
+			It("keeps the blueprints for other versions, and adds this one to the blueprints for its version", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeOthers)
+				test.AddVersions(product, "1.2.2", "1.2.3")
+				product.Blueprints = []*models.ProductBlueprintDetails{
+					test.CreateFakeBlueprint("old-blueprint.zip", "1.2.2"),
+					test.CreateFakeBlueprint("other-blueprint.zip", "1.2.3"),
+				}
+
+				updatedProduct, err := marketplace.AttachBlueprint(blueprint, product, &models.Version{Number: "1.2.3"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(updatedProduct.Blueprints).To(HaveLen(2))
+				oldBlueprints := updatedProduct.GetBlueprintsForVersion("1.2.2")
+				Expect(oldBlueprints).To(HaveLen(1))
+				Expect(oldBlueprints[0].BlueprintFiles[0].Title).To(Equal("old-blueprint.zip"))
+
+				blueprints := updatedProduct.GetBlueprintsForVersion("1.2.3")
+				Expect(blueprints).To(HaveLen(1))
+				Expect(blueprints[0].BlueprintFiles).To(HaveLen(2))
+				Expect(blueprints[0].BlueprintFiles[0].Title).To(Equal("other-blueprint.zip"))
+				Expect(blueprints[0].BlueprintFiles[1].Title).To(Equal("blueprint.zip"))
+			})
+		})
+
+		When("a different hash algorithm is set", func() {
+			BeforeEach(func() {
+				marketplace.HashAlgorithm = "sha256"
+			})
+			It("still hashes the blueprint files with SHA1", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeOthers)
+				test.AddVersions(product, "1.2.3")
+
+				updatedProduct, err := marketplace.AttachBlueprint(blueprint, product, &models.Version{Number: "1.2.3"})
+				Expect(err).ToNot(HaveOccurred())
+
+				blueprintFile := updatedProduct.GetBlueprintsForVersion("1.2.3")[0].BlueprintFiles[0]
+				Expect(blueprintFile.HashType).To(Equal("SHA1"))
+				Expect(blueprintFile.Files[0].HashType).To(Equal("SHA1"))
+				Expect(blueprintFile.Images[0].HashType).To(Equal("SHA1"))
+			})
+		})
+
+		When("uploading the blueprint fails", func() {
+			BeforeEach(func() {
+				uploader.UploadProductFileWithHashStub = nil
+				uploader.UploadProductFileWithHashReturns("", "", errors.New("upload product file failed"))
+			})
+			It("returns an error", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeOthers)
+				test.AddVersions(product, "1.2.3")
+
+				_, err := marketplace.AttachBlueprint(blueprint, product, &models.Version{Number: "1.2.3"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("upload product file failed"))
+			})
+		})
+
+		When("updating the product fails", func() {
+			BeforeEach(func() {
+				httpClient.PutReturns(nil, errors.New("put product failed"))
+			})
+			It("returns an error", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeOthers)
+				test.AddVersions(product, "1.2.3")
+
+				_, err := marketplace.AttachBlueprint(blueprint, product, &models.Version{Number: "1.2.3"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("sending the update for product \"hyperspace-database\" failed: put product failed"))
+			})
+		})
+	})
+})
@@ -9,10 +9,18 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
 	helmChart "helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/lint"
+	"helm.sh/helm/v3/pkg/lint/support"
+	"helm.sh/helm/v3/pkg/registry"
 )
 
 //go:generate counterfeiter . ChartLoaderFunc
@@ -20,46 +28,104 @@ type ChartLoaderFunc func(name string) (*helmChart.Chart, error)
 
 var ChartLoader ChartLoaderFunc = loader.Load
 
+//go:generate counterfeiter . ChartPullerFunc
+type ChartPullerFunc func(ref string) ([]byte, error)
+
+var ChartPuller ChartPullerFunc = pullChart
+
+// pullChart pulls a chart archive from an OCI registry, using the credentials from the Helm registry config or the Docker config
+func pullChart(ref string) ([]byte, error) {
+	client, err := registry.NewClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registry client: %w", err)
+	}
+
+	result, err := client.Pull(ref)
+	if err != nil {
+		return nil, err
+	}
+	return result.Chart.Data, nil
+}
+
 func LoadChart(chartPath string) (*models.ChartVersion, error) {
 	chart, err := ChartLoader(chartPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read chart: %w", err)
 	}
 
-	return &models.ChartVersion{
+	chartVersion := &models.ChartVersion{
 		Version: chart.Metadata.Version,
+		Details: chart.Metadata.Description,
 		Repo: &models.Repo{
 			Name: chart.Name(),
 		},
+		Readme:        chartFile(chart.Files, "README.md", "README.txt", "README"),
+		Values:        chartFile(chart.Raw, chartutil.ValuesfileName),
 		IsExternalUrl: false,
-	}, nil
+	}
+
+	// Charts can also be loaded from a directory, which does not have a digest or size until it is packaged
+	info, err := os.Stat(chartPath)
+	if err == nil && info.Mode().IsRegular() {
+		hashString, err := Hash(chartPath, models.HashAlgoSHA256)
+		if err != nil {
+			return nil, err
+		}
+		chartVersion.Digest = hashString
+		chartVersion.HashDigest = hashString
+		chartVersion.HashAlgorithm = models.HashAlgoSHA256
+		chartVersion.Size = info.Size()
+	}
+
+	return chartVersion, nil
 }
 
-func (m *Marketplace) DownloadChart(chartURL *url.URL) (*models.ChartVersion, error) {
+// chartFile returns the contents of the first file in the chart that matches one of the names, ignoring case
+func chartFile(files []*helmChart.File, names ...string) string {
+	for _, name := range names {
+		for _, file := range files {
+			if strings.EqualFold(file.Name, name) {
+				return string(file.Data)
+			}
+		}
+	}
+	return ""
+}
+
+// downloadChartFile downloads a chart to a temporary file
+func (m *Marketplace) downloadChartFile(chartURL *url.URL) (string, error) {
 	req, err := http.NewRequest("GET", chartURL.String(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request to download chart: %w", err)
+		return "", fmt.Errorf("failed to make request to download chart: %w", err)
 	}
 
 	resp, err := m.Client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to download chart: %w", err)
+		return "", fmt.Errorf("failed to download chart: %w", err)
 	}
 
 	chartFile, err := os.CreateTemp("", "chart-*.tgz")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temporary local chart: %w", err)
+		return "", fmt.Errorf("failed to create temporary local chart: %w", err)
 	}
 
 	_, err = io.Copy(chartFile, resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to save local chart: %w", err)
+		return "", fmt.Errorf("failed to save local chart: %w", err)
 	}
 
 	_ = resp.Body.Close()
 	_ = chartFile.Close()
+	return chartFile.Name(), nil
+}
+
+func (m *Marketplace) DownloadChart(chartURL *url.URL) (*models.ChartVersion, error) {
+	chartPath, err := m.downloadChartFile(chartURL)
+	if err != nil {
+		return nil, err
+	}
 
-	chart, err := LoadChart(chartFile.Name())
+	chart, err := LoadChart(chartPath)
 	if err != nil {
 		return nil, err
 	}
@@ -68,6 +134,27 @@ func (m *Marketplace) DownloadChart(chartURL *url.URL) (*models.ChartVersion, er
 	return chart, nil
 }
 
+// PullChart pulls a chart from an OCI registry (oci://registry/repository/chart:version) and saves it to a temporary directory
+func (m *Marketplace) PullChart(chartURL *url.URL) (string, error) {
+	ref := strings.TrimPrefix(chartURL.String(), "oci://")
+	chartBytes, err := ChartPuller(ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to pull chart %s: %w", ref, err)
+	}
+
+	chartDir, err := os.MkdirTemp("", "chart-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary local chart: %w", err)
+	}
+
+	chartPath := filepath.Join(chartDir, strings.ReplaceAll(path.Base(ref), ":", "-")+".tgz")
+	err = os.WriteFile(chartPath, chartBytes, 0600)
+	if err != nil {
+		return "", fmt.Errorf("failed to save local chart: %w", err)
+	}
+	return chartPath, nil
+}
+
 func (m *Marketplace) AttachLocalChart(chartPath, instructions string, product *models.Product, version *models.Version) (*models.Product, error) {
 	chart, err := LoadChart(chartPath)
 	if err != nil {
@@ -85,10 +172,13 @@ func (m *Marketplace) AttachLocalChart(chartPath, instructions string, product *
 
 	chart.HelmTarUrl = uploadedChartUrl
 	chart.AppVersion = version.Number
-	chart.Readme = instructions
+	chart.InstallOptions = instructions
+	if chart.Readme == "" {
+		chart.Readme = instructions
+	}
 
 	product.PrepForUpdate()
-	product.ChartVersions = []*models.ChartVersion{chart}
+	product.ChartVersions = append(product.ChartVersions, chart)
 	return m.PutProduct(product, version.IsNewVersion)
 }
 
@@ -99,9 +189,185 @@ func (m *Marketplace) AttachPublicChart(chartPath *url.URL, instructions string,
 	}
 
 	chart.AppVersion = version.Number
-	chart.Readme = instructions
+	chart.InstallOptions = instructions
+	if chart.Readme == "" {
+		chart.Readme = instructions
+	}
 
 	product.PrepForUpdate()
-	product.ChartVersions = []*models.ChartVersion{chart}
+	product.ChartVersions = append(product.ChartVersions, chart)
 	return m.PutProduct(product, version.IsNewVersion)
 }
+
+func (m *Marketplace) AttachOCIChart(chartURL *url.URL, instructions string, product *models.Product, version *models.Version) (*models.Product, error) {
+	chartPath, err := m.PullChart(chartURL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(filepath.Dir(chartPath)) }()
+
+	return m.AttachLocalChart(chartPath, instructions, product, version)
+}
+
+// ChartValidationError lists the problems found while validating a chart
+type ChartValidationError struct {
+	Chart    string
+	Problems []string
+}
+
+func (e *ChartValidationError) Error() string {
+	return fmt.Sprintf("chart %s is not valid:\n  %s", e.Chart, strings.Join(e.Problems, "\n  "))
+}
+
+// ValidateChart checks a local, public or OCI chart before it is attached to a product, and returns any warnings.
+// Only lint errors, which include values that do not match values.schema.json, make the chart invalid.
+// If productVersion is empty, the chart's appVersion is not checked.
+func (m *Marketplace) ValidateChart(chartURL *url.URL, productVersion string) ([]string, error) {
+	var chartPath string
+	switch chartURL.Scheme {
+	case "", "file":
+		chartPath = chartURL.Path
+	case "http", "https":
+		downloadedChartPath, err := m.downloadChartFile(chartURL)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = os.Remove(downloadedChartPath) }()
+		chartPath = downloadedChartPath
+	case "oci":
+		pulledChartPath, err := m.PullChart(chartURL)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = os.RemoveAll(filepath.Dir(pulledChartPath)) }()
+		chartPath = pulledChartPath
+	default:
+		return nil, fmt.Errorf("unsupported protocol scheme: %s", chartURL.Scheme)
+	}
+
+	problems, warnings, err := ValidateChartFile(chartPath, productVersion)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		return warnings, &ChartValidationError{
+			Chart:    chartURL.String(),
+			Problems: problems,
+		}
+	}
+	return warnings, nil
+}
+
+// ValidateChartFile lints the chart (which includes validating values.yaml against values.schema.json), and returns the
+// lint errors as problems. Charts can set their images in templates or subcharts, and do not need an appVersion, so
+// an appVersion that does not match the product version, or values that do not reference container images, are warnings.
+func ValidateChartFile(chartPath, productVersion string) ([]string, []string, error) {
+	chart, err := ChartLoader(chartPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read chart: %w", err)
+	}
+
+	problems, err := lintChart(chartPath, chart.Name())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []string
+	if productVersion != "" {
+		if chart.Metadata.AppVersion == "" {
+			warnings = append(warnings, "Chart.yaml does not set an appVersion")
+		} else if !appVersionMatches(chart.Metadata.AppVersion, productVersion) {
+			warnings = append(warnings, fmt.Sprintf("Chart.yaml appVersion %s does not match the product version %s", chart.Metadata.AppVersion, productVersion))
+		}
+	}
+
+	images, imageProblems := findImages("", chart.Values)
+	warnings = append(warnings, imageProblems...)
+	if len(images) == 0 && len(imageProblems) == 0 {
+		warnings = append(warnings, "values.yaml does not reference any container images")
+	}
+
+	return problems, warnings, nil
+}
+
+func lintChart(chartPath, chartName string) ([]string, error) {
+	info, err := os.Stat(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chart: %w", err)
+	}
+
+	chartDir := chartPath
+	if !info.IsDir() {
+		expandedDir, err := os.MkdirTemp("", "chart-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary directory for the chart: %w", err)
+		}
+		defer func() { _ = os.RemoveAll(expandedDir) }()
+
+		err = chartutil.ExpandFile(expandedDir, chartPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand chart: %w", err)
+		}
+		chartDir = filepath.Join(expandedDir, chartName)
+	}
+
+	var problems []string
+	linter := lint.All(chartDir, nil, "default", false)
+	for _, message := range linter.Messages {
+		if message.Severity >= support.ErrorSev {
+			problems = append(problems, message.Error())
+		}
+	}
+	return problems, nil
+}
+
+// appVersionMatches allows the appVersion to have a "v" prefix or a pre-release or build suffix, like 1.2.3-debian-r0
+func appVersionMatches(appVersion, productVersion string) bool {
+	appVersion = strings.TrimPrefix(appVersion, "v")
+	productVersion = strings.TrimPrefix(productVersion, "v")
+	return appVersion == productVersion ||
+		strings.HasPrefix(appVersion, productVersion+"-") ||
+		strings.HasPrefix(appVersion, productVersion+"+")
+}
+
+// findImages returns the images referenced in the chart values, either as "image: repository:tag" or as
+// "image: {registry: ..., repository: ..., tag: ...}". Any key ending in "image" is treated as an image reference.
+func findImages(path string, values map[string]interface{}) ([]string, []string) {
+	var images, problems []string
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		valuePath := key
+		if path != "" {
+			valuePath = path + "." + key
+		}
+		isImage := strings.HasSuffix(strings.ToLower(key), "image")
+
+		switch value := values[key].(type) {
+		case string:
+			if isImage && value != "" {
+				images = append(images, value)
+			}
+		case map[string]interface{}:
+			if isImage {
+				if repository, ok := value["repository"].(string); ok {
+					if repository == "" {
+						problems = append(problems, fmt.Sprintf("values.yaml %s.repository is empty", valuePath))
+					} else {
+						images = append(images, repository)
+					}
+					continue
+				}
+			}
+			childImages, childProblems := findImages(valuePath, value)
+			images = append(images, childImages...)
+			problems = append(problems, childProblems...)
+		}
+	}
+	return images, problems
+}
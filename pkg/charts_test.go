@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -19,6 +20,7 @@ import (
 	"github.com/vmware-labs/marketplace-cli/v2/pkg/pkgfakes"
 	"github.com/vmware-labs/marketplace-cli/v2/test"
 	helmChart "helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
 )
 
@@ -29,6 +31,8 @@ var _ = Describe("Charts", func() {
 		chartPath           string
 		chartLoader         *pkgfakes.FakeChartLoaderFunc
 		previousChartLoader pkg.ChartLoaderFunc
+		chartPuller         *pkgfakes.FakeChartPullerFunc
+		previousChartPuller pkg.ChartPullerFunc
 		httpClient          *pkgfakes.FakeHTTPClient
 		marketplace         *pkg.Marketplace
 	)
@@ -40,6 +44,10 @@ var _ = Describe("Charts", func() {
 		previousChartLoader = pkg.ChartLoader
 		pkg.ChartLoader = chartLoader.Spy
 
+		chartPuller = &pkgfakes.FakeChartPullerFunc{}
+		previousChartPuller = pkg.ChartPuller
+		pkg.ChartPuller = chartPuller.Spy
+
 		httpClient = &pkgfakes.FakeHTTPClient{}
 		marketplace = &pkg.Marketplace{
 			Client: httpClient,
@@ -49,6 +57,7 @@ var _ = Describe("Charts", func() {
 	AfterEach(func() {
 		Expect(os.RemoveAll(chartDir)).To(Succeed())
 		pkg.ChartLoader = previousChartLoader
+		pkg.ChartPuller = previousChartPuller
 	})
 
 	Describe("LoadChart", func() {
@@ -62,6 +71,31 @@ var _ = Describe("Charts", func() {
 			Expect(chartLoader.ArgsForCall(0)).To(Equal("path/to/local/chart.tgz"))
 		})
 
+		It("reads the chart details from the chart archive", func() {
+			chart.Metadata.Description = "The hyperspace database"
+			chart.Files = append(chart.Files, &helmChart.File{Name: "README.md", Data: []byte("# Hyperspace Database")})
+			chartPath, err := chartutil.Save(chart, chartDir)
+			Expect(err).ToNot(HaveOccurred())
+			chartLoader.Stub = loader.Load
+
+			chartVersion, err := pkg.LoadChart(chartPath)
+			Expect(err).ToNot(HaveOccurred())
+
+			expectedHash, err := pkg.Hash(chartPath, models.HashAlgoSHA256)
+			Expect(err).ToNot(HaveOccurred())
+			info, err := os.Stat(chartPath)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(chartVersion.Version).To(Equal("0.1.0"))
+			Expect(chartVersion.Digest).To(Equal(expectedHash))
+			Expect(chartVersion.HashDigest).To(Equal(expectedHash))
+			Expect(chartVersion.HashAlgorithm).To(Equal(models.HashAlgoSHA256))
+			Expect(chartVersion.Size).To(Equal(info.Size()))
+			Expect(chartVersion.Details).To(Equal("The hyperspace database"))
+			Expect(chartVersion.Readme).To(Equal("# Hyperspace Database"))
+			Expect(chartVersion.Values).To(ContainSubstring("replicaCount: 1"))
+		})
+
 		When("loading the chart failed", func() {
 			BeforeEach(func() {
 				chartLoader.Returns(nil, errors.New("chart loader failed"))
@@ -138,6 +172,39 @@ var _ = Describe("Charts", func() {
 		})
 	})
 
+	Describe("PullChart", func() {
+		var chartUrl *url.URL
+		BeforeEach(func() {
+			var err error
+			chartUrl, err = url.Parse("oci://registry.example.com/charts/hyperspace-database-chart:1.2.3")
+			Expect(err).ToNot(HaveOccurred())
+			chartPuller.Returns([]byte("chart contents"), nil)
+		})
+
+		It("pulls the chart from the registry", func() {
+			pulledChartPath, err := marketplace.PullChart(chartUrl)
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(filepath.Dir(pulledChartPath))
+
+			Expect(chartPuller.CallCount()).To(Equal(1))
+			Expect(chartPuller.ArgsForCall(0)).To(Equal("registry.example.com/charts/hyperspace-database-chart:1.2.3"))
+
+			Expect(filepath.Base(pulledChartPath)).To(Equal("hyperspace-database-chart-1.2.3.tgz"))
+			Expect(os.ReadFile(pulledChartPath)).To(Equal([]byte("chart contents")))
+		})
+
+		When("pulling the chart fails", func() {
+			BeforeEach(func() {
+				chartPuller.Returns(nil, errors.New("unauthorized"))
+			})
+			It("returns an error", func() {
+				_, err := marketplace.PullChart(chartUrl)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("failed to pull chart registry.example.com/charts/hyperspace-database-chart:1.2.3: unauthorized"))
+			})
+		})
+	})
+
 	Describe("AttachLocalChart", func() {
 		var uploader *internalfakes.FakeUploader
 		BeforeEach(func() {
@@ -174,6 +241,47 @@ var _ = Describe("Charts", func() {
 				Expect(attachedChart.HelmTarUrl).To(Equal("https://example.com/uploaded-chart.tgz"))
 				Expect(attachedChart.AppVersion).To(Equal("1.2.3"))
 				Expect(attachedChart.Readme).To(Equal("helm install it"))
+				Expect(attachedChart.InstallOptions).To(Equal("helm install it"))
+				Expect(attachedChart.HashAlgorithm).To(Equal(models.HashAlgoSHA256))
+				Expect(attachedChart.HashDigest).ToNot(BeEmpty())
+				Expect(attachedChart.Size).To(BeNumerically(">", 0))
+			})
+		})
+
+		When("the product version already has a chart", func() {
+			It("keeps the existing chart and adds the new one", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeChart)
+				version := &models.Version{Number: "1.2.3"}
+				test.AddVersions(product, "1.2.3")
+				product.ChartVersions = []*models.ChartVersion{
+					{AppVersion: "1.2.3", Version: "0.1.0", HelmTarUrl: "https://example.com/existing-chart.tgz", Repo: &models.Repo{Name: "existing-chart"}},
+				}
+
+				updatedProduct, err := marketplace.AttachLocalChart(chartPath, "helm install it", product, version)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(updatedProduct.ChartVersions).To(HaveLen(2))
+				Expect(updatedProduct.ChartVersions[0].HelmTarUrl).To(Equal("https://example.com/existing-chart.tgz"))
+				Expect(updatedProduct.ChartVersions[1].HelmTarUrl).To(Equal("https://example.com/uploaded-chart.tgz"))
+				Expect(updatedProduct.ChartVersions[1].AppVersion).To(Equal("1.2.3"))
+			})
+		})
+
+		When("the chart has a README", func() {
+			BeforeEach(func() {
+				chart.Files = append(chart.Files, &helmChart.File{Name: "README.md", Data: []byte("# Hyperspace Database")})
+			})
+
+			It("uses the README instead of the instructions", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeChart)
+				version := &models.Version{Number: "1.2.3"}
+				test.AddVersions(product, "1.2.3")
+				updatedProduct, err := marketplace.AttachLocalChart(chartPath, "helm install it", product, version)
+				Expect(err).ToNot(HaveOccurred())
+
+				attachedChart := updatedProduct.ChartVersions[0]
+				Expect(attachedChart.Readme).To(Equal("# Hyperspace Database"))
+				Expect(attachedChart.InstallOptions).To(Equal("helm install it"))
 			})
 		})
 
@@ -288,6 +396,24 @@ var _ = Describe("Charts", func() {
 			})
 		})
 
+		When("the product version already has a chart", func() {
+			It("keeps the existing chart and adds the new one", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeChart)
+				version := &models.Version{Number: "1.2.3"}
+				test.AddVersions(product, "1.2.3")
+				product.ChartVersions = []*models.ChartVersion{
+					{AppVersion: "1.2.3", Version: "0.1.0", HelmTarUrl: "https://example.com/existing-chart.tgz", Repo: &models.Repo{Name: "existing-chart"}},
+				}
+
+				updatedProduct, err := marketplace.AttachPublicChart(chartUrl, "helm install it", product, version)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(updatedProduct.ChartVersions).To(HaveLen(2))
+				Expect(updatedProduct.ChartVersions[0].HelmTarUrl).To(Equal("https://example.com/existing-chart.tgz"))
+				Expect(updatedProduct.ChartVersions[1].HelmTarUrl).To(Equal("https://example.com/my-public-chart.tgz"))
+			})
+		})
+
 		When("downloading the chart fails", func() {
 			BeforeEach(func() {
 				httpClient.DoReturns(nil, errors.New("download chart failed"))
@@ -316,4 +442,219 @@ var _ = Describe("Charts", func() {
 			})
 		})
 	})
+
+	Describe("AttachOCIChart", func() {
+		var uploader *internalfakes.FakeUploader
+		BeforeEach(func() {
+			chartPuller.Returns([]byte("chart contents"), nil)
+			httpClient.PutStub = PutProductEchoResponse
+			uploader = &internalfakes.FakeUploader{}
+			uploader.UploadProductFileReturns("", "https://example.com/uploaded-chart.tgz", nil)
+			marketplace.SetUploader(uploader)
+		})
+
+		It("pulls, uploads and attaches the chart", func() {
+			chartUrl, err := url.Parse("oci://registry.example.com/charts/hyperspace-database-chart:1.2.3")
+			Expect(err).ToNot(HaveOccurred())
+			product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeChart)
+			version := &models.Version{Number: "1.2.3"}
+			test.AddVersions(product, "1.2.3")
+			updatedProduct, err := marketplace.AttachOCIChart(chartUrl, "helm install it", product, version)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("pulling the chart", func() {
+				Expect(chartPuller.CallCount()).To(Equal(1))
+				Expect(chartPuller.ArgsForCall(0)).To(Equal("registry.example.com/charts/hyperspace-database-chart:1.2.3"))
+			})
+
+			By("uploading the pulled chart", func() {
+				Expect(uploader.UploadProductFileCallCount()).To(Equal(1))
+				uploadedPath := uploader.UploadProductFileArgsForCall(0)
+				Expect(filepath.Base(uploadedPath)).To(Equal("hyperspace-database-chart-1.2.3.tgz"))
+				Expect(chartLoader.ArgsForCall(0)).To(Equal(uploadedPath))
+
+				By("cleaning up the pulled chart", func() {
+					Expect(uploadedPath).ToNot(BeAnExistingFile())
+				})
+			})
+
+			By("returning the updated product", func() {
+				Expect(updatedProduct.ChartVersions).To(HaveLen(1))
+				Expect(updatedProduct.ChartVersions[0].HelmTarUrl).To(Equal("https://example.com/uploaded-chart.tgz"))
+				Expect(updatedProduct.ChartVersions[0].Readme).To(Equal("helm install it"))
+			})
+		})
+	})
+
+	Describe("ValidateChartFile", func() {
+		BeforeEach(func() {
+			chartLoader.Stub = loader.Load
+		})
+
+		It("returns no problems or warnings for a valid chart", func() {
+			problems, warnings, err := pkg.ValidateChartFile(chartPath, "1.16.0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(BeEmpty())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("accepts an appVersion with a suffix", func() {
+			chart.Metadata.AppVersion = "v1.16.0-debian-r0"
+			chartPath, err := chartutil.Save(chart, chartDir)
+			Expect(err).ToNot(HaveOccurred())
+
+			problems, warnings, err := pkg.ValidateChartFile(chartPath, "1.16.0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(BeEmpty())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		When("the appVersion does not match the product version", func() {
+			It("returns a warning", func() {
+				problems, warnings, err := pkg.ValidateChartFile(chartPath, "2.0.0")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(problems).To(BeEmpty())
+				Expect(warnings).To(ConsistOf("Chart.yaml appVersion 1.16.0 does not match the product version 2.0.0"))
+			})
+		})
+
+		When("the chart does not set an appVersion", func() {
+			BeforeEach(func() {
+				chart.Metadata.AppVersion = ""
+				var err error
+				chartPath, err = chartutil.Save(chart, chartDir)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns a warning", func() {
+				problems, warnings, err := pkg.ValidateChartFile(chartPath, "1.16.0")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(problems).To(BeEmpty())
+				Expect(warnings).To(ConsistOf("Chart.yaml does not set an appVersion"))
+			})
+		})
+
+		When("no product version is given", func() {
+			It("does not check the appVersion", func() {
+				problems, warnings, err := pkg.ValidateChartFile(chartPath, "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(problems).To(BeEmpty())
+				Expect(warnings).To(BeEmpty())
+			})
+		})
+
+		When("the values do not match the values schema", func() {
+			BeforeEach(func() {
+				chart.Schema = []byte(`{"type": "object", "properties": {"replicaCount": {"type": "string"}}}`)
+				var err error
+				chartPath, err = chartutil.Save(chart, chartDir)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns a problem", func() {
+				problems, _, err := pkg.ValidateChartFile(chartPath, "1.16.0")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(problems).ToNot(BeEmpty())
+				Expect(problems[0]).To(ContainSubstring("replicaCount: Invalid type. Expected: string, given: integer"))
+			})
+		})
+
+		When("the values do not reference any images", func() {
+			BeforeEach(func() {
+				for _, file := range chart.Raw {
+					if file.Name == chartutil.ValuesfileName {
+						file.Data = []byte("replicaCount: 1\n")
+					}
+				}
+				var err error
+				chartPath, err = chartutil.Save(chart, chartDir)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns a warning, because the images can be set in templates or subcharts", func() {
+				_, warnings, err := pkg.ValidateChartFile(chartPath, "1.16.0")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings).To(ContainElement("values.yaml does not reference any container images"))
+			})
+		})
+
+		When("an image does not have a repository", func() {
+			BeforeEach(func() {
+				for _, file := range chart.Raw {
+					if file.Name == chartutil.ValuesfileName {
+						file.Data = []byte("image:\n  repository: nginx\nsidecar:\n  initImage:\n    repository: \"\"\n")
+					}
+				}
+				var err error
+				chartPath, err = chartutil.Save(chart, chartDir)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns a warning", func() {
+				_, warnings, err := pkg.ValidateChartFile(chartPath, "1.16.0")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings).To(ContainElement("values.yaml sidecar.initImage.repository is empty"))
+			})
+		})
+
+		When("loading the chart fails", func() {
+			BeforeEach(func() {
+				chartLoader.Stub = nil
+				chartLoader.Returns(nil, errors.New("chart loader failed"))
+			})
+			It("returns an error", func() {
+				_, _, err := pkg.ValidateChartFile(chartPath, "1.16.0")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("failed to read chart: chart loader failed"))
+			})
+		})
+	})
+
+	Describe("ValidateChart", func() {
+		BeforeEach(func() {
+			chartLoader.Stub = loader.Load
+		})
+
+		It("validates a local chart", func() {
+			chartUrl, err := url.Parse(chartPath)
+			Expect(err).ToNot(HaveOccurred())
+			warnings, err := marketplace.ValidateChart(chartUrl, "1.16.0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("downloads and validates a public chart", func() {
+			chartBytes, err := os.ReadFile(chartPath)
+			Expect(err).ToNot(HaveOccurred())
+			httpClient.DoReturns(test.MakeBytesResponse(chartBytes), nil)
+
+			chartUrl, err := url.Parse("https://charts.example.com/my-chart.tgz")
+			Expect(err).ToNot(HaveOccurred())
+			warnings, err := marketplace.ValidateChart(chartUrl, "2.0.0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf("Chart.yaml appVersion 1.16.0 does not match the product version 2.0.0"))
+		})
+
+		It("pulls and validates an OCI chart", func() {
+			chartBytes, err := os.ReadFile(chartPath)
+			Expect(err).ToNot(HaveOccurred())
+			chartPuller.Returns(chartBytes, nil)
+
+			chartUrl, err := url.Parse("oci://registry.example.com/charts/hyperspace-database-chart:0.1.0")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = marketplace.ValidateChart(chartUrl, "1.16.0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(chartPuller.CallCount()).To(Equal(1))
+		})
+
+		When("the protocol scheme is not supported", func() {
+			It("returns an error", func() {
+				chartUrl, err := url.Parse("ftp://charts.example.com/my-chart.tgz")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = marketplace.ValidateChart(chartUrl, "1.16.0")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("unsupported protocol scheme: ftp"))
+			})
+		})
+	})
 })
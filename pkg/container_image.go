@@ -5,68 +5,208 @@ package pkg
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"gopkg.in/yaml.v3"
 )
 
-func (m *Marketplace) AttachLocalContainerImage(imageFile, image, tag, tagType, instructions string, product *models.Product, version *models.Version) (*models.Product, error) {
-	if product.HasContainerImage(version.Number, image, tag) {
-		return nil, fmt.Errorf("%s %s already has the image %s:%s", product.Slug, version.Number, image, tag)
+// ContainerImage is an image tag to attach to a product. If File is set, the image is uploaded from that image archive.
+type ContainerImage struct {
+	Repository string `yaml:"repository"`
+	Tag        string `yaml:"tag"`
+	TagType    string `yaml:"tagType"`
+	File       string `yaml:"file,omitempty"`
+}
+
+func (i *ContainerImage) String() string {
+	return i.Repository + ":" + i.Tag
+}
+
+// ParseContainerImage parses an image reference in the form repository:tag[:fixed|floating].
+// If the reference does not include a tag type, defaultTagType is used.
+func ParseContainerImage(ref, defaultTagType string) (*ContainerImage, error) {
+	image := &ContainerImage{TagType: strings.ToUpper(defaultTagType)}
+
+	index := strings.LastIndex(ref, ":")
+	if index != -1 {
+		suffix := strings.ToUpper(ref[index+1:])
+		if suffix == models.ImageTagTypeFixed || suffix == models.ImageTagTypeFloating {
+			image.TagType = suffix
+			ref = ref[:index]
+		}
 	}
 
-	uploader, err := m.GetUploader(product.PublisherDetails.OrgId)
+	image.Repository, image.Tag = splitImageRef(ref)
+	if image.Repository == "" || image.Tag == "" {
+		return nil, fmt.Errorf("invalid image \"%s\", expected repository:tag[:fixed|floating]", ref)
+	}
+	if image.TagType == "" {
+		return nil, fmt.Errorf("no tag type for image %s, add :fixed or :floating", image)
+	}
+	return image, nil
+}
+
+type containerImagesFile struct {
+	Images []*ContainerImage `yaml:"images"`
+}
+
+// LoadContainerImages reads a list of images from a YAML or JSON file, like:
+//
+//	images:
+//	- repository: astrowidgets/hyperspacedb
+//	  tag: 1.0.1
+//	  tagType: fixed
+//	- repository: astrowidgets/hyperspacedb-ui
+//	  tag: 1.0.1
+//	  tagType: fixed
+//	  file: hyperspacedb-ui-1.0.1.tar
+//
+// If an image does not have a tag type, defaultTagType is used.
+func LoadContainerImages(imagesFile, defaultTagType string) ([]*ContainerImage, error) {
+	contents, err := os.ReadFile(imagesFile)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to read images file %s: %w", imagesFile, err)
 	}
-	_, fileUrl, err := uploader.UploadProductFile(imageFile)
+
+	parsed := &containerImagesFile{}
+	err = yaml.Unmarshal(contents, parsed)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to parse images file %s: %w", imagesFile, err)
 	}
 
-	product.PrepForUpdate()
-	product.DockerLinkVersions = append(product.DockerLinkVersions, &models.DockerVersionList{
-		AppVersion: version.Number,
-		DockerURLs: []*models.DockerURLDetails{
-			{
-				Url: image,
-				ImageTags: []*models.DockerImageTag{
-					{
-						Tag:               tag,
-						Type:              tagType,
-						MarketplaceS3Link: fileUrl,
-					},
-				},
-				DeploymentInstruction: instructions,
-				DockerType:            models.DockerTypeUpload,
-			},
-		},
-	})
+	for i, image := range parsed.Images {
+		if image.Repository == "" || image.Tag == "" {
+			return nil, fmt.Errorf("image %d in %s must have a repository and a tag", i+1, imagesFile)
+		}
+		if image.TagType == "" {
+			image.TagType = defaultTagType
+		}
+		image.TagType = strings.ToUpper(image.TagType)
+		if image.TagType != models.ImageTagTypeFixed && image.TagType != models.ImageTagTypeFloating {
+			return nil, fmt.Errorf("invalid tag type for image %s in %s: \"%s\". must be either \"%s\" or \"%s\"", image, imagesFile, image.TagType, models.ImageTagTypeFixed, models.ImageTagTypeFloating)
+		}
+	}
+	return parsed.Images, nil
+}
 
-	return m.PutProduct(product, version.IsNewVersion)
+// inspectContainerImage checks that the image is in its archive file or registry, and returns the tag to attach.
+// If registry verification is disabled, registry images are attached without a digest or size.
+func (m *Marketplace) inspectContainerImage(image *ContainerImage) (*models.DockerImageTag, bool, error) {
+	var digest string
+	var size int64
+	var isMultiArch bool
+	if image.File != "" {
+		archiveImages, err := ImageArchiveInspector(image.File)
+		if err != nil {
+			return nil, false, err
+		}
+		archiveImage := FindArchiveImage(archiveImages, image.Repository, image.Tag)
+		if archiveImage == nil {
+			var found []string
+			for _, archiveImage := range archiveImages {
+				found = append(found, archiveImage.String())
+			}
+			return nil, false, fmt.Errorf("%s does not contain the image %s, it contains: %s", image.File, image, strings.Join(found, ", "))
+		}
+		digest, size, isMultiArch = archiveImage.Digest, archiveImage.Size, archiveImage.IsMultiArch
+	} else if !m.noRegistryCheck {
+		registryImage, err := m.GetRegistryImage(image.Repository, image.Tag)
+		if err != nil {
+			return nil, false, err
+		}
+		digest, size, isMultiArch = registryImage.Digest, registryImage.Size, registryImage.IsMultiArch
+	}
+
+	hashAlgo, hashDigest, _ := strings.Cut(digest, ":")
+	return &models.DockerImageTag{
+		Tag:        image.Tag,
+		Type:       image.TagType,
+		HashAlgo:   strings.ToUpper(hashAlgo),
+		HashDigest: hashDigest,
+		Size:       size,
+	}, isMultiArch, nil
 }
 
-func (m *Marketplace) AttachPublicContainerImage(image, tag, tagType, instructions string, product *models.Product, version *models.Version) (*models.Product, error) {
-	if product.HasContainerImage(version.Number, image, tag) {
-		return nil, fmt.Errorf("%s %s already has the image %s:%s", product.Slug, version.Number, image, tag)
+// AttachContainerImages adds all the images to the product version in a single update.
+// Every image is checked before any image file is uploaded, so a bad image does not leave a partial release behind.
+func (m *Marketplace) AttachContainerImages(images []*ContainerImage, instructions string, product *models.Product, version *models.Version) (*models.Product, error) {
+	requested := map[string]bool{}
+	for _, image := range images {
+		if product.HasContainerImage(version.Number, image.Repository, image.Tag) {
+			return nil, fmt.Errorf("%s %s already has the image %s", product.Slug, version.Number, image)
+		}
+		if requested[image.String()] {
+			return nil, fmt.Errorf("the image %s was given more than once", image)
+		}
+		requested[image.String()] = true
 	}
 
-	product.PrepForUpdate()
-	product.DockerLinkVersions = append(product.DockerLinkVersions, &models.DockerVersionList{
-		AppVersion: version.Number,
-		DockerURLs: []*models.DockerURLDetails{
-			{
-				Url: image,
-				ImageTags: []*models.DockerImageTag{
-					{
-						Tag:  tag,
-						Type: tagType,
-					},
-				},
+	tags := make([]*models.DockerImageTag, len(images))
+	multiArch := make([]bool, len(images))
+	for i, image := range images {
+		tag, isMultiArch, err := m.inspectContainerImage(image)
+		if err != nil {
+			return nil, err
+		}
+		tags[i] = tag
+		multiArch[i] = isMultiArch
+	}
+
+	versionList := &models.DockerVersionList{AppVersion: version.Number}
+	for i, image := range images {
+		dockerType := models.DockerTypeRegistry
+		if image.File != "" {
+			dockerType = models.DockerTypeUpload
+			uploader, err := m.GetUploader(product.PublisherDetails.OrgId)
+			if err != nil {
+				return nil, err
+			}
+			_, fileUrl, err := uploader.UploadProductFile(image.File)
+			if err != nil {
+				return nil, err
+			}
+			tags[i].MarketplaceS3Link = fileUrl
+		}
+
+		// Tags of the same repository are listed together
+		var dockerURL *models.DockerURLDetails
+		for _, existing := range versionList.DockerURLs {
+			if existing.Url == image.Repository && existing.DockerType == dockerType {
+				dockerURL = existing
+			}
+		}
+		if dockerURL == nil {
+			dockerURL = &models.DockerURLDetails{
+				Url:                   image.Repository,
 				DeploymentInstruction: instructions,
-				DockerType:            models.DockerTypeRegistry,
-			},
-		},
-	})
+				DockerType:            dockerType,
+			}
+			versionList.DockerURLs = append(versionList.DockerURLs, dockerURL)
+		}
+		dockerURL.ImageTags = append(dockerURL.ImageTags, tags[i])
+		dockerURL.IsMultiArch = dockerURL.IsMultiArch || multiArch[i]
+	}
 
+	product.PrepForUpdate()
+	product.DockerLinkVersions = append(product.DockerLinkVersions, versionList)
 	return m.PutProduct(product, version.IsNewVersion)
 }
+
+func (m *Marketplace) AttachLocalContainerImage(imageFile, image, tag, tagType, instructions string, product *models.Product, version *models.Version) (*models.Product, error) {
+	return m.AttachContainerImages([]*ContainerImage{{
+		Repository: image,
+		Tag:        tag,
+		TagType:    tagType,
+		File:       imageFile,
+	}}, instructions, product, version)
+}
+
+func (m *Marketplace) AttachPublicContainerImage(image, tag, tagType, instructions string, product *models.Product, version *models.Version) (*models.Product, error) {
+	return m.AttachContainerImages([]*ContainerImage{{
+		Repository: image,
+		Tag:        tag,
+		TagType:    tagType,
+	}}, instructions, product, version)
+}
@@ -5,6 +5,9 @@ package pkg_test
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"os"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -35,9 +38,29 @@ var _ = Describe("Container image", func() {
 	})
 
 	Describe("AttachLocalContainerImage", func() {
+		var (
+			imageArchiveInspector         *pkgfakes.FakeImageArchiveInspectorFunc
+			previousImageArchiveInspector pkg.ImageArchiveInspectorFunc
+		)
+
 		BeforeEach(func() {
 			httpClient.PutStub = PutProductEchoResponse
 			uploader.UploadProductFileReturns("", "https://s3.example.com/uploads/image.tar", nil)
+
+			imageArchiveInspector = &pkgfakes.FakeImageArchiveInspectorFunc{}
+			imageArchiveInspector.Returns([]*pkg.ArchiveImage{{
+				Repository:  "docker.io/library/nginx",
+				Tag:         "latest",
+				Digest:      "sha256:0123456789abcdef",
+				Size:        12345,
+				IsMultiArch: true,
+			}}, nil)
+			previousImageArchiveInspector = pkg.ImageArchiveInspector
+			pkg.ImageArchiveInspector = imageArchiveInspector.Spy
+		})
+
+		AfterEach(func() {
+			pkg.ImageArchiveInspector = previousImageArchiveInspector
 		})
 
 		It("updates the product with a public container image", func() {
@@ -65,6 +88,44 @@ var _ = Describe("Container image", func() {
 				Expect(images[0].DockerURLs[0].ImageTags[0].Type).To(Equal("FLOATING"))
 				Expect(images[0].DockerURLs[0].ImageTags[0].MarketplaceS3Link).To(Equal("https://s3.example.com/uploads/image.tar"))
 			})
+
+			By("recording the details from the image archive", func() {
+				Expect(imageArchiveInspector.CallCount()).To(Equal(1))
+				Expect(imageArchiveInspector.ArgsForCall(0)).To(Equal("image.tar"))
+
+				images := updatedProduct.GetContainerImagesForVersion("1.2.3")
+				Expect(images[0].DockerURLs[0].IsMultiArch).To(BeTrue())
+				tag := images[0].DockerURLs[0].ImageTags[0]
+				Expect(tag.HashAlgo).To(Equal(models.HashAlgoSHA256))
+				Expect(tag.HashDigest).To(Equal("0123456789abcdef"))
+				Expect(tag.Size).To(Equal(int64(12345)))
+			})
+		})
+
+		When("the image archive does not contain the image", func() {
+			It("returns an error", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeImage)
+				test.AddVersions(product, "1.2.3")
+
+				_, err := marketplace.AttachLocalContainerImage("image.tar", "nginx", "1.21.6", "FIXED", "docker run it", product, &models.Version{Number: "1.2.3"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("image.tar does not contain the image nginx:1.21.6, it contains: docker.io/library/nginx:latest"))
+				Expect(uploader.UploadProductFileCallCount()).To(Equal(0))
+			})
+		})
+
+		When("inspecting the image archive fails", func() {
+			BeforeEach(func() {
+				imageArchiveInspector.Returns(nil, errors.New("image.tar is not a docker-archive or OCI-layout image archive"))
+			})
+			It("returns an error", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeImage)
+				test.AddVersions(product, "1.2.3")
+
+				_, err := marketplace.AttachLocalContainerImage("image.tar", "nginx", "latest", "FLOATING", "docker run it", product, &models.Version{Number: "1.2.3"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("image.tar is not a docker-archive or OCI-layout image archive"))
+			})
 		})
 
 		When("the container and tag combo already exists for this version", func() {
@@ -134,6 +195,11 @@ var _ = Describe("Container image", func() {
 	Describe("AttachPublicContainerImage", func() {
 		BeforeEach(func() {
 			httpClient.PutStub = PutProductEchoResponse
+			httpClient.DoStub = func(req *http.Request) (*http.Response, error) {
+				response := test.MakeStringResponse(`{"schemaVersion": 2, "config": {"size": 10}, "layers": [{"size": 1000}, {"size": 2000}]}`)
+				response.Header = http.Header{"Docker-Content-Digest": []string{"sha256:0123456789abcdef"}}
+				return response, nil
+			}
 		})
 
 		It("updates the product with a public container image", func() {
@@ -155,6 +221,59 @@ var _ = Describe("Container image", func() {
 				Expect(images[0].DockerURLs[0].ImageTags[0].Tag).To(Equal("latest"))
 				Expect(images[0].DockerURLs[0].ImageTags[0].Type).To(Equal("FLOATING"))
 			})
+
+			By("verifying the image in its registry", func() {
+				Expect(httpClient.DoCallCount()).To(Equal(2))
+				req := httpClient.DoArgsForCall(0)
+				Expect(req.Method).To(Equal("HEAD"))
+				Expect(req.URL.String()).To(Equal("https://registry-1.docker.io/v2/library/nginx/manifests/latest"))
+				req = httpClient.DoArgsForCall(1)
+				Expect(req.Method).To(Equal("GET"))
+				Expect(req.URL.String()).To(Equal("https://registry-1.docker.io/v2/library/nginx/manifests/sha256:0123456789abcdef"))
+
+				tag := updatedProduct.GetContainerImagesForVersion("1.2.3")[0].DockerURLs[0].ImageTags[0]
+				Expect(tag.HashAlgo).To(Equal(models.HashAlgoSHA256))
+				Expect(tag.HashDigest).To(Equal("0123456789abcdef"))
+				Expect(tag.Size).To(Equal(int64(3010)))
+			})
+		})
+
+		When("the image does not exist in the registry", func() {
+			BeforeEach(func() {
+				httpClient.DoStub = func(req *http.Request) (*http.Response, error) {
+					response := test.MakeStringResponse(`{"errors": [{"code": "MANIFEST_UNKNOWN"}]}`)
+					response.StatusCode = http.StatusNotFound
+					return response, nil
+				}
+			})
+			It("returns an error", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeImage)
+				test.AddVersions(product, "1.2.3")
+
+				_, err := marketplace.AttachPublicContainerImage("nginx", "lates", "FLOATING", "docker run it", product, &models.Version{Number: "1.2.3"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("image library/nginx:lates was not found in docker.io"))
+				Expect(httpClient.PutCallCount()).To(Equal(0))
+			})
+		})
+
+		When("registry verification is disabled", func() {
+			BeforeEach(func() {
+				marketplace.DisableRegistryVerification()
+			})
+			It("attaches the image without checking the registry", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeImage)
+				test.AddVersions(product, "1.2.3")
+
+				updatedProduct, err := marketplace.AttachPublicContainerImage("nginx", "latest", "FLOATING", "docker run it", product, &models.Version{Number: "1.2.3"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(httpClient.DoCallCount()).To(Equal(0))
+
+				tag := updatedProduct.GetContainerImagesForVersion("1.2.3")[0].DockerURLs[0].ImageTags[0]
+				Expect(tag.Tag).To(Equal("latest"))
+				Expect(tag.HashDigest).To(BeEmpty())
+				Expect(tag.Size).To(BeZero())
+			})
 		})
 
 		When("the container and tag combo already exists for this version", func() {
@@ -193,4 +312,168 @@ var _ = Describe("Container image", func() {
 			})
 		})
 	})
+
+	Describe("AttachContainerImages", func() {
+		var (
+			imageArchiveInspector         *pkgfakes.FakeImageArchiveInspectorFunc
+			previousImageArchiveInspector pkg.ImageArchiveInspectorFunc
+			images                        []*pkg.ContainerImage
+		)
+
+		BeforeEach(func() {
+			httpClient.PutStub = PutProductEchoResponse
+			httpClient.DoStub = func(req *http.Request) (*http.Response, error) {
+				response := test.MakeStringResponse(`{"schemaVersion": 2, "config": {"size": 10}, "layers": [{"size": 1000}]}`)
+				response.Header = http.Header{"Docker-Content-Digest": []string{"sha256:0123456789abcdef"}}
+				return response, nil
+			}
+			uploader.UploadProductFileReturns("", "https://s3.example.com/uploads/ui.tar", nil)
+
+			imageArchiveInspector = &pkgfakes.FakeImageArchiveInspectorFunc{}
+			imageArchiveInspector.Returns([]*pkg.ArchiveImage{{Repository: "astrowidgets/hyperspacedb-ui", Tag: "1.2.3", Digest: "sha256:fedcba"}}, nil)
+			previousImageArchiveInspector = pkg.ImageArchiveInspector
+			pkg.ImageArchiveInspector = imageArchiveInspector.Spy
+
+			images = []*pkg.ContainerImage{
+				{Repository: "astrowidgets/hyperspacedb", Tag: "1.2.3", TagType: models.ImageTagTypeFixed},
+				{Repository: "astrowidgets/hyperspacedb", Tag: "latest", TagType: models.ImageTagTypeFloating},
+				{Repository: "astrowidgets/hyperspacedb-ui", Tag: "1.2.3", TagType: models.ImageTagTypeFixed, File: "ui.tar"},
+			}
+		})
+
+		AfterEach(func() {
+			pkg.ImageArchiveInspector = previousImageArchiveInspector
+		})
+
+		It("attaches all of the images in one update", func() {
+			product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeImage)
+			test.AddVersions(product, "1.2.3")
+
+			updatedProduct, err := marketplace.AttachContainerImages(images, "docker run it", product, &models.Version{Number: "1.2.3"})
+			Expect(err).ToNot(HaveOccurred())
+
+			By("checking every image", func() {
+				Expect(httpClient.DoCallCount()).To(Equal(4))
+				Expect(imageArchiveInspector.CallCount()).To(Equal(1))
+				Expect(imageArchiveInspector.ArgsForCall(0)).To(Equal("ui.tar"))
+			})
+
+			By("uploading the image files", func() {
+				Expect(uploader.UploadProductFileCallCount()).To(Equal(1))
+				Expect(uploader.UploadProductFileArgsForCall(0)).To(Equal("ui.tar"))
+			})
+
+			By("updating the product once, with the images in one version list", func() {
+				Expect(httpClient.PutCallCount()).To(Equal(1))
+
+				versionLists := updatedProduct.GetContainerImagesForVersion("1.2.3")
+				Expect(versionLists).To(HaveLen(1))
+				dockerURLs := versionLists[0].DockerURLs
+				Expect(dockerURLs).To(HaveLen(2))
+
+				Expect(dockerURLs[0].Url).To(Equal("astrowidgets/hyperspacedb"))
+				Expect(dockerURLs[0].DockerType).To(Equal(models.DockerTypeRegistry))
+				Expect(dockerURLs[0].ImageTags).To(HaveLen(2))
+				Expect(dockerURLs[0].ImageTags[0].Tag).To(Equal("1.2.3"))
+				Expect(dockerURLs[0].ImageTags[1].Tag).To(Equal("latest"))
+				Expect(dockerURLs[0].ImageTags[1].Type).To(Equal(models.ImageTagTypeFloating))
+
+				Expect(dockerURLs[1].Url).To(Equal("astrowidgets/hyperspacedb-ui"))
+				Expect(dockerURLs[1].DockerType).To(Equal(models.DockerTypeUpload))
+				Expect(dockerURLs[1].ImageTags[0].MarketplaceS3Link).To(Equal("https://s3.example.com/uploads/ui.tar"))
+				Expect(dockerURLs[1].ImageTags[0].HashDigest).To(Equal("fedcba"))
+			})
+		})
+
+		When("one of the images is not valid", func() {
+			BeforeEach(func() {
+				imageArchiveInspector.Returns([]*pkg.ArchiveImage{}, nil)
+			})
+
+			It("does not upload or attach any images", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeImage)
+				test.AddVersions(product, "1.2.3")
+
+				_, err := marketplace.AttachContainerImages(images, "docker run it", product, &models.Version{Number: "1.2.3"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("ui.tar does not contain the image astrowidgets/hyperspacedb-ui:1.2.3, it contains: "))
+				Expect(uploader.UploadProductFileCallCount()).To(Equal(0))
+				Expect(httpClient.PutCallCount()).To(Equal(0))
+			})
+		})
+
+		When("an image is given more than once", func() {
+			It("returns an error", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeImage)
+				test.AddVersions(product, "1.2.3")
+
+				images = append(images, &pkg.ContainerImage{Repository: "astrowidgets/hyperspacedb", Tag: "latest", TagType: models.ImageTagTypeFixed})
+				_, err := marketplace.AttachContainerImages(images, "docker run it", product, &models.Version{Number: "1.2.3"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("the image astrowidgets/hyperspacedb:latest was given more than once"))
+				Expect(httpClient.DoCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("ParseContainerImage", func() {
+		It("parses the repository, tag and tag type", func() {
+			image, err := pkg.ParseContainerImage("astrowidgets/hyperspacedb:1.2.3:floating", "FIXED")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(image.Repository).To(Equal("astrowidgets/hyperspacedb"))
+			Expect(image.Tag).To(Equal("1.2.3"))
+			Expect(image.TagType).To(Equal(models.ImageTagTypeFloating))
+
+			image, err = pkg.ParseContainerImage("localhost:5000/hyperspacedb:1.2.3", "fixed")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(image.Repository).To(Equal("localhost:5000/hyperspacedb"))
+			Expect(image.Tag).To(Equal("1.2.3"))
+			Expect(image.TagType).To(Equal(models.ImageTagTypeFixed))
+		})
+
+		It("rejects images without a tag or tag type", func() {
+			_, err := pkg.ParseContainerImage("astrowidgets/hyperspacedb:fixed", "")
+			Expect(err).To(MatchError("invalid image \"astrowidgets/hyperspacedb\", expected repository:tag[:fixed|floating]"))
+
+			_, err = pkg.ParseContainerImage("astrowidgets/hyperspacedb:1.2.3", "")
+			Expect(err).To(MatchError("no tag type for image astrowidgets/hyperspacedb:1.2.3, add :fixed or :floating"))
+		})
+	})
+
+	Describe("LoadContainerImages", func() {
+		var imagesFile string
+		BeforeEach(func() {
+			file, err := os.CreateTemp("", "images-*.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			imagesFile = file.Name()
+			_, err = file.WriteString(`images:
+- repository: astrowidgets/hyperspacedb
+  tag: 1.2.3
+- repository: astrowidgets/hyperspacedb-ui
+  tag: latest
+  tagType: floating
+  file: ui.tar
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+		})
+		AfterEach(func() {
+			Expect(os.Remove(imagesFile)).To(Succeed())
+		})
+
+		It("reads the images from the file", func() {
+			images, err := pkg.LoadContainerImages(imagesFile, "fixed")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(images).To(HaveLen(2))
+			Expect(*images[0]).To(Equal(pkg.ContainerImage{Repository: "astrowidgets/hyperspacedb", Tag: "1.2.3", TagType: "FIXED"}))
+			Expect(*images[1]).To(Equal(pkg.ContainerImage{Repository: "astrowidgets/hyperspacedb-ui", Tag: "latest", TagType: "FLOATING", File: "ui.tar"}))
+		})
+
+		When("an image does not have a tag type", func() {
+			It("returns an error", func() {
+				_, err := pkg.LoadContainerImages(imagesFile, "")
+				Expect(err).To(MatchError(fmt.Sprintf("invalid tag type for image astrowidgets/hyperspacedb:1.2.3 in %s: \"\". must be either \"FIXED\" or \"FLOATING\"", imagesFile)))
+			})
+		})
+	})
 })
@@ -0,0 +1,112 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+)
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// DiffProducts returns the list of fields that differ between the two products.
+// Fields are named by their JSON path in the Marketplace API (e.g. description.summary)
+func DiffProducts(before, after *models.Product) ([]*FieldChange, error) {
+	beforeValue, err := toGenericJSON(before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the original product: %w", err)
+	}
+	afterValue, err := toGenericJSON(after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the updated product: %w", err)
+	}
+
+	return diffValues("", beforeValue, afterValue), nil
+}
+
+func toGenericJSON(object interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	err = json.Unmarshal(encoded, &value)
+	return value, err
+}
+
+func diffValues(path string, before, after interface{}) []*FieldChange {
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if beforeIsMap && afterIsMap {
+		var changes []*FieldChange
+		for _, key := range unionOfKeys(beforeMap, afterMap) {
+			changes = append(changes, diffValues(joinPath(path, key), beforeMap[key], afterMap[key])...)
+		}
+		return changes
+	}
+
+	beforeList, beforeIsList := before.([]interface{})
+	afterList, afterIsList := after.([]interface{})
+	if beforeIsList && afterIsList && len(beforeList) == len(afterList) {
+		var changes []*FieldChange
+		for i := range beforeList {
+			changes = append(changes, diffValues(fmt.Sprintf("%s[%d]", path, i), beforeList[i], afterList[i])...)
+		}
+		return changes
+	}
+
+	if isEmptyValue(before) && isEmptyValue(after) {
+		return nil
+	}
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+	return []*FieldChange{{Field: path, Old: before, New: after}}
+}
+
+// isEmptyValue treats null and empty lists or objects the same, since the Marketplace does not distinguish them
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func unionOfKeys(a, b map[string]interface{}) []string {
+	keySet := map[string]bool{}
+	for key := range a {
+		keySet[key] = true
+	}
+	for key := range b {
+		keySet[key] = true
+	}
+
+	var keys []string
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package pkg_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg"
+	"github.com/vmware-labs/marketplace-cli/v2/test"
+)
+
+var _ = Describe("DiffProducts", func() {
+	var (
+		before *models.Product
+		after  *models.Product
+	)
+
+	BeforeEach(func() {
+		before = test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeChart)
+		test.AddVersions(before, "1.2.3")
+		before.Description = &models.Description{Summary: "The best product"}
+
+		after = test.CreateFakeProduct(before.ProductId, "My Super Product", "my-super-product", models.SolutionTypeChart)
+		test.AddVersions(after, "1.2.3")
+		after.PublisherDetails = before.PublisherDetails
+		after.Description = &models.Description{Summary: "The best product"}
+	})
+
+	It("returns no changes for identical products", func() {
+		changes, err := pkg.DiffProducts(before, after)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("returns the changed fields by their path", func() {
+		after.DisplayName = "My Amazing Product"
+		after.Description.Summary = "The very best product"
+		after.AllVersions[0].Details = "New details"
+		after.Tags = []string{"database"}
+
+		changes, err := pkg.DiffProducts(before, after)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(4))
+
+		Expect(changes[0].Field).To(Equal("allversiondetailsList[0].versiondetails"))
+		Expect(changes[0].Old).To(Equal("Details for 1.2.3"))
+		Expect(changes[0].New).To(Equal("New details"))
+
+		Expect(changes[1].Field).To(Equal("description.summary"))
+		Expect(changes[1].Old).To(Equal("The best product"))
+		Expect(changes[1].New).To(Equal("The very best product"))
+
+		Expect(changes[2].Field).To(Equal("displayname"))
+		Expect(changes[2].Old).To(Equal("My Super Product"))
+		Expect(changes[2].New).To(Equal("My Amazing Product"))
+
+		Expect(changes[3].Field).To(Equal("tagsList"))
+		Expect(changes[3].Old).To(BeNil())
+		Expect(changes[3].New).To(Equal([]interface{}{"database"}))
+	})
+
+	It("treats lists of different lengths as a single change", func() {
+		test.AddVersions(after, "2.0.0")
+
+		changes, err := pkg.DiffProducts(before, after)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Field).To(Equal("allversiondetailsList"))
+	})
+})
@@ -4,12 +4,30 @@
 package pkg
 
 import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/vmware-labs/marketplace-cli/v2/internal"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+)
+
+const PartialDownloadSuffix = ".part"
+
+// DownloadAttempts is how many times a resumable download is tried before giving up
+var DownloadAttempts = 3
+
+var (
+	errDownloadLinkExpired    = errors.New("the download link has expired")
+	errPartialDownloadInvalid = errors.New("the partially downloaded file does not match the asset")
 )
 
 type DownloadRequestPayload struct {
@@ -36,31 +54,108 @@ type DownloadResponse struct {
 	Response *DownloadResponseBody `json:"response"`
 }
 
-func (m *Marketplace) Download(filename string, payload *DownloadRequestPayload) error {
+const (
+	DownloadStatusSucceeded = "Downloaded"
+	DownloadStatusSkipped   = "Skipped"
+	DownloadStatusFailed    = "Failed"
+)
+
+type DownloadResult struct {
+	Asset    *Asset `json:"asset"`
+	Filename string `json:"filename"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+func (m *Marketplace) Download(filename string, asset *Asset, resume bool) error {
+	var hasher hash.Hash
+	hashAlgorithm := asset.HashAlgorithm
+	if asset.HashDigest != "" {
+		if hashAlgorithm == "" {
+			hashAlgorithm = hashAlgorithmForDigest(asset.HashDigest)
+		}
+		if hashAlgorithm == "" {
+			_, _ = fmt.Fprintf(m.Output, "Warning: the hash algorithm for %s is not known, so the download will not be verified\n", asset.DisplayName)
+		} else {
+			var err error
+			hasher, err = NewHasher(hashAlgorithm)
+			if err != nil {
+				return fmt.Errorf("unable to verify the download of %s: %w", asset.DisplayName, err)
+			}
+		}
+	}
+
+	downloadURL, err := m.getDownloadLink(asset.DownloadRequestPayload)
+	if err != nil {
+		return err
+	}
+
+	if !resume {
+		err = m.downloadFile(filename, downloadURL, hasher)
+		if err != nil {
+			return err
+		}
+		return m.verifyDownload(filename, filename, asset.HashDigest, hashAlgorithm, hasher)
+	}
+
+	partFilename := filename + PartialDownloadSuffix
+	for attempt := 1; ; attempt++ {
+		err = m.downloadPart(partFilename, downloadURL, asset.Size, hasher)
+		if err == nil {
+			break
+		}
+		if attempt >= DownloadAttempts {
+			return err
+		}
+
+		if errors.Is(err, errDownloadLinkExpired) {
+			downloadURL, err = m.getDownloadLink(asset.DownloadRequestPayload)
+			if err != nil {
+				return err
+			}
+		} else if errors.Is(err, errPartialDownloadInvalid) {
+			_, _ = fmt.Fprintf(m.Output, "Partial download of %s does not match the asset, starting over...\n", filename)
+		} else {
+			_, _ = fmt.Fprintf(m.Output, "Download of %s was interrupted (%s), resuming...\n", filename, err.Error())
+		}
+	}
+
+	err = m.verifyDownload(partFilename, filename, asset.HashDigest, hashAlgorithm, hasher)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(partFilename, filename)
+	if err != nil {
+		return fmt.Errorf("failed to move the downloaded file to %s: %w", filename, err)
+	}
+	return nil
+}
+
+func (m *Marketplace) getDownloadLink(payload *DownloadRequestPayload) (string, error) {
 	requestURL := MakeURL(m.GetHost(), fmt.Sprintf("/api/v1/products/%s/download", payload.ProductId), nil)
 	resp, err := m.Client.PostJSON(requestURL, payload)
 	if err != nil {
-		return fmt.Errorf("failed to get download link: %w", err)
+		return "", fmt.Errorf("failed to get download link: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err == nil {
-			return fmt.Errorf("failed to fetch download link: %s\n%s", resp.Status, string(body))
+			return "", fmt.Errorf("failed to fetch download link: %s\n%s", resp.Status, string(body))
 		}
-		return fmt.Errorf("failed to fetch download link: %s", resp.Status)
+		return "", fmt.Errorf("failed to fetch download link: %s", resp.Status)
 	}
 
 	downloadResponse := &DownloadResponse{}
 	err = m.DecodeJson(resp.Body, downloadResponse)
 	if err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+		return "", fmt.Errorf("failed to parse response: %w", err)
 	}
-
-	return m.downloadFile(filename, downloadResponse.Response.PreSignedURL)
+	return downloadResponse.Response.PreSignedURL, nil
 }
 
-func (m *Marketplace) downloadFile(filename string, fileDownloadURL string) error {
+func (m *Marketplace) downloadFile(filename string, fileDownloadURL string, hasher hash.Hash) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return fmt.Errorf("failed to create file for download: %w", err)
@@ -77,10 +172,195 @@ func (m *Marketplace) downloadFile(filename string, fileDownloadURL string) erro
 	}
 	defer resp.Body.Close()
 
-	progressBar := internal.MakeProgressBar(fmt.Sprintf("Downloading %s", filename), resp.ContentLength, m.Output)
-	_, err = io.Copy(progressBar.WrapWriter(file), resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download file: %s", resp.Status)
+	}
+
+	var destination io.Writer = file
+	if hasher != nil {
+		destination = io.MultiWriter(file, hasher)
+	}
+
+	progressBar := m.makeDownloadProgressBar(fmt.Sprintf("Downloading %s", filename), resp.ContentLength)
+	_, err = io.Copy(progressBar.WrapWriter(destination), resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to download file to disk: %w", err)
+	}
+	return nil
+}
+
+// downloadPart continues downloading into a partial file, asking only for the bytes that are not already on disk.
+// If the partial file does not line up with what the server sends, it is emptied so the next attempt starts over.
+func (m *Marketplace) downloadPart(filename string, fileDownloadURL string, size int64, hasher hash.Hash) error {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create file for download: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to read the partially downloaded file %s: %w", filename, err)
+	}
+	offset := info.Size()
+
+	req, err := http.NewRequest("GET", fileDownloadURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create download file request: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		start, _, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			return resetPart(file)
+		}
+	case http.StatusOK:
+		// The server ignored the range, so start over
+		offset = 0
+		err = file.Truncate(0)
+		if err != nil {
+			return fmt.Errorf("failed to reset the partially downloaded file %s: %w", filename, err)
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Everything may already have been downloaded, but only if the partial file is exactly the size of the asset
+		_, total, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || total < 0 {
+			total = size
+		}
+		if total <= 0 || offset != total {
+			return resetPart(file)
+		}
+		return hashExisting(file, offset, hasher)
+	case http.StatusForbidden:
+		return errDownloadLinkExpired
+	default:
+		return fmt.Errorf("failed to download file: %s", resp.Status)
+	}
+
+	err = hashExisting(file, offset, hasher)
+	if err != nil {
+		return err
+	}
+	_, err = file.Seek(offset, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("failed to read the partially downloaded file %s: %w", filename, err)
+	}
+
+	var destination io.Writer = file
+	if hasher != nil {
+		destination = io.MultiWriter(file, hasher)
+	}
+
+	description := fmt.Sprintf("Downloading %s", filename)
+	if offset > 0 {
+		description = fmt.Sprintf("Resuming download of %s", filename)
+	}
+	progressBar := m.makeDownloadProgressBar(description, resp.ContentLength)
+	_, err = io.Copy(progressBar.WrapWriter(destination), resp.Body)
 	if err != nil {
 		return fmt.Errorf("failed to download file to disk: %w", err)
 	}
 	return nil
 }
+
+func (m *Marketplace) makeDownloadProgressBar(description string, length int64) internal.ProgressBar {
+	if m.noProgressBars {
+		return internal.MakeProgressBar(description, length, io.Discard)
+	}
+	if m.progressBars != nil {
+		return internal.MakeProgressBar(description, length, m.progressBars.AddLine())
+	}
+	return internal.MakeProgressBar(description, length, m.Output)
+}
+
+// parseContentRange reads the start of the range and the total size from a Content-Range header,
+// either "bytes <start>-<end>/<total>" or "bytes */<total>". The total is -1 when the server does not know it.
+func parseContentRange(contentRange string) (int64, int64, bool) {
+	if !strings.HasPrefix(contentRange, "bytes ") {
+		return 0, 0, false
+	}
+	byteRange, totalString, found := strings.Cut(strings.TrimPrefix(contentRange, "bytes "), "/")
+	if !found {
+		return 0, 0, false
+	}
+
+	total := int64(-1)
+	if totalString != "*" {
+		var err error
+		total, err = strconv.ParseInt(totalString, 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+	}
+
+	if byteRange == "*" {
+		return 0, total, true
+	}
+	startString, _, found := strings.Cut(byteRange, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(startString, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, total, true
+}
+
+// resetPart empties a partial file that cannot be resumed
+func resetPart(file *os.File) error {
+	err := file.Truncate(0)
+	if err != nil {
+		return fmt.Errorf("failed to reset the partially downloaded file %s: %w", file.Name(), err)
+	}
+	return errPartialDownloadInvalid
+}
+
+// hashExisting restarts the hash with the bytes that were downloaded previously
+func hashExisting(file *os.File, size int64, hasher hash.Hash) error {
+	if hasher == nil {
+		return nil
+	}
+
+	hasher.Reset()
+	_, err := io.Copy(hasher, io.NewSectionReader(file, 0, size))
+	if err != nil {
+		return fmt.Errorf("failed to read the partially downloaded file %s: %w", file.Name(), err)
+	}
+	return nil
+}
+
+// hashAlgorithmForDigest guesses the hash algorithm from the length of a hex encoded digest.
+// Older assets were recorded with a digest, but without the algorithm used to make it.
+func hashAlgorithmForDigest(digest string) string {
+	switch len(digest) {
+	case hex.EncodedLen(sha1.Size):
+		return models.HashAlgoSHA1
+	case hex.EncodedLen(sha256.Size):
+		return models.HashAlgoSHA256
+	}
+	return ""
+}
+
+func (m *Marketplace) verifyDownload(path, filename, expectedDigest, hashAlgorithm string, hasher hash.Hash) error {
+	if hasher == nil {
+		return nil
+	}
+
+	digest := hex.EncodeToString(hasher.Sum(nil))
+	if !strings.EqualFold(digest, expectedDigest) {
+		_ = os.Remove(path)
+		return fmt.Errorf("the %s digest of %s does not match, expected %s but got %s", strings.ToUpper(hashAlgorithm), filename, expectedDigest, digest)
+	}
+	_, _ = fmt.Fprintf(m.Output, "Verified %s digest of %s: %s\n", strings.ToUpper(hashAlgorithm), filename, digest)
+	return nil
+}
@@ -4,16 +4,21 @@
 package pkg_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gbytes"
 	"github.com/vmware-labs/marketplace-cli/v2/internal"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/internalfakes"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
 	"github.com/vmware-labs/marketplace-cli/v2/pkg"
 	"github.com/vmware-labs/marketplace-cli/v2/pkg/pkgfakes"
 	"github.com/vmware-labs/marketplace-cli/v2/test"
@@ -65,7 +70,7 @@ var _ = Describe("Download", func() {
 			ProductId:  "my-product-id",
 			AppVersion: "1.2.3",
 		}
-		err := marketplace.Download(filename, requestPayload)
+		err := marketplace.Download(filename, &pkg.Asset{DownloadRequestPayload: requestPayload}, false)
 		Expect(err).ToNot(HaveOccurred())
 
 		By("requesting the download link", func() {
@@ -100,6 +105,26 @@ var _ = Describe("Download", func() {
 		})
 	})
 
+	When("multiple progress bars are enabled", func() {
+		BeforeEach(func() {
+			marketplace.EnableMultipleProgressBars()
+		})
+
+		It("gives the download its own line of the output", func() {
+			filename = "destination-file.txt"
+			err := marketplace.Download(filename, &pkg.Asset{DownloadRequestPayload: &pkg.DownloadRequestPayload{}}, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(progressBarMaker.CallCount()).To(Equal(1))
+			_, _, progressBarOutput := progressBarMaker.ArgsForCall(0)
+			Expect(progressBarOutput).ToNot(Equal(output))
+
+			_, err = progressBarOutput.Write([]byte("\rDownloading destination-file.txt"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).To(Say("Downloading destination-file.txt\n"))
+		})
+	})
+
 	When("requesting the download link fails", func() {
 		BeforeEach(func() {
 			httpClient.PostJSONReturns(nil, errors.New("download link request failed"))
@@ -109,7 +134,7 @@ var _ = Describe("Download", func() {
 				ProductId:  "my-product-id",
 				AppVersion: "1.2.3",
 			}
-			err := marketplace.Download("", requestPayload)
+			err := marketplace.Download("", &pkg.Asset{DownloadRequestPayload: requestPayload}, false)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("failed to get download link: download link request failed"))
 		})
@@ -127,7 +152,7 @@ var _ = Describe("Download", func() {
 				ProductId:  "my-product-id",
 				AppVersion: "1.2.3",
 			}
-			err := marketplace.Download("", requestPayload)
+			err := marketplace.Download("", &pkg.Asset{DownloadRequestPayload: requestPayload}, false)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("failed to fetch download link: I'm a teapot\ndownload link request failed"))
 		})
@@ -147,7 +172,7 @@ var _ = Describe("Download", func() {
 					ProductId:  "my-product-id",
 					AppVersion: "1.2.3",
 				}
-				err := marketplace.Download("", requestPayload)
+				err := marketplace.Download("", &pkg.Asset{DownloadRequestPayload: requestPayload}, false)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(Equal("failed to fetch download link: I'm a teapot"))
 			})
@@ -164,7 +189,7 @@ var _ = Describe("Download", func() {
 				ProductId:  "my-product-id",
 				AppVersion: "1.2.3",
 			}
-			err := marketplace.Download("", requestPayload)
+			err := marketplace.Download("", &pkg.Asset{DownloadRequestPayload: requestPayload}, false)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("failed to parse response: invalid character 'h' in literal true (expecting 'r')"))
 		})
@@ -176,7 +201,7 @@ var _ = Describe("Download", func() {
 				ProductId:  "my-product-id",
 				AppVersion: "1.2.3",
 			}
-			err := marketplace.Download("/this/path/does/not/exist", requestPayload)
+			err := marketplace.Download("/this/path/does/not/exist", &pkg.Asset{DownloadRequestPayload: requestPayload}, false)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("failed to create file for download: open /this/path/does/not/exist: no such file or directory"))
 		})
@@ -196,7 +221,7 @@ var _ = Describe("Download", func() {
 				ProductId:  "my-product-id",
 				AppVersion: "1.2.3",
 			}
-			err := marketplace.Download(filename, requestPayload)
+			err := marketplace.Download(filename, &pkg.Asset{DownloadRequestPayload: requestPayload}, false)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("failed to create download file request: parse \": : this is a bad url\": missing protocol scheme"))
 		})
@@ -212,7 +237,7 @@ var _ = Describe("Download", func() {
 				ProductId:  "my-product-id",
 				AppVersion: "1.2.3",
 			}
-			err := marketplace.Download(filename, requestPayload)
+			err := marketplace.Download(filename, &pkg.Asset{DownloadRequestPayload: requestPayload}, false)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("failed to download file: download failed"))
 		})
@@ -228,9 +253,328 @@ var _ = Describe("Download", func() {
 				ProductId:  "my-product-id",
 				AppVersion: "1.2.3",
 			}
-			err := marketplace.Download(filename, requestPayload)
+			err := marketplace.Download(filename, &pkg.Asset{DownloadRequestPayload: requestPayload}, false)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("failed to download file to disk: writing failed"))
 		})
 	})
+
+	When("the asset has a digest", func() {
+		var asset *pkg.Asset
+
+		BeforeEach(func() {
+			filename = "destination-file.txt"
+			asset = &pkg.Asset{
+				DisplayName: "file.txt",
+				DownloadRequestPayload: &pkg.DownloadRequestPayload{
+					ProductId:  "my-product-id",
+					AppVersion: "1.2.3",
+				},
+				HashDigest:    sha256Of("file contents!"),
+				HashAlgorithm: models.HashAlgoSHA256,
+			}
+		})
+
+		It("verifies the downloaded file", func() {
+			err := marketplace.Download(filename, asset, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			content, err := os.ReadFile(filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(Equal("file contents!"))
+			Expect(output).To(Say("Verified SHA256 digest of destination-file.txt: " + asset.HashDigest))
+		})
+
+		When("the digest does not match", func() {
+			BeforeEach(func() {
+				asset.HashDigest = sha256Of("other contents")
+			})
+
+			It("returns an error and removes the file", func() {
+				err := marketplace.Download(filename, asset, false)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("the SHA256 digest of destination-file.txt does not match, expected " + sha256Of("other contents") + " but got " + sha256Of("file contents!")))
+				Expect(filename).ToNot(BeAnExistingFile())
+				filename = ""
+			})
+		})
+
+		When("the hash algorithm is not supported", func() {
+			BeforeEach(func() {
+				asset.HashAlgorithm = "MD4"
+			})
+
+			It("returns an error", func() {
+				err := marketplace.Download(filename, asset, false)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("unable to verify the download of file.txt: unsupported hash algorithm: \"MD4\""))
+				Expect(httpClient.DoCallCount()).To(Equal(0))
+				filename = ""
+			})
+		})
+
+		When("the hash algorithm is missing", func() {
+			BeforeEach(func() {
+				asset.HashAlgorithm = ""
+			})
+
+			It("works out the algorithm from the digest", func() {
+				err := marketplace.Download(filename, asset, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).To(Say("Verified SHA256 digest of destination-file.txt: " + asset.HashDigest))
+			})
+
+			When("the digest is not a known length", func() {
+				BeforeEach(func() {
+					asset.HashDigest = "abcdef"
+				})
+
+				It("downloads the file without verifying it", func() {
+					err := marketplace.Download(filename, asset, false)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(output).To(Say("Warning: the hash algorithm for file.txt is not known, so the download will not be verified"))
+
+					content, err := os.ReadFile(filename)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(content)).To(Equal("file contents!"))
+				})
+			})
+		})
+	})
+
+	When("resuming a download", func() {
+		var (
+			asset        *pkg.Asset
+			partFilename string
+		)
+
+		BeforeEach(func() {
+			filename = "destination-file.txt"
+			partFilename = filename + pkg.PartialDownloadSuffix
+			asset = &pkg.Asset{
+				DownloadRequestPayload: &pkg.DownloadRequestPayload{
+					ProductId:  "my-product-id",
+					AppVersion: "1.2.3",
+				},
+				HashDigest:    sha256Of("file contents!"),
+				HashAlgorithm: models.HashAlgoSHA256,
+			}
+			Expect(os.WriteFile(partFilename, []byte("file "), 0644)).To(Succeed())
+
+			httpClient.DoReturns(makePartialResponse("contents!", 5, 14), nil)
+		})
+
+		AfterEach(func() {
+			_ = os.Remove(partFilename)
+		})
+
+		It("requests only the remaining bytes", func() {
+			err := marketplace.Download(filename, asset, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("sending a range request", func() {
+				Expect(httpClient.DoCallCount()).To(Equal(1))
+				request := httpClient.DoArgsForCall(0)
+				Expect(request.Header.Get("Range")).To(Equal("bytes=5-"))
+				description, _, _ := progressBarMaker.ArgsForCall(0)
+				Expect(description).To(Equal("Resuming download of destination-file.txt.part"))
+			})
+
+			By("moving the completed file into place", func() {
+				content, err := os.ReadFile(filename)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(Equal("file contents!"))
+				Expect(partFilename).ToNot(BeAnExistingFile())
+			})
+
+			By("verifying the whole file", func() {
+				Expect(output).To(Say("Verified SHA256 digest of destination-file.txt: " + asset.HashDigest))
+			})
+		})
+
+		When("there is no partial file", func() {
+			BeforeEach(func() {
+				Expect(os.Remove(partFilename)).To(Succeed())
+				httpClient.DoReturns(test.MakeStringResponse("file contents!"), nil)
+			})
+
+			It("downloads the whole file", func() {
+				err := marketplace.Download(filename, asset, true)
+				Expect(err).ToNot(HaveOccurred())
+
+				request := httpClient.DoArgsForCall(0)
+				Expect(request.Header.Get("Range")).To(BeEmpty())
+				content, err := os.ReadFile(filename)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(Equal("file contents!"))
+			})
+		})
+
+		When("the server does not support range requests", func() {
+			BeforeEach(func() {
+				httpClient.DoReturns(test.MakeStringResponse("file contents!"), nil)
+			})
+
+			It("starts the download over", func() {
+				err := marketplace.Download(filename, asset, true)
+				Expect(err).ToNot(HaveOccurred())
+
+				content, err := os.ReadFile(filename)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(Equal("file contents!"))
+			})
+		})
+
+		When("the partial file is already complete", func() {
+			BeforeEach(func() {
+				Expect(os.WriteFile(partFilename, []byte("file contents!"), 0644)).To(Succeed())
+				asset.Size = 14
+				httpClient.DoReturns(&http.Response{
+					StatusCode: http.StatusRequestedRangeNotSatisfiable,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil)
+			})
+
+			It("verifies and moves the file into place", func() {
+				err := marketplace.Download(filename, asset, true)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(httpClient.DoCallCount()).To(Equal(1))
+				content, err := os.ReadFile(filename)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(Equal("file contents!"))
+				Expect(output).To(Say("Verified SHA256 digest of destination-file.txt"))
+			})
+		})
+
+		When("the partial file is larger than the asset", func() {
+			BeforeEach(func() {
+				Expect(os.WriteFile(partFilename, []byte("file contents! and some stale bytes"), 0644)).To(Succeed())
+				asset.Size = 14
+				httpClient.DoReturnsOnCall(0, &http.Response{
+					StatusCode: http.StatusRequestedRangeNotSatisfiable,
+					Header:     http.Header{"Content-Range": []string{"bytes */14"}},
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil)
+				httpClient.DoReturnsOnCall(1, test.MakeStringResponse("file contents!"), nil)
+			})
+
+			It("starts the download over", func() {
+				err := marketplace.Download(filename, asset, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).To(Say("Partial download of destination-file.txt does not match the asset, starting over..."))
+
+				Expect(httpClient.DoCallCount()).To(Equal(2))
+				Expect(httpClient.DoArgsForCall(1).Header.Get("Range")).To(BeEmpty())
+				content, err := os.ReadFile(filename)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(Equal("file contents!"))
+			})
+		})
+
+		When("the server sends a different range than was requested", func() {
+			BeforeEach(func() {
+				httpClient.DoReturnsOnCall(0, makePartialResponse("contents!", 0, 14), nil)
+				httpClient.DoReturnsOnCall(1, test.MakeStringResponse("file contents!"), nil)
+			})
+
+			It("starts the download over", func() {
+				err := marketplace.Download(filename, asset, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).To(Say("Partial download of destination-file.txt does not match the asset, starting over..."))
+
+				Expect(httpClient.DoCallCount()).To(Equal(2))
+				content, err := os.ReadFile(filename)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(Equal("file contents!"))
+			})
+		})
+
+		When("the download link has expired", func() {
+			BeforeEach(func() {
+				httpClient.PostJSONReturnsOnCall(1, test.MakeJSONResponse(&pkg.DownloadResponse{
+					Response: &pkg.DownloadResponseBody{
+						PreSignedURL: "https://example.com/download/file.txt?renewed=true",
+					},
+				}), nil)
+				httpClient.DoReturnsOnCall(0, &http.Response{
+					Status:     http.StatusText(http.StatusForbidden),
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader("Request has expired")),
+				}, nil)
+			})
+
+			It("requests a new download link", func() {
+				err := marketplace.Download(filename, asset, true)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(httpClient.PostJSONCallCount()).To(Equal(2))
+				Expect(httpClient.DoCallCount()).To(Equal(2))
+				request := httpClient.DoArgsForCall(1)
+				Expect(request.URL.String()).To(Equal("https://example.com/download/file.txt?renewed=true"))
+				Expect(request.Header.Get("Range")).To(Equal("bytes=5-"))
+
+				content, err := os.ReadFile(filename)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(Equal("file contents!"))
+			})
+		})
+
+		When("the download is interrupted", func() {
+			BeforeEach(func() {
+				interruptedResponse := makePartialResponse("cont", 5, 14)
+				interruptedResponse.Body = io.NopCloser(io.MultiReader(
+					strings.NewReader("cont"),
+					&test.FailingReadWriter{Message: "connection reset"},
+				))
+				httpClient.DoReturnsOnCall(0, interruptedResponse, nil)
+				httpClient.DoReturnsOnCall(1, makePartialResponse("ents!", 9, 14), nil)
+			})
+
+			It("continues from where it stopped", func() {
+				err := marketplace.Download(filename, asset, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).To(Say("Download of destination-file.txt was interrupted \\(failed to download file to disk: connection reset\\), resuming..."))
+
+				Expect(httpClient.DoCallCount()).To(Equal(2))
+				Expect(httpClient.DoArgsForCall(1).Header.Get("Range")).To(Equal("bytes=9-"))
+
+				content, err := os.ReadFile(filename)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(Equal("file contents!"))
+			})
+
+			When("it keeps failing", func() {
+				BeforeEach(func() {
+					httpClient.DoReturns(nil, errors.New("network is down"))
+					httpClient.DoReturnsOnCall(1, nil, errors.New("network is down"))
+				})
+
+				It("keeps the partial file and returns an error", func() {
+					err := marketplace.Download(filename, asset, true)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("failed to download file: network is down"))
+					Expect(httpClient.DoCallCount()).To(Equal(pkg.DownloadAttempts))
+
+					content, err := os.ReadFile(partFilename)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(content)).To(Equal("file cont"))
+					filename = ""
+				})
+			})
+		})
+	})
 })
+
+func makePartialResponse(body string, start, total int) *http.Response {
+	response := test.MakeStringResponse(body)
+	response.StatusCode = http.StatusPartialContent
+	response.Header = http.Header{}
+	response.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, start+len(body)-1, total))
+	return response
+}
+
+func sha256Of(contents string) string {
+	sum := sha256.Sum256([]byte(contents))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ExportProductFilename = "product.yaml"
+	ExportVersionsDir     = "versions"
+)
+
+type ProductExport struct {
+	Product  *models.Product
+	Versions map[string]*models.VersionSpecificProductDetails
+}
+
+func (m *Marketplace) ExportProduct(slug string) (*ProductExport, error) {
+	product, err := m.GetProduct(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &ProductExport{
+		Product:  product,
+		Versions: map[string]*models.VersionSpecificProductDetails{},
+	}
+	for _, version := range product.AllVersions {
+		details, err := m.getVersionDetails(product, version.Number)
+		if err != nil {
+			return nil, err
+		}
+		if details != nil {
+			export.Versions[version.Number] = details
+		}
+	}
+	return export, nil
+}
+
+// WriteTo saves the product and the details for each version as YAML files inside the given directory.
+// It returns the list of files that were written.
+func (e *ProductExport) WriteTo(dir string) ([]string, error) {
+	err := os.MkdirAll(filepath.Join(dir, ExportVersionsDir), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the export directory %s: %w", dir, err)
+	}
+
+	productFile := filepath.Join(dir, ExportProductFilename)
+	err = WriteSpecFile(productFile, e.Product)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{productFile}
+
+	for _, version := range e.Product.AllVersions {
+		details, ok := e.Versions[version.Number]
+		if !ok {
+			continue
+		}
+
+		versionFile := filepath.Join(dir, ExportVersionsDir, versionFilename(version.Number))
+		err = WriteSpecFile(versionFile, details)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, versionFile)
+	}
+	return files, nil
+}
+
+func versionFilename(version string) string {
+	replacer := strings.NewReplacer("/", "_", "\\", "_")
+	return replacer.Replace(version) + ".yaml"
+}
+
+// WriteSpecFile saves the object as YAML, using the same field names as the Marketplace API.
+// This makes the files usable as product specs.
+func WriteSpecFile(path string, object interface{}) error {
+	value, err := toGenericJSON(object)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+
+	contents, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+
+	err = os.WriteFile(path, contents, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package pkg_test
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg/pkgfakes"
+	"github.com/vmware-labs/marketplace-cli/v2/test"
+)
+
+var _ = Describe("Export", func() {
+	var (
+		httpClient  *pkgfakes.FakeHTTPClient
+		marketplace *pkg.Marketplace
+		product     *models.Product
+	)
+
+	BeforeEach(func() {
+		httpClient = &pkgfakes.FakeHTTPClient{}
+		marketplace = &pkg.Marketplace{
+			Client: httpClient,
+			Output: NewBuffer(),
+		}
+
+		product = test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeChart)
+		test.AddVersions(product, "1.0.0", "2.0.0")
+		httpClient.GetReturns(test.MakeJSONResponse(&pkg.GetProductResponse{
+			Response: &pkg.GetProductResponsePayload{
+				Data:       product,
+				StatusCode: http.StatusOK,
+			},
+		}), nil)
+
+		httpClient.PostJSONStub = func(requestURL *url.URL, content interface{}) (*http.Response, error) {
+			version := requestURL.Query().Get("versionNumber")
+			return test.MakeJSONResponse(&pkg.VersionSpecificDetailsPayloadResponse{
+				Response: &pkg.VersionSpecificDetailsPayload{
+					Data: &models.VersionSpecificProductDetails{
+						EulaURL: "https://example.com/eula-" + version + ".txt",
+						ChartVersions: []*models.ChartVersion{
+							{Version: version, AppVersion: version},
+						},
+					},
+					StatusCode: http.StatusOK,
+				},
+			}), nil
+		}
+	})
+
+	Describe("ExportProduct", func() {
+		It("gets the product and the details for every version", func() {
+			export, err := marketplace.ExportProduct("my-super-product")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(export.Product.Slug).To(Equal("my-super-product"))
+
+			Expect(httpClient.PostJSONCallCount()).To(Equal(2))
+			Expect(export.Versions).To(HaveLen(2))
+			Expect(export.Versions["1.0.0"].EulaURL).To(Equal("https://example.com/eula-1.0.0.txt"))
+			Expect(export.Versions["2.0.0"].EulaURL).To(Equal("https://example.com/eula-2.0.0.txt"))
+		})
+
+		When("getting the version details fails", func() {
+			BeforeEach(func() {
+				httpClient.PostJSONStub = nil
+				httpClient.PostJSONReturns(&http.Response{
+					StatusCode: http.StatusNotFound,
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := marketplace.ExportProduct("my-super-product")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("product version details for my-super-product 1.0.0 not found"))
+			})
+		})
+	})
+
+	Describe("WriteTo", func() {
+		var exportDir string
+
+		BeforeEach(func() {
+			var err error
+			exportDir, err = os.MkdirTemp("", "mkpcli-test-export")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(exportDir)).To(Succeed())
+		})
+
+		It("writes the product and each version as spec files", func() {
+			export, err := marketplace.ExportProduct("my-super-product")
+			Expect(err).ToNot(HaveOccurred())
+
+			files, err := export.WriteTo(exportDir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(Equal([]string{
+				filepath.Join(exportDir, "product.yaml"),
+				filepath.Join(exportDir, "versions", "1.0.0.yaml"),
+				filepath.Join(exportDir, "versions", "2.0.0.yaml"),
+			}))
+
+			By("writing a product file that can be used as a spec", func() {
+				spec, err := pkg.LoadProductSpec(files[0])
+				Expect(err).ToNot(HaveOccurred())
+				Expect(spec.Slug()).To(Equal("my-super-product"))
+
+				restored, err := spec.ApplyTo(&models.Product{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(restored.ProductId).To(Equal(product.ProductId))
+				Expect(restored.AllVersions).To(HaveLen(2))
+			})
+
+			By("writing the version specific details", func() {
+				contents, err := os.ReadFile(files[2])
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(contents)).To(ContainSubstring("eulaurl: https://example.com/eula-2.0.0.txt"))
+				Expect(string(contents)).To(ContainSubstring("chartversionsList:"))
+			})
+		})
+	})
+})
@@ -0,0 +1,68 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package pkg
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/vmware-labs/marketplace-cli/v2/internal"
+)
+
+// externalFile is a file that stays hosted outside the Marketplace, and is linked to by its URL
+type externalFile struct {
+	URL           string
+	Name          string
+	Size          int64
+	HashDigest    string
+	HashAlgorithm string
+}
+
+// getExternalFile describes the file at the URL. If fetch is true, the file is read to compute its size and hash, but not saved.
+func (m *Marketplace) getExternalFile(fileURL string, fetch bool) (*externalFile, error) {
+	parsedURL, err := url.Parse(fileURL)
+	if err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
+		return nil, fmt.Errorf("invalid file URL: %s", fileURL)
+	}
+
+	file := &externalFile{
+		URL:  fileURL,
+		Name: path.Base(parsedURL.Path),
+	}
+	if !fetch {
+		return file, nil
+	}
+
+	req, err := http.NewRequest("GET", fileURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to fetch %s: %w", fileURL, err)
+	}
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", fileURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", fileURL, resp.Status)
+	}
+
+	hasher, err := NewHasher(m.hashAlgorithm())
+	if err != nil {
+		return nil, err
+	}
+	progressBar := internal.MakeProgressBar(fmt.Sprintf("Fetching %s", file.Name), resp.ContentLength, m.Output)
+	file.Size, err = io.Copy(progressBar.WrapWriter(hasher), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", fileURL, err)
+	}
+
+	file.HashDigest = hex.EncodeToString(hasher.Sum(nil))
+	file.HashAlgorithm = m.hashAlgorithm()
+	return file, nil
+}
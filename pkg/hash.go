@@ -11,16 +11,26 @@ import (
 	"hash"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
 )
 
+// NewHasher returns a hash for one of the hash algorithms used by the Marketplace
+func NewHasher(hashAlgorithm string) (hash.Hash, error) {
+	switch strings.ToUpper(hashAlgorithm) {
+	case models.HashAlgoSHA1:
+		return sha1.New(), nil
+	case models.HashAlgoSHA256:
+		return sha256.New(), nil
+	}
+	return nil, fmt.Errorf("unsupported hash algorithm: \"%s\"", hashAlgorithm)
+}
+
 func Hash(filePath, hashAlgorithm string) (string, error) {
-	var hashAlgo hash.Hash
-	if hashAlgorithm == models.HashAlgoSHA1 {
-		hashAlgo = sha1.New()
-	} else if hashAlgorithm == models.HashAlgoSHA256 {
-		hashAlgo = sha256.New()
+	hasher, err := NewHasher(hashAlgorithm)
+	if err != nil {
+		return "", err
 	}
 
 	file, err := os.Open(filePath)
@@ -28,7 +38,7 @@ func Hash(filePath, hashAlgorithm string) (string, error) {
 		return "", fmt.Errorf("failed to open %s: %w", filePath, err)
 	}
 
-	_, err = io.Copy(hashAlgo, file)
+	_, err = io.Copy(hasher, file)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate the hash for %s: %w", filePath, err)
 	}
@@ -38,5 +48,22 @@ func Hash(filePath, hashAlgorithm string) (string, error) {
 		return "", fmt.Errorf("failed to close the file %s: %w", filePath, err)
 	}
 
-	return hex.EncodeToString(hashAlgo.Sum(nil)), nil
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+type hashingUploadFunc func(filePath string, hasher hash.Hash) (string, string, error)
+
+// uploadAndHash uploads the file and computes its hash from the same read of the file.
+// It returns the uploaded file name, its URL and its hash.
+func uploadAndHash(filePath, hashAlgorithm string, upload hashingUploadFunc) (string, string, string, error) {
+	hasher, err := NewHasher(hashAlgorithm)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	filename, fileUrl, err := upload(filePath, hasher)
+	if err != nil {
+		return "", "", "", err
+	}
+	return filename, fileUrl, hex.EncodeToString(hasher.Sum(nil)), nil
 }
@@ -0,0 +1,310 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package pkg
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// ArchiveImage is a tagged image inside a docker-archive or OCI-layout tar file
+type ArchiveImage struct {
+	Repository  string
+	Tag         string
+	Digest      string
+	Size        int64
+	IsMultiArch bool
+}
+
+func (i *ArchiveImage) String() string {
+	if i.Repository == "" {
+		return i.Tag
+	}
+	return i.Repository + ":" + i.Tag
+}
+
+//go:generate counterfeiter . ImageArchiveInspectorFunc
+type ImageArchiveInspectorFunc func(imageFile string) ([]*ArchiveImage, error)
+
+var ImageArchiveInspector ImageArchiveInspectorFunc = InspectImageArchive
+
+const (
+	MediaTypeOCIImageIndex      = "application/vnd.oci.image.index.v1+json"
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+type dockerArchiveManifest struct {
+	Config   string   `json:"Config"`
+	RepoTags []string `json:"RepoTags"`
+	Layers   []string `json:"Layers"`
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ociManifest holds the fields of both OCI image indexes and image manifests
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Manifests []ociDescriptor `json:"manifests"`
+	Config    *ociDescriptor  `json:"config"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+type imageArchive struct {
+	file  string
+	sizes map[string]int64
+	// files holds the contents of the index and manifest files that have been read from the archive
+	files map[string][]byte
+}
+
+// read goes through the archive, recording the size of every file and keeping the contents of the named files.
+// The tar reader seeks past the contents of the other files, so the layers are not read.
+func (a *imageArchive) read(names ...string) error {
+	wanted := map[string]bool{}
+	for _, name := range names {
+		if _, ok := a.files[name]; !ok {
+			wanted[name] = true
+		}
+	}
+	if len(wanted) == 0 && len(a.sizes) > 0 {
+		return nil
+	}
+
+	file, err := os.Open(a.file)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", a.file, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	reader := tar.NewReader(file)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read image archive %s: %w", a.file, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(header.Name)
+		a.sizes[name] = header.Size
+		if !wanted[name] {
+			continue
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("failed to read %s from image archive %s: %w", name, a.file, err)
+		}
+		a.files[name] = data
+	}
+	return nil
+}
+
+// InspectImageArchive returns the tagged images inside a docker-archive (docker save) or OCI-layout tar file
+func InspectImageArchive(imageFile string) ([]*ArchiveImage, error) {
+	archive := &imageArchive{
+		file:  imageFile,
+		sizes: map[string]int64{},
+		files: map[string][]byte{},
+	}
+	err := archive.read("index.json", "manifest.json")
+	if err != nil {
+		return nil, err
+	}
+
+	if index, ok := archive.files["index.json"]; ok {
+		return archive.ociImages(index)
+	}
+	if manifest, ok := archive.files["manifest.json"]; ok {
+		return archive.dockerImages(manifest)
+	}
+	return nil, fmt.Errorf("%s is not a docker-archive or OCI-layout image archive", imageFile)
+}
+
+func (a *imageArchive) dockerImages(manifestFile []byte) ([]*ArchiveImage, error) {
+	var manifests []*dockerArchiveManifest
+	err := json.Unmarshal(manifestFile, &manifests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest.json in %s: %w", a.file, err)
+	}
+
+	var images []*ArchiveImage
+	for _, manifest := range manifests {
+		size := a.sizes[path.Clean(manifest.Config)]
+		for _, layer := range manifest.Layers {
+			size += a.sizes[path.Clean(layer)]
+		}
+
+		// A docker-archive does not have the manifest digest. It depends on how the layers are compressed when the
+		// image is pushed, so the digest is left empty rather than using the image ID.
+		for _, repoTag := range manifest.RepoTags {
+			repository, tag := splitImageRef(repoTag)
+			images = append(images, &ArchiveImage{
+				Repository: repository,
+				Tag:        tag,
+				Size:       size,
+			})
+		}
+	}
+	return images, nil
+}
+
+func (a *imageArchive) ociImages(indexFile []byte) ([]*ArchiveImage, error) {
+	index := &ociManifest{}
+	err := json.Unmarshal(indexFile, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse index.json in %s: %w", a.file, err)
+	}
+
+	var images []*ArchiveImage
+	var descriptors []ociDescriptor
+	for _, descriptor := range index.Manifests {
+		ref := descriptor.Annotations["io.containerd.image.name"]
+		if ref == "" {
+			ref = descriptor.Annotations["org.opencontainers.image.ref.name"]
+		}
+		if ref == "" {
+			continue
+		}
+
+		repository, tag := splitImageRef(ref)
+		images = append(images, &ArchiveImage{
+			Repository: repository,
+			Tag:        tag,
+			Digest:     descriptor.Digest,
+		})
+		descriptors = append(descriptors, descriptor)
+	}
+
+	err = a.readOCIManifests(descriptors)
+	if err != nil {
+		return nil, err
+	}
+	for i, descriptor := range descriptors {
+		images[i].Size = a.ociImageSize(descriptor)
+		images[i].IsMultiArch = a.isOCIIndex(descriptor)
+	}
+	return images, nil
+}
+
+func ociBlobPath(digest string) string {
+	return path.Join("blobs", strings.Replace(digest, ":", "/", 1))
+}
+
+// readOCIManifests reads the manifests for the descriptors, and then the platform manifests of any image indexes among them
+func (a *imageArchive) readOCIManifests(descriptors []ociDescriptor) error {
+	seen := map[string]bool{}
+	for len(descriptors) > 0 {
+		var names []string
+		for _, descriptor := range descriptors {
+			seen[descriptor.Digest] = true
+			names = append(names, ociBlobPath(descriptor.Digest))
+		}
+		err := a.read(names...)
+		if err != nil {
+			return err
+		}
+
+		var children []ociDescriptor
+		for _, descriptor := range descriptors {
+			if manifest := a.ociManifest(descriptor); manifest != nil {
+				for _, child := range manifest.Manifests {
+					if !seen[child.Digest] {
+						children = append(children, child)
+					}
+				}
+			}
+		}
+		descriptors = children
+	}
+	return nil
+}
+
+func (a *imageArchive) ociManifest(descriptor ociDescriptor) *ociManifest {
+	contents, ok := a.files[ociBlobPath(descriptor.Digest)]
+	if !ok {
+		return nil
+	}
+	manifest := &ociManifest{}
+	if json.Unmarshal(contents, manifest) != nil {
+		return nil
+	}
+	return manifest
+}
+
+func (a *imageArchive) isOCIIndex(descriptor ociDescriptor) bool {
+	if descriptor.MediaType == MediaTypeOCIImageIndex || descriptor.MediaType == MediaTypeDockerManifestList {
+		return true
+	}
+	manifest := a.ociManifest(descriptor)
+	return manifest != nil && len(manifest.Manifests) > 0
+}
+
+// ociImageSize adds up the config and layer sizes of the image. For multi-arch images, this includes every platform in the archive.
+func (a *imageArchive) ociImageSize(descriptor ociDescriptor) int64 {
+	manifest := a.ociManifest(descriptor)
+	if manifest == nil {
+		return 0
+	}
+
+	size := manifestSize(manifest)
+	for _, child := range manifest.Manifests {
+		size += a.ociImageSize(child)
+	}
+	return size
+}
+
+// splitImageRef splits an image reference into its repository and tag. A reference without a repository is treated as a tag.
+func splitImageRef(ref string) (string, string) {
+	index := strings.LastIndex(ref, ":")
+	if index == -1 {
+		if strings.Contains(ref, "/") {
+			return ref, ""
+		}
+		return "", ref
+	}
+	if strings.Contains(ref[index:], "/") {
+		// The colon is part of a registry host with a port
+		return ref, ""
+	}
+	return ref[:index], ref[index+1:]
+}
+
+// normalizeImageRepository removes the default Docker Hub registry and namespace, so that nginx and docker.io/library/nginx match
+func normalizeImageRepository(repository string) string {
+	repository = strings.TrimPrefix(repository, "index.docker.io/")
+	repository = strings.TrimPrefix(repository, "docker.io/")
+	return strings.TrimPrefix(repository, "library/")
+}
+
+// Matches returns true if the image has the repository and tag. An empty repository or tag matches any.
+func (i *ArchiveImage) Matches(repository, tag string) bool {
+	if tag != "" && i.Tag != tag {
+		return false
+	}
+	return repository == "" || i.Repository == "" || normalizeImageRepository(i.Repository) == normalizeImageRepository(repository)
+}
+
+// FindArchiveImage returns the image with the given repository and tag, or nil if it is not in the list
+func FindArchiveImage(images []*ArchiveImage, repository, tag string) *ArchiveImage {
+	for _, image := range images {
+		if image.Matches(repository, tag) {
+			return image
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package pkg_test
+
+import (
+	"archive/tar"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg"
+)
+
+func writeTarFile(path string, files map[string]string) {
+	file, err := os.Create(path)
+	Expect(err).ToNot(HaveOccurred())
+	writer := tar.NewWriter(file)
+	for name, contents := range files {
+		Expect(writer.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(contents)),
+			Typeflag: tar.TypeReg,
+		})).To(Succeed())
+		_, err = writer.Write([]byte(contents))
+		Expect(err).ToNot(HaveOccurred())
+	}
+	Expect(writer.Close()).To(Succeed())
+	Expect(file.Close()).To(Succeed())
+}
+
+var _ = Describe("InspectImageArchive", func() {
+	var (
+		archiveDir string
+		imageFile  string
+	)
+
+	BeforeEach(func() {
+		var err error
+		archiveDir, err = os.MkdirTemp("", "mkpcli-test-image-archive")
+		Expect(err).ToNot(HaveOccurred())
+		imageFile = filepath.Join(archiveDir, "image.tar")
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(archiveDir)).To(Succeed())
+	})
+
+	Context("docker-archive", func() {
+		BeforeEach(func() {
+			writeTarFile(imageFile, map[string]string{
+				"manifest.json":    `[{"Config": "abc123.json", "RepoTags": ["bitnami/nginx:1.21.6", "bitnami/nginx:latest"], "Layers": ["layer1/layer.tar", "layer2/layer.tar"]}]`,
+				"abc123.json":      `{"architecture": "amd64"}`,
+				"layer1/layer.tar": "layer one",
+				"layer2/layer.tar": "layer two!",
+			})
+		})
+
+		It("returns the tagged images", func() {
+			images, err := pkg.InspectImageArchive(imageFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(images).To(HaveLen(2))
+
+			Expect(images[0].String()).To(Equal("bitnami/nginx:1.21.6"))
+			Expect(images[0].Digest).To(BeEmpty())
+			Expect(images[0].Size).To(Equal(int64(len(`{"architecture": "amd64"}`) + len("layer one") + len("layer two!"))))
+			Expect(images[0].IsMultiArch).To(BeFalse())
+			Expect(images[1].String()).To(Equal("bitnami/nginx:latest"))
+
+			By("matching the image with its full repository", func() {
+				Expect(pkg.FindArchiveImage(images, "docker.io/bitnami/nginx", "1.21.6")).To(Equal(images[0]))
+				Expect(pkg.FindArchiveImage(images, "docker.io/bitnami/nginx", "1.0.0")).To(BeNil())
+				Expect(pkg.FindArchiveImage(images, "docker.io/bitnami/redis", "latest")).To(BeNil())
+			})
+		})
+	})
+
+	Context("OCI-layout", func() {
+		BeforeEach(func() {
+			writeTarFile(imageFile, map[string]string{
+				"oci-layout": `{"imageLayoutVersion": "1.0.0"}`,
+				"index.json": `{"schemaVersion": 2, "manifests": [
+					{"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:single", "size": 100, "annotations": {"io.containerd.image.name": "docker.io/library/nginx:1.21.6", "org.opencontainers.image.ref.name": "1.21.6"}},
+					{"mediaType": "application/vnd.oci.image.index.v1+json", "digest": "sha256:multi", "size": 200, "annotations": {"org.opencontainers.image.ref.name": "2.0.0"}},
+					{"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:untagged", "size": 100}
+				]}`,
+				"blobs/sha256/single": `{"schemaVersion": 2, "config": {"digest": "sha256:config", "size": 10}, "layers": [{"digest": "sha256:layer", "size": 1000}]}`,
+				"blobs/sha256/multi": `{"schemaVersion": 2, "manifests": [
+					{"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:amd64", "size": 100},
+					{"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:arm64", "size": 100}
+				]}`,
+				"blobs/sha256/amd64": `{"schemaVersion": 2, "config": {"digest": "sha256:config1", "size": 10}, "layers": [{"digest": "sha256:layer1", "size": 1000}]}`,
+				"blobs/sha256/arm64": `{"schemaVersion": 2, "config": {"digest": "sha256:config2", "size": 20}, "layers": [{"digest": "sha256:layer2", "size": 2000}]}`,
+			})
+		})
+
+		It("returns the tagged images", func() {
+			images, err := pkg.InspectImageArchive(imageFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(images).To(HaveLen(2))
+
+			Expect(images[0].String()).To(Equal("docker.io/library/nginx:1.21.6"))
+			Expect(images[0].Digest).To(Equal("sha256:single"))
+			Expect(images[0].Size).To(Equal(int64(1010)))
+			Expect(images[0].IsMultiArch).To(BeFalse())
+
+			By("detecting multi-arch images", func() {
+				Expect(images[1].String()).To(Equal("2.0.0"))
+				Expect(images[1].Digest).To(Equal("sha256:multi"))
+				Expect(images[1].Size).To(Equal(int64(3030)))
+				Expect(images[1].IsMultiArch).To(BeTrue())
+			})
+
+			By("matching images by their tag when the archive does not name the repository", func() {
+				Expect(pkg.FindArchiveImage(images, "nginx", "1.21.6")).To(Equal(images[0]))
+				Expect(pkg.FindArchiveImage(images, "example.com/nginx", "2.0.0")).To(Equal(images[1]))
+			})
+		})
+	})
+
+	When("the file is not an image archive", func() {
+		BeforeEach(func() {
+			writeTarFile(imageFile, map[string]string{"readme.txt": "hello"})
+		})
+
+		It("returns an error", func() {
+			_, err := pkg.InspectImageArchive(imageFile)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(imageFile + " is not a docker-archive or OCI-layout image archive"))
+		})
+	})
+})
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/url"
+	"strings"
 
 	"github.com/vmware-labs/marketplace-cli/v2/internal"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
@@ -15,6 +16,10 @@ import (
 //go:generate counterfeiter . MarketplaceInterface
 type MarketplaceInterface interface {
 	EnableStrictDecoding()
+	EnableDryRun()
+	DisableProgressBars()
+	EnableMultipleProgressBars()
+	DisableRegistryVerification()
 	DecodeJson(input io.Reader, output interface{}) error
 
 	GetHost() string
@@ -25,42 +30,92 @@ type MarketplaceInterface interface {
 	GetProduct(slug string) (*models.Product, error)
 	GetProductWithVersion(slug, version string) (*models.Product, *models.Version, error)
 	PutProduct(product *models.Product, versionUpdate bool) (*models.Product, error)
+	CreateProduct(product *models.Product) (*models.Product, error)
+	ArchiveVersion(product *models.Product, version *models.Version) (*models.Product, error)
+	ExportProduct(slug string) (*ProductExport, error)
 
 	GetUploader(orgID string) (internal.Uploader, error)
 	SetUploader(uploader internal.Uploader)
 
-	Download(filename string, payload *DownloadRequestPayload) error
+	Download(filename string, asset *Asset, resume bool) error
 
 	DownloadChart(chartURL *url.URL) (*models.ChartVersion, error)
 	AttachLocalChart(chartPath, instructions string, product *models.Product, version *models.Version) (*models.Product, error)
 	AttachPublicChart(chartPath *url.URL, instructions string, product *models.Product, version *models.Version) (*models.Product, error)
+	PullChart(chartURL *url.URL) (string, error)
+	AttachOCIChart(chartURL *url.URL, instructions string, product *models.Product, version *models.Version) (*models.Product, error)
+	ValidateChart(chartURL *url.URL, productVersion string) ([]string, error)
 
 	AttachLocalContainerImage(imageFile, image, tag, tagType, instructions string, product *models.Product, version *models.Version) (*models.Product, error)
 	AttachPublicContainerImage(image, tag, tagType, instructions string, product *models.Product, version *models.Version) (*models.Product, error)
+	AttachContainerImages(images []*ContainerImage, instructions string, product *models.Product, version *models.Version) (*models.Product, error)
 
 	AttachMetaFile(metafile, metafileType, metafileVersion string, product *models.Product, version *models.Version) (*models.Product, error)
 
 	AttachOtherFile(file string, product *models.Product, version *models.Version) (*models.Product, error)
+	AttachExternalOtherFile(fileURL string, fetch bool, product *models.Product, version *models.Version) (*models.Product, error)
+
+	AttachBlueprint(blueprint *Blueprint, product *models.Product, version *models.Version) (*models.Product, error)
 
 	UploadVM(vmFile string, product *models.Product, version *models.Version) (*models.Product, error)
+	AttachExternalVM(fileURL string, fetch bool, product *models.Product, version *models.Version) (*models.Product, error)
 }
 
 type Marketplace struct {
-	Host           string
-	APIHost        string
-	UIHost         string
-	StorageBucket  string
-	StorageRegion  string
-	Client         HTTPClient
-	Output         io.Writer
-	uploader       internal.Uploader
-	strictDecoding bool
+	Host              string
+	APIHost           string
+	UIHost            string
+	StorageBucket     string
+	StorageRegion     string
+	UploadPartSize    int64
+	UploadConcurrency int
+	HashAlgorithm     string
+	Client            HTTPClient
+	Output            io.Writer
+	uploader          internal.Uploader
+	uploaders         map[string]internal.Uploader
+	uploadCredentials *uploadCredentialsProvider
+	strictDecoding    bool
+	dryRun            bool
+	noProgressBars    bool
+	progressBars      *internal.MultiProgressBar
+	noRegistryCheck   bool
 }
 
 func (m *Marketplace) EnableStrictDecoding() {
 	m.strictDecoding = true
 }
 
+// EnableDryRun makes PutProduct return the changes it would make, instead of updating the product
+func (m *Marketplace) EnableDryRun() {
+	m.dryRun = true
+}
+
+// DisableProgressBars stops downloads from drawing progress bars, for when several run at the same time
+func (m *Marketplace) DisableProgressBars() {
+	m.noProgressBars = true
+}
+
+// EnableMultipleProgressBars draws each download's progress bar on its own line, for when several run at the same time
+func (m *Marketplace) EnableMultipleProgressBars() {
+	m.progressBars = internal.NewMultiProgressBar(m.Output)
+}
+
+// DisableRegistryVerification attaches registry images without checking that they exist in their registry
+func (m *Marketplace) DisableRegistryVerification() {
+	m.noRegistryCheck = true
+}
+
+// DefaultHashAlgorithm is used to hash uploaded files when the Marketplace does not have a hash algorithm set
+const DefaultHashAlgorithm = models.HashAlgoSHA256
+
+func (m *Marketplace) hashAlgorithm() string {
+	if m.HashAlgorithm == "" {
+		return DefaultHashAlgorithm
+	}
+	return strings.ToUpper(m.HashAlgorithm)
+}
+
 func (m *Marketplace) GetHost() string {
 	return m.Host
 }
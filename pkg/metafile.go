@@ -14,16 +14,12 @@ const (
 )
 
 func (m *Marketplace) AttachMetaFile(metafile, metafileType, metafileVersion string, product *models.Product, version *models.Version) (*models.Product, error) {
-	hashString, err := Hash(metafile, models.HashAlgoSHA1)
-	if err != nil {
-		return nil, err
-	}
-
 	uploader, err := m.GetUploader(product.PublisherDetails.OrgId)
 	if err != nil {
 		return nil, err
 	}
-	filename, fileUrl, err := uploader.UploadMetaFile(metafile)
+	// Meta files are always hashed with SHA1, which is the only algorithm the Marketplace is known to accept for them
+	filename, fileUrl, hashString, err := uploadAndHash(metafile, models.HashAlgoSHA1, uploader.UploadMetaFileWithHash)
 	if err != nil {
 		return nil, err
 	}
@@ -8,28 +8,46 @@ import (
 )
 
 func (m *Marketplace) AttachOtherFile(file string, product *models.Product, version *models.Version) (*models.Product, error) {
-	hashString, err := Hash(file, models.HashAlgoSHA1)
-	if err != nil {
-		return nil, err
-	}
-
 	uploader, err := m.GetUploader(product.PublisherDetails.OrgId)
 	if err != nil {
 		return nil, err
 	}
-	filename, fileUrl, err := uploader.UploadProductFile(file)
+	filename, fileUrl, hashString, err := uploadAndHash(file, m.hashAlgorithm(), uploader.UploadProductFileWithHash)
 	if err != nil {
 		return nil, err
 	}
 
 	product.PrepForUpdate()
-	product.AddOnFiles = []*models.AddOnFile{{
+	product.AddOnFiles = append(product.AddOnFiles, &models.AddOnFile{
 		Name:          filename,
 		URL:           fileUrl,
 		AppVersion:    version.Number,
 		HashDigest:    hashString,
-		HashAlgorithm: models.HashAlgoSHA1,
-	}}
+		HashAlgorithm: m.hashAlgorithm(),
+	})
+
+	return m.PutProduct(product, version.IsNewVersion)
+}
+
+// AttachExternalOtherFile attaches a file that stays hosted at the given URL, instead of uploading it to the Marketplace
+func (m *Marketplace) AttachExternalOtherFile(fileURL string, fetch bool, product *models.Product, version *models.Version) (*models.Product, error) {
+	file, err := m.getExternalFile(fileURL, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	product.PrepForUpdate()
+	product.AddOnFiles = append(product.AddOnFiles, &models.AddOnFile{
+		Name:            file.Name,
+		URL:             file.URL,
+		AppVersion:      version.Number,
+		HashDigest:      file.HashDigest,
+		HashAlgorithm:   file.HashAlgorithm,
+		Size:            file.Size,
+		IsRedirectURL:   true,
+		IsThirdPartyURL: true,
+		ThirdPartyURL:   file.URL,
+	})
 
 	return m.PutProduct(product, version.IsNewVersion)
 }
@@ -4,11 +4,18 @@
 package pkg_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
 	"github.com/spf13/viper"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/internalfakes"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
@@ -42,7 +49,10 @@ var _ = Describe("Other", func() {
 			file, err := os.CreateTemp("", "mkpcli-attachotherfile-test-file.tgz")
 			Expect(err).ToNot(HaveOccurred())
 			filePath = file.Name()
-			uploader.UploadProductFileReturns("uploaded-file.tgz", "https://example.com/uploaded-file.tgz", err)
+			uploader.UploadProductFileWithHashStub = func(filePath string, hasher hash.Hash) (string, string, error) {
+				_, _ = hasher.Write([]byte("file contents"))
+				return "uploaded-file.tgz", "https://example.com/uploaded-file.tgz", nil
+			}
 
 			httpClient.PutStub = PutProductEchoResponse
 		})
@@ -59,8 +69,8 @@ var _ = Describe("Other", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			By("uploading the file", func() {
-				Expect(uploader.UploadProductFileCallCount()).To(Equal(1))
-				uploadedFilePath := uploader.UploadProductFileArgsForCall(0)
+				Expect(uploader.UploadProductFileWithHashCallCount()).To(Equal(1))
+				uploadedFilePath, _ := uploader.UploadProductFileWithHashArgsForCall(0)
 				Expect(uploadedFilePath).To(Equal(filePath))
 			})
 
@@ -70,19 +80,48 @@ var _ = Describe("Other", func() {
 				Expect(uploadedFile.Name).To(Equal("uploaded-file.tgz"))
 				Expect(uploadedFile.AppVersion).To(Equal("1.2.3"))
 				Expect(uploadedFile.URL).To(Equal("https://example.com/uploaded-file.tgz"))
-				Expect(uploadedFile.HashAlgorithm).To(Equal("SHA1"))
-				Expect(uploadedFile.HashDigest).To(Equal("da39a3ee5e6b4b0d3255bfef95601890afd80709"))
+				Expect(uploadedFile.HashAlgorithm).To(Equal("SHA256"))
+				Expect(uploadedFile.HashDigest).To(Equal("7bb6f9f7a47a63e684925af3608c059edcc371eb81188c48c9714896fb1091fd"))
 			})
 		})
 
-		When("hashing fails", func() {
+		When("the product already has other files", func() {
+			It("keeps them, except for a file that was removed to replace it", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", "PENDING")
+				test.AddVersions(product, "1.2.2", "1.2.3")
+				product.AddOnFiles = []*models.AddOnFile{
+					test.CreateFakeOtherFile("uploaded-file.tgz", "1.2.3"),
+					test.CreateFakeOtherFile("other-file.tgz", "1.2.3"),
+					test.CreateFakeOtherFile("uploaded-file.tgz", "1.2.2"),
+				}
+				product.RemoveAddonFile("1.2.3", "uploaded-file.tgz")
+
+				sentProduct, err := marketplace.AttachOtherFile(filePath, product, &models.Version{Number: "1.2.3"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(httpClient.PutCallCount()).To(Equal(1))
+				Expect(sentProduct.AddOnFiles).To(HaveLen(3))
+				Expect(sentProduct.AddOnFiles[0].Name).To(Equal("other-file.tgz"))
+				Expect(sentProduct.AddOnFiles[1].Name).To(Equal("uploaded-file.tgz"))
+				Expect(sentProduct.AddOnFiles[1].AppVersion).To(Equal("1.2.2"))
+				Expect(sentProduct.AddOnFiles[2].Name).To(Equal("uploaded-file.tgz"))
+				Expect(sentProduct.AddOnFiles[2].AppVersion).To(Equal("1.2.3"))
+				Expect(sentProduct.AddOnFiles[2].URL).To(Equal("https://example.com/uploaded-file.tgz"))
+			})
+		})
+
+		When("the hash algorithm is not supported", func() {
+			BeforeEach(func() {
+				marketplace.HashAlgorithm = "md5"
+			})
 			It("returns an error", func() {
 				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", "PENDING")
 				test.AddVersions(product, "1.2.3")
 
-				_, err := marketplace.AttachOtherFile("this/file/does/not/exist", product, &models.Version{Number: "1.2.3"})
+				_, err := marketplace.AttachOtherFile(filePath, product, &models.Version{Number: "1.2.3"})
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("failed to open this/file/does/not/exist: open this/file/does/not/exist: no such file or directory"))
+				Expect(err.Error()).To(Equal("unsupported hash algorithm: \"MD5\""))
+				Expect(uploader.UploadProductFileWithHashCallCount()).To(Equal(0))
 			})
 		})
 
@@ -104,7 +143,8 @@ var _ = Describe("Other", func() {
 
 		When("uploading the file fails", func() {
 			BeforeEach(func() {
-				uploader.UploadProductFileReturns("", "", errors.New("upload product file failed"))
+				uploader.UploadProductFileWithHashStub = nil
+				uploader.UploadProductFileWithHashReturns("", "", errors.New("upload product file failed"))
 			})
 			It("returns an error", func() {
 				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", "PENDING")
@@ -130,4 +170,53 @@ var _ = Describe("Other", func() {
 			})
 		})
 	})
+
+	Describe("AttachExternalOtherFile", func() {
+		BeforeEach(func() {
+			marketplace.Output = NewBuffer()
+			httpClient.DoReturns(test.MakeStringResponse("file contents"), nil)
+			httpClient.PutStub = PutProductEchoResponse
+		})
+
+		It("attaches the file by its URL", func() {
+			product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeOthers)
+			test.AddVersions(product, "1.2.3")
+
+			updatedProduct, err := marketplace.AttachExternalOtherFile("https://downloads.example.com/files/hyperspace-db.tgz?token=abc", true, product, &models.Version{Number: "1.2.3"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(uploader.UploadProductFileCallCount()).To(Equal(0))
+			Expect(updatedProduct.AddOnFiles).To(HaveLen(1))
+			file := updatedProduct.AddOnFiles[0]
+			Expect(file.Name).To(Equal("hyperspace-db.tgz"))
+			Expect(file.URL).To(Equal("https://downloads.example.com/files/hyperspace-db.tgz?token=abc"))
+			Expect(file.IsRedirectURL).To(BeTrue())
+			Expect(file.IsThirdPartyURL).To(BeTrue())
+			Expect(file.Size).To(Equal(int64(13)))
+			Expect(file.HashAlgorithm).To(Equal("SHA256"))
+			Expect(file.HashDigest).To(Equal("7bb6f9f7a47a63e684925af3608c059edcc371eb81188c48c9714896fb1091fd"))
+		})
+
+		It("sends the external URL as the third party URL", func() {
+			var sentProduct map[string]interface{}
+			httpClient.PutStub = func(requestURL *url.URL, content io.Reader, contentType string) (*http.Response, error) {
+				body, err := io.ReadAll(content)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(json.Unmarshal(body, &sentProduct)).To(Succeed())
+				return PutProductEchoResponse(requestURL, bytes.NewReader(body), contentType)
+			}
+			product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeOthers)
+			test.AddVersions(product, "1.2.3")
+
+			_, err := marketplace.AttachExternalOtherFile("https://downloads.example.com/files/hyperspace-db.tgz?token=abc", false, product, &models.Version{Number: "1.2.3"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(httpClient.PutCallCount()).To(Equal(1))
+			files := sentProduct["addonfilesList"].([]interface{})
+			Expect(files).To(HaveLen(1))
+			file := files[0].(map[string]interface{})
+			Expect(file["isthirdpartyurl"]).To(BeTrue())
+			Expect(file["thirdpartyurl"]).To(Equal("https://downloads.example.com/files/hyperspace-db.tgz?token=abc"))
+		})
+	})
 })
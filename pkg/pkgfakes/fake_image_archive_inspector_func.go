@@ -0,0 +1,115 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package pkgfakes
+
+import (
+	"sync"
+
+	"github.com/vmware-labs/marketplace-cli/v2/pkg"
+)
+
+type FakeImageArchiveInspectorFunc struct {
+	Stub        func(string) ([]*pkg.ArchiveImage, error)
+	mutex       sync.RWMutex
+	argsForCall []struct {
+		arg1 string
+	}
+	returns struct {
+		result1 []*pkg.ArchiveImage
+		result2 error
+	}
+	returnsOnCall map[int]struct {
+		result1 []*pkg.ArchiveImage
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImageArchiveInspectorFunc) Spy(arg1 string) ([]*pkg.ArchiveImage, error) {
+	fake.mutex.Lock()
+	ret, specificReturn := fake.returnsOnCall[len(fake.argsForCall)]
+	fake.argsForCall = append(fake.argsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.Stub
+	returns := fake.returns
+	fake.recordInvocation("ImageArchiveInspectorFunc", []interface{}{arg1})
+	fake.mutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return returns.result1, returns.result2
+}
+
+func (fake *FakeImageArchiveInspectorFunc) CallCount() int {
+	fake.mutex.RLock()
+	defer fake.mutex.RUnlock()
+	return len(fake.argsForCall)
+}
+
+func (fake *FakeImageArchiveInspectorFunc) Calls(stub func(string) ([]*pkg.ArchiveImage, error)) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.Stub = stub
+}
+
+func (fake *FakeImageArchiveInspectorFunc) ArgsForCall(i int) string {
+	fake.mutex.RLock()
+	defer fake.mutex.RUnlock()
+	return fake.argsForCall[i].arg1
+}
+
+func (fake *FakeImageArchiveInspectorFunc) Returns(result1 []*pkg.ArchiveImage, result2 error) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.Stub = nil
+	fake.returns = struct {
+		result1 []*pkg.ArchiveImage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImageArchiveInspectorFunc) ReturnsOnCall(i int, result1 []*pkg.ArchiveImage, result2 error) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.Stub = nil
+	if fake.returnsOnCall == nil {
+		fake.returnsOnCall = make(map[int]struct {
+			result1 []*pkg.ArchiveImage
+			result2 error
+		})
+	}
+	fake.returnsOnCall[i] = struct {
+		result1 []*pkg.ArchiveImage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImageArchiveInspectorFunc) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.mutex.RLock()
+	defer fake.mutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImageArchiveInspectorFunc) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ pkg.ImageArchiveInspectorFunc = new(FakeImageArchiveInspectorFunc).Spy
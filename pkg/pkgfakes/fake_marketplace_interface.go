@@ -12,6 +12,83 @@ import (
 )
 
 type FakeMarketplaceInterface struct {
+	ArchiveVersionStub        func(*models.Product, *models.Version) (*models.Product, error)
+	archiveVersionMutex       sync.RWMutex
+	archiveVersionArgsForCall []struct {
+		arg1 *models.Product
+		arg2 *models.Version
+	}
+	archiveVersionReturns struct {
+		result1 *models.Product
+		result2 error
+	}
+	archiveVersionReturnsOnCall map[int]struct {
+		result1 *models.Product
+		result2 error
+	}
+	AttachBlueprintStub        func(*pkg.Blueprint, *models.Product, *models.Version) (*models.Product, error)
+	attachBlueprintMutex       sync.RWMutex
+	attachBlueprintArgsForCall []struct {
+		arg1 *pkg.Blueprint
+		arg2 *models.Product
+		arg3 *models.Version
+	}
+	attachBlueprintReturns struct {
+		result1 *models.Product
+		result2 error
+	}
+	attachBlueprintReturnsOnCall map[int]struct {
+		result1 *models.Product
+		result2 error
+	}
+	AttachContainerImagesStub        func([]*pkg.ContainerImage, string, *models.Product, *models.Version) (*models.Product, error)
+	attachContainerImagesMutex       sync.RWMutex
+	attachContainerImagesArgsForCall []struct {
+		arg1 []*pkg.ContainerImage
+		arg2 string
+		arg3 *models.Product
+		arg4 *models.Version
+	}
+	attachContainerImagesReturns struct {
+		result1 *models.Product
+		result2 error
+	}
+	attachContainerImagesReturnsOnCall map[int]struct {
+		result1 *models.Product
+		result2 error
+	}
+	AttachExternalOtherFileStub        func(string, bool, *models.Product, *models.Version) (*models.Product, error)
+	attachExternalOtherFileMutex       sync.RWMutex
+	attachExternalOtherFileArgsForCall []struct {
+		arg1 string
+		arg2 bool
+		arg3 *models.Product
+		arg4 *models.Version
+	}
+	attachExternalOtherFileReturns struct {
+		result1 *models.Product
+		result2 error
+	}
+	attachExternalOtherFileReturnsOnCall map[int]struct {
+		result1 *models.Product
+		result2 error
+	}
+	AttachExternalVMStub        func(string, bool, *models.Product, *models.Version) (*models.Product, error)
+	attachExternalVMMutex       sync.RWMutex
+	attachExternalVMArgsForCall []struct {
+		arg1 string
+		arg2 bool
+		arg3 *models.Product
+		arg4 *models.Version
+	}
+	attachExternalVMReturns struct {
+		result1 *models.Product
+		result2 error
+	}
+	attachExternalVMReturnsOnCall map[int]struct {
+		result1 *models.Product
+		result2 error
+	}
 	AttachLocalChartStub        func(string, string, *models.Product, *models.Version) (*models.Product, error)
 	attachLocalChartMutex       sync.RWMutex
 	attachLocalChartArgsForCall []struct {
@@ -64,6 +141,22 @@ type FakeMarketplaceInterface struct {
 		result1 *models.Product
 		result2 error
 	}
+	AttachOCIChartStub        func(*url.URL, string, *models.Product, *models.Version) (*models.Product, error)
+	attachOCIChartMutex       sync.RWMutex
+	attachOCIChartArgsForCall []struct {
+		arg1 *url.URL
+		arg2 string
+		arg3 *models.Product
+		arg4 *models.Version
+	}
+	attachOCIChartReturns struct {
+		result1 *models.Product
+		result2 error
+	}
+	attachOCIChartReturnsOnCall map[int]struct {
+		result1 *models.Product
+		result2 error
+	}
 	AttachOtherFileStub        func(string, *models.Product, *models.Version) (*models.Product, error)
 	attachOtherFileMutex       sync.RWMutex
 	attachOtherFileArgsForCall []struct {
@@ -113,6 +206,19 @@ type FakeMarketplaceInterface struct {
 		result1 *models.Product
 		result2 error
 	}
+	CreateProductStub        func(*models.Product) (*models.Product, error)
+	createProductMutex       sync.RWMutex
+	createProductArgsForCall []struct {
+		arg1 *models.Product
+	}
+	createProductReturns struct {
+		result1 *models.Product
+		result2 error
+	}
+	createProductReturnsOnCall map[int]struct {
+		result1 *models.Product
+		result2 error
+	}
 	DecodeJsonStub        func(io.Reader, interface{}) error
 	decodeJsonMutex       sync.RWMutex
 	decodeJsonArgsForCall []struct {
@@ -125,11 +231,20 @@ type FakeMarketplaceInterface struct {
 	decodeJsonReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DownloadStub        func(string, *pkg.DownloadRequestPayload) error
+	DisableProgressBarsStub        func()
+	disableProgressBarsMutex       sync.RWMutex
+	disableProgressBarsArgsForCall []struct {
+	}
+	DisableRegistryVerificationStub        func()
+	disableRegistryVerificationMutex       sync.RWMutex
+	disableRegistryVerificationArgsForCall []struct {
+	}
+	DownloadStub        func(string, *pkg.Asset, bool) error
 	downloadMutex       sync.RWMutex
 	downloadArgsForCall []struct {
 		arg1 string
-		arg2 *pkg.DownloadRequestPayload
+		arg2 *pkg.Asset
+		arg3 bool
 	}
 	downloadReturns struct {
 		result1 error
@@ -150,10 +265,31 @@ type FakeMarketplaceInterface struct {
 		result1 *models.ChartVersion
 		result2 error
 	}
+	EnableDryRunStub        func()
+	enableDryRunMutex       sync.RWMutex
+	enableDryRunArgsForCall []struct {
+	}
+	EnableMultipleProgressBarsStub        func()
+	enableMultipleProgressBarsMutex       sync.RWMutex
+	enableMultipleProgressBarsArgsForCall []struct {
+	}
 	EnableStrictDecodingStub        func()
 	enableStrictDecodingMutex       sync.RWMutex
 	enableStrictDecodingArgsForCall []struct {
 	}
+	ExportProductStub        func(string) (*pkg.ProductExport, error)
+	exportProductMutex       sync.RWMutex
+	exportProductArgsForCall []struct {
+		arg1 string
+	}
+	exportProductReturns struct {
+		result1 *pkg.ProductExport
+		result2 error
+	}
+	exportProductReturnsOnCall map[int]struct {
+		result1 *pkg.ProductExport
+		result2 error
+	}
 	GetAPIHostStub        func() string
 	getAPIHostMutex       sync.RWMutex
 	getAPIHostArgsForCall []struct {
@@ -239,6 +375,19 @@ type FakeMarketplaceInterface struct {
 		result1 []*models.Product
 		result2 error
 	}
+	PullChartStub        func(*url.URL) (string, error)
+	pullChartMutex       sync.RWMutex
+	pullChartArgsForCall []struct {
+		arg1 *url.URL
+	}
+	pullChartReturns struct {
+		result1 string
+		result2 error
+	}
+	pullChartReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	PutProductStub        func(*models.Product, bool) (*models.Product, error)
 	putProductMutex       sync.RWMutex
 	putProductArgsForCall []struct {
@@ -273,10 +422,361 @@ type FakeMarketplaceInterface struct {
 		result1 *models.Product
 		result2 error
 	}
+	ValidateChartStub        func(*url.URL, string) ([]string, error)
+	validateChartMutex       sync.RWMutex
+	validateChartArgsForCall []struct {
+		arg1 *url.URL
+		arg2 string
+	}
+	validateChartReturns struct {
+		result1 []string
+		result2 error
+	}
+	validateChartReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeMarketplaceInterface) ArchiveVersion(arg1 *models.Product, arg2 *models.Version) (*models.Product, error) {
+	fake.archiveVersionMutex.Lock()
+	ret, specificReturn := fake.archiveVersionReturnsOnCall[len(fake.archiveVersionArgsForCall)]
+	fake.archiveVersionArgsForCall = append(fake.archiveVersionArgsForCall, struct {
+		arg1 *models.Product
+		arg2 *models.Version
+	}{arg1, arg2})
+	stub := fake.ArchiveVersionStub
+	fakeReturns := fake.archiveVersionReturns
+	fake.recordInvocation("ArchiveVersion", []interface{}{arg1, arg2})
+	fake.archiveVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMarketplaceInterface) ArchiveVersionCallCount() int {
+	fake.archiveVersionMutex.RLock()
+	defer fake.archiveVersionMutex.RUnlock()
+	return len(fake.archiveVersionArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) ArchiveVersionCalls(stub func(*models.Product, *models.Version) (*models.Product, error)) {
+	fake.archiveVersionMutex.Lock()
+	defer fake.archiveVersionMutex.Unlock()
+	fake.ArchiveVersionStub = stub
+}
+
+func (fake *FakeMarketplaceInterface) ArchiveVersionArgsForCall(i int) (*models.Product, *models.Version) {
+	fake.archiveVersionMutex.RLock()
+	defer fake.archiveVersionMutex.RUnlock()
+	argsForCall := fake.archiveVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMarketplaceInterface) ArchiveVersionReturns(result1 *models.Product, result2 error) {
+	fake.archiveVersionMutex.Lock()
+	defer fake.archiveVersionMutex.Unlock()
+	fake.ArchiveVersionStub = nil
+	fake.archiveVersionReturns = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) ArchiveVersionReturnsOnCall(i int, result1 *models.Product, result2 error) {
+	fake.archiveVersionMutex.Lock()
+	defer fake.archiveVersionMutex.Unlock()
+	fake.ArchiveVersionStub = nil
+	if fake.archiveVersionReturnsOnCall == nil {
+		fake.archiveVersionReturnsOnCall = make(map[int]struct {
+			result1 *models.Product
+			result2 error
+		})
+	}
+	fake.archiveVersionReturnsOnCall[i] = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) AttachBlueprint(arg1 *pkg.Blueprint, arg2 *models.Product, arg3 *models.Version) (*models.Product, error) {
+	fake.attachBlueprintMutex.Lock()
+	ret, specificReturn := fake.attachBlueprintReturnsOnCall[len(fake.attachBlueprintArgsForCall)]
+	fake.attachBlueprintArgsForCall = append(fake.attachBlueprintArgsForCall, struct {
+		arg1 *pkg.Blueprint
+		arg2 *models.Product
+		arg3 *models.Version
+	}{arg1, arg2, arg3})
+	stub := fake.AttachBlueprintStub
+	fakeReturns := fake.attachBlueprintReturns
+	fake.recordInvocation("AttachBlueprint", []interface{}{arg1, arg2, arg3})
+	fake.attachBlueprintMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMarketplaceInterface) AttachBlueprintCallCount() int {
+	fake.attachBlueprintMutex.RLock()
+	defer fake.attachBlueprintMutex.RUnlock()
+	return len(fake.attachBlueprintArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) AttachBlueprintCalls(stub func(*pkg.Blueprint, *models.Product, *models.Version) (*models.Product, error)) {
+	fake.attachBlueprintMutex.Lock()
+	defer fake.attachBlueprintMutex.Unlock()
+	fake.AttachBlueprintStub = stub
+}
+
+func (fake *FakeMarketplaceInterface) AttachBlueprintArgsForCall(i int) (*pkg.Blueprint, *models.Product, *models.Version) {
+	fake.attachBlueprintMutex.RLock()
+	defer fake.attachBlueprintMutex.RUnlock()
+	argsForCall := fake.attachBlueprintArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeMarketplaceInterface) AttachBlueprintReturns(result1 *models.Product, result2 error) {
+	fake.attachBlueprintMutex.Lock()
+	defer fake.attachBlueprintMutex.Unlock()
+	fake.AttachBlueprintStub = nil
+	fake.attachBlueprintReturns = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) AttachBlueprintReturnsOnCall(i int, result1 *models.Product, result2 error) {
+	fake.attachBlueprintMutex.Lock()
+	defer fake.attachBlueprintMutex.Unlock()
+	fake.AttachBlueprintStub = nil
+	if fake.attachBlueprintReturnsOnCall == nil {
+		fake.attachBlueprintReturnsOnCall = make(map[int]struct {
+			result1 *models.Product
+			result2 error
+		})
+	}
+	fake.attachBlueprintReturnsOnCall[i] = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) AttachContainerImages(arg1 []*pkg.ContainerImage, arg2 string, arg3 *models.Product, arg4 *models.Version) (*models.Product, error) {
+	var arg1Copy []*pkg.ContainerImage
+	if arg1 != nil {
+		arg1Copy = make([]*pkg.ContainerImage, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.attachContainerImagesMutex.Lock()
+	ret, specificReturn := fake.attachContainerImagesReturnsOnCall[len(fake.attachContainerImagesArgsForCall)]
+	fake.attachContainerImagesArgsForCall = append(fake.attachContainerImagesArgsForCall, struct {
+		arg1 []*pkg.ContainerImage
+		arg2 string
+		arg3 *models.Product
+		arg4 *models.Version
+	}{arg1Copy, arg2, arg3, arg4})
+	stub := fake.AttachContainerImagesStub
+	fakeReturns := fake.attachContainerImagesReturns
+	fake.recordInvocation("AttachContainerImages", []interface{}{arg1Copy, arg2, arg3, arg4})
+	fake.attachContainerImagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMarketplaceInterface) AttachContainerImagesCallCount() int {
+	fake.attachContainerImagesMutex.RLock()
+	defer fake.attachContainerImagesMutex.RUnlock()
+	return len(fake.attachContainerImagesArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) AttachContainerImagesCalls(stub func([]*pkg.ContainerImage, string, *models.Product, *models.Version) (*models.Product, error)) {
+	fake.attachContainerImagesMutex.Lock()
+	defer fake.attachContainerImagesMutex.Unlock()
+	fake.AttachContainerImagesStub = stub
+}
+
+func (fake *FakeMarketplaceInterface) AttachContainerImagesArgsForCall(i int) ([]*pkg.ContainerImage, string, *models.Product, *models.Version) {
+	fake.attachContainerImagesMutex.RLock()
+	defer fake.attachContainerImagesMutex.RUnlock()
+	argsForCall := fake.attachContainerImagesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeMarketplaceInterface) AttachContainerImagesReturns(result1 *models.Product, result2 error) {
+	fake.attachContainerImagesMutex.Lock()
+	defer fake.attachContainerImagesMutex.Unlock()
+	fake.AttachContainerImagesStub = nil
+	fake.attachContainerImagesReturns = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) AttachContainerImagesReturnsOnCall(i int, result1 *models.Product, result2 error) {
+	fake.attachContainerImagesMutex.Lock()
+	defer fake.attachContainerImagesMutex.Unlock()
+	fake.AttachContainerImagesStub = nil
+	if fake.attachContainerImagesReturnsOnCall == nil {
+		fake.attachContainerImagesReturnsOnCall = make(map[int]struct {
+			result1 *models.Product
+			result2 error
+		})
+	}
+	fake.attachContainerImagesReturnsOnCall[i] = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) AttachExternalOtherFile(arg1 string, arg2 bool, arg3 *models.Product, arg4 *models.Version) (*models.Product, error) {
+	fake.attachExternalOtherFileMutex.Lock()
+	ret, specificReturn := fake.attachExternalOtherFileReturnsOnCall[len(fake.attachExternalOtherFileArgsForCall)]
+	fake.attachExternalOtherFileArgsForCall = append(fake.attachExternalOtherFileArgsForCall, struct {
+		arg1 string
+		arg2 bool
+		arg3 *models.Product
+		arg4 *models.Version
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AttachExternalOtherFileStub
+	fakeReturns := fake.attachExternalOtherFileReturns
+	fake.recordInvocation("AttachExternalOtherFile", []interface{}{arg1, arg2, arg3, arg4})
+	fake.attachExternalOtherFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMarketplaceInterface) AttachExternalOtherFileCallCount() int {
+	fake.attachExternalOtherFileMutex.RLock()
+	defer fake.attachExternalOtherFileMutex.RUnlock()
+	return len(fake.attachExternalOtherFileArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) AttachExternalOtherFileCalls(stub func(string, bool, *models.Product, *models.Version) (*models.Product, error)) {
+	fake.attachExternalOtherFileMutex.Lock()
+	defer fake.attachExternalOtherFileMutex.Unlock()
+	fake.AttachExternalOtherFileStub = stub
+}
+
+func (fake *FakeMarketplaceInterface) AttachExternalOtherFileArgsForCall(i int) (string, bool, *models.Product, *models.Version) {
+	fake.attachExternalOtherFileMutex.RLock()
+	defer fake.attachExternalOtherFileMutex.RUnlock()
+	argsForCall := fake.attachExternalOtherFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeMarketplaceInterface) AttachExternalOtherFileReturns(result1 *models.Product, result2 error) {
+	fake.attachExternalOtherFileMutex.Lock()
+	defer fake.attachExternalOtherFileMutex.Unlock()
+	fake.AttachExternalOtherFileStub = nil
+	fake.attachExternalOtherFileReturns = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) AttachExternalOtherFileReturnsOnCall(i int, result1 *models.Product, result2 error) {
+	fake.attachExternalOtherFileMutex.Lock()
+	defer fake.attachExternalOtherFileMutex.Unlock()
+	fake.AttachExternalOtherFileStub = nil
+	if fake.attachExternalOtherFileReturnsOnCall == nil {
+		fake.attachExternalOtherFileReturnsOnCall = make(map[int]struct {
+			result1 *models.Product
+			result2 error
+		})
+	}
+	fake.attachExternalOtherFileReturnsOnCall[i] = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) AttachExternalVM(arg1 string, arg2 bool, arg3 *models.Product, arg4 *models.Version) (*models.Product, error) {
+	fake.attachExternalVMMutex.Lock()
+	ret, specificReturn := fake.attachExternalVMReturnsOnCall[len(fake.attachExternalVMArgsForCall)]
+	fake.attachExternalVMArgsForCall = append(fake.attachExternalVMArgsForCall, struct {
+		arg1 string
+		arg2 bool
+		arg3 *models.Product
+		arg4 *models.Version
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AttachExternalVMStub
+	fakeReturns := fake.attachExternalVMReturns
+	fake.recordInvocation("AttachExternalVM", []interface{}{arg1, arg2, arg3, arg4})
+	fake.attachExternalVMMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMarketplaceInterface) AttachExternalVMCallCount() int {
+	fake.attachExternalVMMutex.RLock()
+	defer fake.attachExternalVMMutex.RUnlock()
+	return len(fake.attachExternalVMArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) AttachExternalVMCalls(stub func(string, bool, *models.Product, *models.Version) (*models.Product, error)) {
+	fake.attachExternalVMMutex.Lock()
+	defer fake.attachExternalVMMutex.Unlock()
+	fake.AttachExternalVMStub = stub
+}
+
+func (fake *FakeMarketplaceInterface) AttachExternalVMArgsForCall(i int) (string, bool, *models.Product, *models.Version) {
+	fake.attachExternalVMMutex.RLock()
+	defer fake.attachExternalVMMutex.RUnlock()
+	argsForCall := fake.attachExternalVMArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeMarketplaceInterface) AttachExternalVMReturns(result1 *models.Product, result2 error) {
+	fake.attachExternalVMMutex.Lock()
+	defer fake.attachExternalVMMutex.Unlock()
+	fake.AttachExternalVMStub = nil
+	fake.attachExternalVMReturns = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) AttachExternalVMReturnsOnCall(i int, result1 *models.Product, result2 error) {
+	fake.attachExternalVMMutex.Lock()
+	defer fake.attachExternalVMMutex.Unlock()
+	fake.AttachExternalVMStub = nil
+	if fake.attachExternalVMReturnsOnCall == nil {
+		fake.attachExternalVMReturnsOnCall = make(map[int]struct {
+			result1 *models.Product
+			result2 error
+		})
+	}
+	fake.attachExternalVMReturnsOnCall[i] = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeMarketplaceInterface) AttachLocalChart(arg1 string, arg2 string, arg3 *models.Product, arg4 *models.Version) (*models.Product, error) {
 	fake.attachLocalChartMutex.Lock()
 	ret, specificReturn := fake.attachLocalChartReturnsOnCall[len(fake.attachLocalChartArgsForCall)]
@@ -482,6 +982,73 @@ func (fake *FakeMarketplaceInterface) AttachMetaFileReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeMarketplaceInterface) AttachOCIChart(arg1 *url.URL, arg2 string, arg3 *models.Product, arg4 *models.Version) (*models.Product, error) {
+	fake.attachOCIChartMutex.Lock()
+	ret, specificReturn := fake.attachOCIChartReturnsOnCall[len(fake.attachOCIChartArgsForCall)]
+	fake.attachOCIChartArgsForCall = append(fake.attachOCIChartArgsForCall, struct {
+		arg1 *url.URL
+		arg2 string
+		arg3 *models.Product
+		arg4 *models.Version
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AttachOCIChartStub
+	fakeReturns := fake.attachOCIChartReturns
+	fake.recordInvocation("AttachOCIChart", []interface{}{arg1, arg2, arg3, arg4})
+	fake.attachOCIChartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMarketplaceInterface) AttachOCIChartCallCount() int {
+	fake.attachOCIChartMutex.RLock()
+	defer fake.attachOCIChartMutex.RUnlock()
+	return len(fake.attachOCIChartArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) AttachOCIChartCalls(stub func(*url.URL, string, *models.Product, *models.Version) (*models.Product, error)) {
+	fake.attachOCIChartMutex.Lock()
+	defer fake.attachOCIChartMutex.Unlock()
+	fake.AttachOCIChartStub = stub
+}
+
+func (fake *FakeMarketplaceInterface) AttachOCIChartArgsForCall(i int) (*url.URL, string, *models.Product, *models.Version) {
+	fake.attachOCIChartMutex.RLock()
+	defer fake.attachOCIChartMutex.RUnlock()
+	argsForCall := fake.attachOCIChartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeMarketplaceInterface) AttachOCIChartReturns(result1 *models.Product, result2 error) {
+	fake.attachOCIChartMutex.Lock()
+	defer fake.attachOCIChartMutex.Unlock()
+	fake.AttachOCIChartStub = nil
+	fake.attachOCIChartReturns = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) AttachOCIChartReturnsOnCall(i int, result1 *models.Product, result2 error) {
+	fake.attachOCIChartMutex.Lock()
+	defer fake.attachOCIChartMutex.Unlock()
+	fake.AttachOCIChartStub = nil
+	if fake.attachOCIChartReturnsOnCall == nil {
+		fake.attachOCIChartReturnsOnCall = make(map[int]struct {
+			result1 *models.Product
+			result2 error
+		})
+	}
+	fake.attachOCIChartReturnsOnCall[i] = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeMarketplaceInterface) AttachOtherFile(arg1 string, arg2 *models.Product, arg3 *models.Version) (*models.Product, error) {
 	fake.attachOtherFileMutex.Lock()
 	ret, specificReturn := fake.attachOtherFileReturnsOnCall[len(fake.attachOtherFileArgsForCall)]
@@ -684,6 +1251,70 @@ func (fake *FakeMarketplaceInterface) AttachPublicContainerImageReturnsOnCall(i
 	}{result1, result2}
 }
 
+func (fake *FakeMarketplaceInterface) CreateProduct(arg1 *models.Product) (*models.Product, error) {
+	fake.createProductMutex.Lock()
+	ret, specificReturn := fake.createProductReturnsOnCall[len(fake.createProductArgsForCall)]
+	fake.createProductArgsForCall = append(fake.createProductArgsForCall, struct {
+		arg1 *models.Product
+	}{arg1})
+	stub := fake.CreateProductStub
+	fakeReturns := fake.createProductReturns
+	fake.recordInvocation("CreateProduct", []interface{}{arg1})
+	fake.createProductMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMarketplaceInterface) CreateProductCallCount() int {
+	fake.createProductMutex.RLock()
+	defer fake.createProductMutex.RUnlock()
+	return len(fake.createProductArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) CreateProductCalls(stub func(*models.Product) (*models.Product, error)) {
+	fake.createProductMutex.Lock()
+	defer fake.createProductMutex.Unlock()
+	fake.CreateProductStub = stub
+}
+
+func (fake *FakeMarketplaceInterface) CreateProductArgsForCall(i int) *models.Product {
+	fake.createProductMutex.RLock()
+	defer fake.createProductMutex.RUnlock()
+	argsForCall := fake.createProductArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMarketplaceInterface) CreateProductReturns(result1 *models.Product, result2 error) {
+	fake.createProductMutex.Lock()
+	defer fake.createProductMutex.Unlock()
+	fake.CreateProductStub = nil
+	fake.createProductReturns = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) CreateProductReturnsOnCall(i int, result1 *models.Product, result2 error) {
+	fake.createProductMutex.Lock()
+	defer fake.createProductMutex.Unlock()
+	fake.CreateProductStub = nil
+	if fake.createProductReturnsOnCall == nil {
+		fake.createProductReturnsOnCall = make(map[int]struct {
+			result1 *models.Product
+			result2 error
+		})
+	}
+	fake.createProductReturnsOnCall[i] = struct {
+		result1 *models.Product
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeMarketplaceInterface) DecodeJson(arg1 io.Reader, arg2 interface{}) error {
 	fake.decodeJsonMutex.Lock()
 	ret, specificReturn := fake.decodeJsonReturnsOnCall[len(fake.decodeJsonArgsForCall)]
@@ -746,19 +1377,68 @@ func (fake *FakeMarketplaceInterface) DecodeJsonReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
-func (fake *FakeMarketplaceInterface) Download(arg1 string, arg2 *pkg.DownloadRequestPayload) error {
+func (fake *FakeMarketplaceInterface) DisableProgressBars() {
+	fake.disableProgressBarsMutex.Lock()
+	fake.disableProgressBarsArgsForCall = append(fake.disableProgressBarsArgsForCall, struct {
+	}{})
+	stub := fake.DisableProgressBarsStub
+	fake.recordInvocation("DisableProgressBars", []interface{}{})
+	fake.disableProgressBarsMutex.Unlock()
+	if stub != nil {
+		fake.DisableProgressBarsStub()
+	}
+}
+
+func (fake *FakeMarketplaceInterface) DisableProgressBarsCallCount() int {
+	fake.disableProgressBarsMutex.RLock()
+	defer fake.disableProgressBarsMutex.RUnlock()
+	return len(fake.disableProgressBarsArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) DisableProgressBarsCalls(stub func()) {
+	fake.disableProgressBarsMutex.Lock()
+	defer fake.disableProgressBarsMutex.Unlock()
+	fake.DisableProgressBarsStub = stub
+}
+
+func (fake *FakeMarketplaceInterface) DisableRegistryVerification() {
+	fake.disableRegistryVerificationMutex.Lock()
+	fake.disableRegistryVerificationArgsForCall = append(fake.disableRegistryVerificationArgsForCall, struct {
+	}{})
+	stub := fake.DisableRegistryVerificationStub
+	fake.recordInvocation("DisableRegistryVerification", []interface{}{})
+	fake.disableRegistryVerificationMutex.Unlock()
+	if stub != nil {
+		fake.DisableRegistryVerificationStub()
+	}
+}
+
+func (fake *FakeMarketplaceInterface) DisableRegistryVerificationCallCount() int {
+	fake.disableRegistryVerificationMutex.RLock()
+	defer fake.disableRegistryVerificationMutex.RUnlock()
+	return len(fake.disableRegistryVerificationArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) DisableRegistryVerificationCalls(stub func()) {
+	fake.disableRegistryVerificationMutex.Lock()
+	defer fake.disableRegistryVerificationMutex.Unlock()
+	fake.DisableRegistryVerificationStub = stub
+}
+
+func (fake *FakeMarketplaceInterface) Download(arg1 string, arg2 *pkg.Asset, arg3 bool) error {
 	fake.downloadMutex.Lock()
 	ret, specificReturn := fake.downloadReturnsOnCall[len(fake.downloadArgsForCall)]
 	fake.downloadArgsForCall = append(fake.downloadArgsForCall, struct {
 		arg1 string
-		arg2 *pkg.DownloadRequestPayload
-	}{arg1, arg2})
+		arg2 *pkg.Asset
+		arg3 bool
+	}{arg1, arg2, arg3})
 	stub := fake.DownloadStub
 	fakeReturns := fake.downloadReturns
-	fake.recordInvocation("Download", []interface{}{arg1, arg2})
+	fake.recordInvocation("Download", []interface{}{arg1, arg2, arg3})
 	fake.downloadMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -772,17 +1452,17 @@ func (fake *FakeMarketplaceInterface) DownloadCallCount() int {
 	return len(fake.downloadArgsForCall)
 }
 
-func (fake *FakeMarketplaceInterface) DownloadCalls(stub func(string, *pkg.DownloadRequestPayload) error) {
+func (fake *FakeMarketplaceInterface) DownloadCalls(stub func(string, *pkg.Asset, bool) error) {
 	fake.downloadMutex.Lock()
 	defer fake.downloadMutex.Unlock()
 	fake.DownloadStub = stub
 }
 
-func (fake *FakeMarketplaceInterface) DownloadArgsForCall(i int) (string, *pkg.DownloadRequestPayload) {
+func (fake *FakeMarketplaceInterface) DownloadArgsForCall(i int) (string, *pkg.Asset, bool) {
 	fake.downloadMutex.RLock()
 	defer fake.downloadMutex.RUnlock()
 	argsForCall := fake.downloadArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeMarketplaceInterface) DownloadReturns(result1 error) {
@@ -872,6 +1552,54 @@ func (fake *FakeMarketplaceInterface) DownloadChartReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeMarketplaceInterface) EnableDryRun() {
+	fake.enableDryRunMutex.Lock()
+	fake.enableDryRunArgsForCall = append(fake.enableDryRunArgsForCall, struct {
+	}{})
+	stub := fake.EnableDryRunStub
+	fake.recordInvocation("EnableDryRun", []interface{}{})
+	fake.enableDryRunMutex.Unlock()
+	if stub != nil {
+		fake.EnableDryRunStub()
+	}
+}
+
+func (fake *FakeMarketplaceInterface) EnableDryRunCallCount() int {
+	fake.enableDryRunMutex.RLock()
+	defer fake.enableDryRunMutex.RUnlock()
+	return len(fake.enableDryRunArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) EnableDryRunCalls(stub func()) {
+	fake.enableDryRunMutex.Lock()
+	defer fake.enableDryRunMutex.Unlock()
+	fake.EnableDryRunStub = stub
+}
+
+func (fake *FakeMarketplaceInterface) EnableMultipleProgressBars() {
+	fake.enableMultipleProgressBarsMutex.Lock()
+	fake.enableMultipleProgressBarsArgsForCall = append(fake.enableMultipleProgressBarsArgsForCall, struct {
+	}{})
+	stub := fake.EnableMultipleProgressBarsStub
+	fake.recordInvocation("EnableMultipleProgressBars", []interface{}{})
+	fake.enableMultipleProgressBarsMutex.Unlock()
+	if stub != nil {
+		fake.EnableMultipleProgressBarsStub()
+	}
+}
+
+func (fake *FakeMarketplaceInterface) EnableMultipleProgressBarsCallCount() int {
+	fake.enableMultipleProgressBarsMutex.RLock()
+	defer fake.enableMultipleProgressBarsMutex.RUnlock()
+	return len(fake.enableMultipleProgressBarsArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) EnableMultipleProgressBarsCalls(stub func()) {
+	fake.enableMultipleProgressBarsMutex.Lock()
+	defer fake.enableMultipleProgressBarsMutex.Unlock()
+	fake.EnableMultipleProgressBarsStub = stub
+}
+
 func (fake *FakeMarketplaceInterface) EnableStrictDecoding() {
 	fake.enableStrictDecodingMutex.Lock()
 	fake.enableStrictDecodingArgsForCall = append(fake.enableStrictDecodingArgsForCall, struct {
@@ -896,6 +1624,70 @@ func (fake *FakeMarketplaceInterface) EnableStrictDecodingCalls(stub func()) {
 	fake.EnableStrictDecodingStub = stub
 }
 
+func (fake *FakeMarketplaceInterface) ExportProduct(arg1 string) (*pkg.ProductExport, error) {
+	fake.exportProductMutex.Lock()
+	ret, specificReturn := fake.exportProductReturnsOnCall[len(fake.exportProductArgsForCall)]
+	fake.exportProductArgsForCall = append(fake.exportProductArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ExportProductStub
+	fakeReturns := fake.exportProductReturns
+	fake.recordInvocation("ExportProduct", []interface{}{arg1})
+	fake.exportProductMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMarketplaceInterface) ExportProductCallCount() int {
+	fake.exportProductMutex.RLock()
+	defer fake.exportProductMutex.RUnlock()
+	return len(fake.exportProductArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) ExportProductCalls(stub func(string) (*pkg.ProductExport, error)) {
+	fake.exportProductMutex.Lock()
+	defer fake.exportProductMutex.Unlock()
+	fake.ExportProductStub = stub
+}
+
+func (fake *FakeMarketplaceInterface) ExportProductArgsForCall(i int) string {
+	fake.exportProductMutex.RLock()
+	defer fake.exportProductMutex.RUnlock()
+	argsForCall := fake.exportProductArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMarketplaceInterface) ExportProductReturns(result1 *pkg.ProductExport, result2 error) {
+	fake.exportProductMutex.Lock()
+	defer fake.exportProductMutex.Unlock()
+	fake.ExportProductStub = nil
+	fake.exportProductReturns = struct {
+		result1 *pkg.ProductExport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) ExportProductReturnsOnCall(i int, result1 *pkg.ProductExport, result2 error) {
+	fake.exportProductMutex.Lock()
+	defer fake.exportProductMutex.Unlock()
+	fake.ExportProductStub = nil
+	if fake.exportProductReturnsOnCall == nil {
+		fake.exportProductReturnsOnCall = make(map[int]struct {
+			result1 *pkg.ProductExport
+			result2 error
+		})
+	}
+	fake.exportProductReturnsOnCall[i] = struct {
+		result1 *pkg.ProductExport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeMarketplaceInterface) GetAPIHost() string {
 	fake.getAPIHostMutex.Lock()
 	ret, specificReturn := fake.getAPIHostReturnsOnCall[len(fake.getAPIHostArgsForCall)]
@@ -1315,6 +2107,70 @@ func (fake *FakeMarketplaceInterface) ListProductsReturnsOnCall(i int, result1 [
 	}{result1, result2}
 }
 
+func (fake *FakeMarketplaceInterface) PullChart(arg1 *url.URL) (string, error) {
+	fake.pullChartMutex.Lock()
+	ret, specificReturn := fake.pullChartReturnsOnCall[len(fake.pullChartArgsForCall)]
+	fake.pullChartArgsForCall = append(fake.pullChartArgsForCall, struct {
+		arg1 *url.URL
+	}{arg1})
+	stub := fake.PullChartStub
+	fakeReturns := fake.pullChartReturns
+	fake.recordInvocation("PullChart", []interface{}{arg1})
+	fake.pullChartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMarketplaceInterface) PullChartCallCount() int {
+	fake.pullChartMutex.RLock()
+	defer fake.pullChartMutex.RUnlock()
+	return len(fake.pullChartArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) PullChartCalls(stub func(*url.URL) (string, error)) {
+	fake.pullChartMutex.Lock()
+	defer fake.pullChartMutex.Unlock()
+	fake.PullChartStub = stub
+}
+
+func (fake *FakeMarketplaceInterface) PullChartArgsForCall(i int) *url.URL {
+	fake.pullChartMutex.RLock()
+	defer fake.pullChartMutex.RUnlock()
+	argsForCall := fake.pullChartArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeMarketplaceInterface) PullChartReturns(result1 string, result2 error) {
+	fake.pullChartMutex.Lock()
+	defer fake.pullChartMutex.Unlock()
+	fake.PullChartStub = nil
+	fake.pullChartReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) PullChartReturnsOnCall(i int, result1 string, result2 error) {
+	fake.pullChartMutex.Lock()
+	defer fake.pullChartMutex.Unlock()
+	fake.PullChartStub = nil
+	if fake.pullChartReturnsOnCall == nil {
+		fake.pullChartReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.pullChartReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeMarketplaceInterface) PutProduct(arg1 *models.Product, arg2 bool) (*models.Product, error) {
 	fake.putProductMutex.Lock()
 	ret, specificReturn := fake.putProductReturnsOnCall[len(fake.putProductArgsForCall)]
@@ -1478,29 +2334,118 @@ func (fake *FakeMarketplaceInterface) UploadVMReturnsOnCall(i int, result1 *mode
 	}{result1, result2}
 }
 
+func (fake *FakeMarketplaceInterface) ValidateChart(arg1 *url.URL, arg2 string) ([]string, error) {
+	fake.validateChartMutex.Lock()
+	ret, specificReturn := fake.validateChartReturnsOnCall[len(fake.validateChartArgsForCall)]
+	fake.validateChartArgsForCall = append(fake.validateChartArgsForCall, struct {
+		arg1 *url.URL
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ValidateChartStub
+	fakeReturns := fake.validateChartReturns
+	fake.recordInvocation("ValidateChart", []interface{}{arg1, arg2})
+	fake.validateChartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeMarketplaceInterface) ValidateChartCallCount() int {
+	fake.validateChartMutex.RLock()
+	defer fake.validateChartMutex.RUnlock()
+	return len(fake.validateChartArgsForCall)
+}
+
+func (fake *FakeMarketplaceInterface) ValidateChartCalls(stub func(*url.URL, string) ([]string, error)) {
+	fake.validateChartMutex.Lock()
+	defer fake.validateChartMutex.Unlock()
+	fake.ValidateChartStub = stub
+}
+
+func (fake *FakeMarketplaceInterface) ValidateChartArgsForCall(i int) (*url.URL, string) {
+	fake.validateChartMutex.RLock()
+	defer fake.validateChartMutex.RUnlock()
+	argsForCall := fake.validateChartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeMarketplaceInterface) ValidateChartReturns(result1 []string, result2 error) {
+	fake.validateChartMutex.Lock()
+	defer fake.validateChartMutex.Unlock()
+	fake.ValidateChartStub = nil
+	fake.validateChartReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMarketplaceInterface) ValidateChartReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.validateChartMutex.Lock()
+	defer fake.validateChartMutex.Unlock()
+	fake.ValidateChartStub = nil
+	if fake.validateChartReturnsOnCall == nil {
+		fake.validateChartReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.validateChartReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeMarketplaceInterface) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.archiveVersionMutex.RLock()
+	defer fake.archiveVersionMutex.RUnlock()
+	fake.attachBlueprintMutex.RLock()
+	defer fake.attachBlueprintMutex.RUnlock()
+	fake.attachContainerImagesMutex.RLock()
+	defer fake.attachContainerImagesMutex.RUnlock()
+	fake.attachExternalOtherFileMutex.RLock()
+	defer fake.attachExternalOtherFileMutex.RUnlock()
+	fake.attachExternalVMMutex.RLock()
+	defer fake.attachExternalVMMutex.RUnlock()
 	fake.attachLocalChartMutex.RLock()
 	defer fake.attachLocalChartMutex.RUnlock()
 	fake.attachLocalContainerImageMutex.RLock()
 	defer fake.attachLocalContainerImageMutex.RUnlock()
 	fake.attachMetaFileMutex.RLock()
 	defer fake.attachMetaFileMutex.RUnlock()
+	fake.attachOCIChartMutex.RLock()
+	defer fake.attachOCIChartMutex.RUnlock()
 	fake.attachOtherFileMutex.RLock()
 	defer fake.attachOtherFileMutex.RUnlock()
 	fake.attachPublicChartMutex.RLock()
 	defer fake.attachPublicChartMutex.RUnlock()
 	fake.attachPublicContainerImageMutex.RLock()
 	defer fake.attachPublicContainerImageMutex.RUnlock()
+	fake.createProductMutex.RLock()
+	defer fake.createProductMutex.RUnlock()
 	fake.decodeJsonMutex.RLock()
 	defer fake.decodeJsonMutex.RUnlock()
+	fake.disableProgressBarsMutex.RLock()
+	defer fake.disableProgressBarsMutex.RUnlock()
+	fake.disableRegistryVerificationMutex.RLock()
+	defer fake.disableRegistryVerificationMutex.RUnlock()
 	fake.downloadMutex.RLock()
 	defer fake.downloadMutex.RUnlock()
 	fake.downloadChartMutex.RLock()
 	defer fake.downloadChartMutex.RUnlock()
+	fake.enableDryRunMutex.RLock()
+	defer fake.enableDryRunMutex.RUnlock()
+	fake.enableMultipleProgressBarsMutex.RLock()
+	defer fake.enableMultipleProgressBarsMutex.RUnlock()
 	fake.enableStrictDecodingMutex.RLock()
 	defer fake.enableStrictDecodingMutex.RUnlock()
+	fake.exportProductMutex.RLock()
+	defer fake.exportProductMutex.RUnlock()
 	fake.getAPIHostMutex.RLock()
 	defer fake.getAPIHostMutex.RUnlock()
 	fake.getHostMutex.RLock()
@@ -1515,12 +2460,16 @@ func (fake *FakeMarketplaceInterface) Invocations() map[string][][]interface{} {
 	defer fake.getUploaderMutex.RUnlock()
 	fake.listProductsMutex.RLock()
 	defer fake.listProductsMutex.RUnlock()
+	fake.pullChartMutex.RLock()
+	defer fake.pullChartMutex.RUnlock()
 	fake.putProductMutex.RLock()
 	defer fake.putProductMutex.RUnlock()
 	fake.setUploaderMutex.RLock()
 	defer fake.setUploaderMutex.RUnlock()
 	fake.uploadVMMutex.RLock()
 	defer fake.uploadVMMutex.RUnlock()
+	fake.validateChartMutex.RLock()
+	defer fake.validateChartMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -6,6 +6,7 @@ package pkg
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -24,6 +25,16 @@ type VersionDoesNotExistError struct {
 	Version string
 }
 
+// DryRunError is returned by PutProduct in dry-run mode, instead of sending the update
+type DryRunError struct {
+	Product *models.Product
+	Changes []*FieldChange
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("dry run: product \"%s\" was not updated", e.Product.Slug)
+}
+
 func (e *VersionDoesNotExistError) Error() string {
 	return fmt.Sprintf("product \"%s\" does not have version %s", e.Product, e.Version)
 }
@@ -276,6 +287,14 @@ func (m *Marketplace) GetProductWithVersion(slug, version string) (*models.Produ
 }
 
 func (m *Marketplace) PutProduct(product *models.Product, versionUpdate bool) (*models.Product, error) {
+	return m.putProduct(product, versionUpdate, false)
+}
+
+func (m *Marketplace) putProduct(product *models.Product, versionUpdate, archivePreviousVersion bool) (*models.Product, error) {
+	if m.dryRun {
+		return nil, m.dryRunPutProduct(product)
+	}
+
 	encoded, err := json.Marshal(product)
 	if err != nil {
 		return nil, err
@@ -285,7 +304,7 @@ func (m *Marketplace) PutProduct(product *models.Product, versionUpdate bool) (*
 		m.GetHost(),
 		fmt.Sprintf("/api/v1/products/%s", product.ProductId),
 		url.Values{
-			"archivepreviousversion": []string{"false"},
+			"archivepreviousversion": []string{strconv.FormatBool(archivePreviousVersion)},
 			"isversionupdate":        []string{strconv.FormatBool(versionUpdate)},
 		},
 	)
@@ -313,3 +332,102 @@ func (m *Marketplace) PutProduct(product *models.Product, versionUpdate bool) (*
 	}
 	return response.Response.Data, nil
 }
+
+// ArchiveVersion archives the given version. The Marketplace archives versions with the archivepreviousversion
+// parameter, which archives the current version when the update makes a different version current. So the version is
+// made current first, if it is not already, and then the version that should stay current is set with the parameter.
+// If the version being archived is the current version, the latest remaining version becomes current.
+func (m *Marketplace) ArchiveVersion(product *models.Product, version *models.Version) (*models.Product, error) {
+	nextVersion := product.CurrentVersion
+	if nextVersion == version.Number || !product.HasVersion(nextVersion) {
+		latest := latestOtherVersion(product, version.Number)
+		if latest == nil {
+			return nil, fmt.Errorf("cannot archive version %s, because it is the only version of %s", version.Number, product.Slug)
+		}
+		nextVersion = latest.Number
+	}
+
+	if m.dryRun {
+		changes := []*FieldChange{{Field: "archivedversion", New: version.Number}}
+		if nextVersion != product.CurrentVersion {
+			changes = append(changes, &FieldChange{Field: "currentversion", Old: product.CurrentVersion, New: nextVersion})
+		}
+		return nil, &DryRunError{Product: product, Changes: changes}
+	}
+
+	if product.CurrentVersion != version.Number {
+		product.CurrentVersion = version.Number
+		_, err := m.putProduct(product, false, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	product.CurrentVersion = nextVersion
+	return m.putProduct(product, false, true)
+}
+
+// latestOtherVersion returns the latest version of the product, other than the given version
+func latestOtherVersion(product *models.Product, excluded string) *models.Version {
+	var latest *models.Version
+	for _, v := range product.AllVersions {
+		if v.Number != excluded && (latest == nil || latest.LessThan(*v)) {
+			latest = v
+		}
+	}
+	return latest
+}
+
+func (m *Marketplace) CreateProduct(product *models.Product) (*models.Product, error) {
+	if m.dryRun {
+		changes, err := DiffProducts(&models.Product{}, product)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &DryRunError{
+			Product: product,
+			Changes: changes,
+		}
+	}
+
+	requestURL := MakeURL(m.GetHost(), "/api/v1/products", nil)
+	resp, err := m.Client.PostJSON(requestURL, product)
+	if err != nil {
+		return nil, fmt.Errorf("sending the request to create product \"%s\" failed: %w", product.DisplayName, err)
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("you do not have permission to create products in organization %s", product.PublisherDetails.OrgId)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			body = []byte{}
+		}
+		return nil, fmt.Errorf("creating product \"%s\" failed: (%d)\n%s", product.DisplayName, resp.StatusCode, body)
+	}
+
+	response := &GetProductResponse{}
+	err = m.DecodeJson(resp.Body, response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the response for product \"%s\": %w", product.DisplayName, err)
+	}
+	return response.Response.Data, nil
+}
+
+// dryRunPutProduct compares the update payload with the product as it is currently in the Marketplace
+func (m *Marketplace) dryRunPutProduct(product *models.Product) error {
+	original, _, err := m.GetProductWithVersion(product.Slug, product.CurrentVersion)
+	if err != nil && !errors.Is(err, &VersionDoesNotExistError{}) {
+		return err
+	}
+
+	changes, err := DiffProducts(original, product)
+	if err != nil {
+		return err
+	}
+	return &DryRunError{
+		Product: product,
+		Changes: changes,
+	}
+}
@@ -5,10 +5,12 @@ package pkg_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	. "github.com/onsi/ginkgo"
@@ -455,4 +457,214 @@ var _ = Describe("Product", func() {
 			})
 		})
 	})
+
+	Describe("PutProduct", func() {
+		var product *models.Product
+
+		BeforeEach(func() {
+			product = test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeImage)
+			test.AddVersions(product, "1.2.3")
+			response := &pkg.GetProductResponse{
+				Response: &pkg.GetProductResponsePayload{
+					Data:       product,
+					StatusCode: http.StatusOK,
+					Message:    "testing",
+				},
+			}
+			httpClient.GetReturns(test.MakeJSONResponse(response), nil)
+			httpClient.PostJSONReturns(&http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader(""))}, nil)
+		})
+
+		Context("dry run mode", func() {
+			BeforeEach(func() {
+				marketplace.EnableDryRun()
+			})
+
+			It("returns the changes instead of updating the product", func() {
+				updatedProduct := test.CreateFakeProduct(product.ProductId, "My Renamed Product", "my-super-product", models.SolutionTypeImage)
+				test.AddVersions(updatedProduct, "1.2.3")
+				updatedProduct.CurrentVersion = "1.2.3"
+				updatedProduct.LatestVersion = "1.2.3"
+				updatedProduct.PublisherDetails = product.PublisherDetails
+
+				_, err := marketplace.PutProduct(updatedProduct, false)
+				Expect(err).To(HaveOccurred())
+
+				var dryRun *pkg.DryRunError
+				Expect(errors.As(err, &dryRun)).To(BeTrue())
+				Expect(err.Error()).To(Equal("dry run: product \"my-super-product\" was not updated"))
+				Expect(dryRun.Product).To(Equal(updatedProduct))
+				Expect(dryRun.Changes).To(ConsistOf(&pkg.FieldChange{
+					Field: "displayname",
+					Old:   "My Super Product",
+					New:   "My Renamed Product",
+				}))
+
+				By("getting the current product, but not updating it", func() {
+					Expect(httpClient.GetCallCount()).To(Equal(1))
+					Expect(httpClient.GetArgsForCall(0).Path).To(Equal("/api/v1/products/my-super-product"))
+					Expect(httpClient.PutCallCount()).To(Equal(0))
+				})
+			})
+		})
+	})
+
+	Describe("CreateProduct", func() {
+		var product *models.Product
+
+		BeforeEach(func() {
+			product = test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeChart)
+			createdProduct := test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeChart)
+			response := &pkg.GetProductResponse{
+				Response: &pkg.GetProductResponsePayload{
+					Data:       createdProduct,
+					StatusCode: http.StatusCreated,
+					Message:    "testing",
+				},
+			}
+			httpClient.PostJSONReturns(test.MakeJSONResponse(response), nil)
+		})
+
+		It("creates the product", func() {
+			createdProduct, err := marketplace.CreateProduct(product)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(createdProduct.ProductId).ToNot(BeEmpty())
+			Expect(createdProduct.Slug).To(Equal("my-super-product"))
+
+			Expect(httpClient.PostJSONCallCount()).To(Equal(1))
+			url, payload := httpClient.PostJSONArgsForCall(0)
+			Expect(url.Path).To(Equal("/api/v1/products"))
+			Expect(payload).To(Equal(product))
+		})
+
+		Context("Error sending the request", func() {
+			BeforeEach(func() {
+				httpClient.PostJSONReturns(nil, errors.New("post failed"))
+			})
+
+			It("returns an error", func() {
+				_, err := marketplace.CreateProduct(product)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("sending the request to create product \"My Super Product\" failed: post failed"))
+			})
+		})
+
+		Context("Unexpected status code", func() {
+			BeforeEach(func() {
+				httpClient.PostJSONReturns(&http.Response{
+					StatusCode: http.StatusTeapot,
+					Body:       io.NopCloser(strings.NewReader("Teapots all the way down")),
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := marketplace.CreateProduct(product)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("creating product \"My Super Product\" failed: (418)\nTeapots all the way down"))
+			})
+		})
+
+		Context("dry run mode", func() {
+			BeforeEach(func() {
+				marketplace.EnableDryRun()
+			})
+
+			It("returns the new product fields instead of creating it", func() {
+				_, err := marketplace.CreateProduct(product)
+				var dryRun *pkg.DryRunError
+				Expect(errors.As(err, &dryRun)).To(BeTrue())
+				Expect(dryRun.Changes).To(ContainElement(&pkg.FieldChange{
+					Field: "displayname",
+					Old:   "",
+					New:   "My Super Product",
+				}))
+				Expect(httpClient.PostJSONCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("ArchiveVersion", func() {
+		var (
+			product    *models.Product
+			sentBodies [][]byte
+		)
+
+		BeforeEach(func() {
+			sentBodies = [][]byte{}
+			product = test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeChart)
+			test.AddVersions(product, "1.0.0", "2.0.0", "3.0.0")
+			product.CurrentVersion = "2.0.0"
+			httpClient.PutStub = func(requestURL *url.URL, content io.Reader, contentType string) (*http.Response, error) {
+				body, err := io.ReadAll(content)
+				Expect(err).ToNot(HaveOccurred())
+				sentBodies = append(sentBodies, body)
+				return PutProductEchoResponse(requestURL, bytes.NewReader(body), contentType)
+			}
+		})
+
+		sentUpdate := func(index int) (*models.Product, string) {
+			requestURL, _, _ := httpClient.PutArgsForCall(index)
+			Expect(requestURL.Path).To(Equal(fmt.Sprintf("/api/v1/products/%s", product.ProductId)))
+			Expect(requestURL.Query().Get("isversionupdate")).To(Equal("false"))
+
+			sentProduct := &models.Product{}
+			Expect(json.Unmarshal(sentBodies[index], sentProduct)).To(Succeed())
+			return sentProduct, requestURL.Query().Get("archivepreviousversion")
+		}
+
+		It("makes the version current, and then puts back the current version with archivepreviousversion set", func() {
+			_, err := marketplace.ArchiveVersion(product, product.GetVersion("1.0.0"))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(httpClient.PutCallCount()).To(Equal(2))
+			sentProduct, archivePreviousVersion := sentUpdate(0)
+			Expect(sentProduct.CurrentVersion).To(Equal("1.0.0"))
+			Expect(archivePreviousVersion).To(Equal("false"))
+
+			sentProduct, archivePreviousVersion = sentUpdate(1)
+			Expect(sentProduct.CurrentVersion).To(Equal("2.0.0"))
+			Expect(archivePreviousVersion).To(Equal("true"))
+		})
+
+		When("archiving the current version", func() {
+			It("makes the latest remaining version current with archivepreviousversion set", func() {
+				_, err := marketplace.ArchiveVersion(product, product.GetVersion("2.0.0"))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(httpClient.PutCallCount()).To(Equal(1))
+				sentProduct, archivePreviousVersion := sentUpdate(0)
+				Expect(sentProduct.CurrentVersion).To(Equal("3.0.0"))
+				Expect(archivePreviousVersion).To(Equal("true"))
+			})
+		})
+
+		When("archiving the only version", func() {
+			It("returns an error", func() {
+				product.AllVersions = product.AllVersions[1:2]
+				_, err := marketplace.ArchiveVersion(product, product.GetVersion("2.0.0"))
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("cannot archive version 2.0.0, because it is the only version of my-super-product"))
+				Expect(httpClient.PutCallCount()).To(Equal(0))
+			})
+		})
+
+		When("in dry run mode", func() {
+			BeforeEach(func() {
+				marketplace.EnableDryRun()
+			})
+
+			It("returns the version that would be archived, without updating the product", func() {
+				_, err := marketplace.ArchiveVersion(product, product.GetVersion("2.0.0"))
+				Expect(err).To(HaveOccurred())
+
+				var dryRun *pkg.DryRunError
+				Expect(errors.As(err, &dryRun)).To(BeTrue())
+				Expect(dryRun.Changes).To(Equal([]*pkg.FieldChange{
+					{Field: "archivedversion", New: "2.0.0"},
+					{Field: "currentversion", Old: "2.0.0", New: "3.0.0"},
+				}))
+				Expect(httpClient.PutCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
@@ -0,0 +1,304 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package pkg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/docker/cli/cli/config"
+)
+
+const (
+	DockerHubRegistry    = "docker.io"
+	DockerHubAPIHost     = "registry-1.docker.io"
+	DockerHubIndexServer = "https://index.docker.io/v1/"
+
+	MediaTypeOCIImageManifest    = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeDockerManifest      = "application/vnd.docker.distribution.manifest.v2+json"
+	registryManifestAcceptHeader = MediaTypeOCIImageIndex + ", " + MediaTypeDockerManifestList + ", " + MediaTypeOCIImageManifest + ", " + MediaTypeDockerManifest
+)
+
+// RegistryImage is the manifest of an image in a container registry
+type RegistryImage struct {
+	Digest      string
+	Size        int64
+	IsMultiArch bool
+}
+
+//go:generate counterfeiter . RegistryAuthFunc
+type RegistryAuthFunc func(registry string) (username, password string, err error)
+
+// RegistryAuth returns the credentials for a registry. By default, they are read from the Docker config.
+var RegistryAuth RegistryAuthFunc = dockerConfigAuth
+
+func dockerConfigAuth(registry string) (string, string, error) {
+	dockerConfig, err := config.Load(config.Dir())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read the Docker config: %w", err)
+	}
+
+	if registry == DockerHubRegistry {
+		registry = DockerHubIndexServer
+	}
+	auth, err := dockerConfig.GetAuthConfig(registry)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get the credentials for %s from the Docker config: %w", registry, err)
+	}
+	return auth.Username, auth.Password, nil
+}
+
+type imageReference struct {
+	Registry   string
+	Repository string
+	Reference  string
+}
+
+func (r *imageReference) String() string {
+	return fmt.Sprintf("%s/%s:%s", r.Registry, r.Repository, r.Reference)
+}
+
+func parseImageReference(image, tag string) *imageReference {
+	ref := &imageReference{
+		Registry:   DockerHubRegistry,
+		Repository: image,
+		Reference:  tag,
+	}
+
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		ref.Registry = parts[0]
+		ref.Repository = parts[1]
+	}
+	if ref.Registry == "index.docker.io" {
+		ref.Registry = DockerHubRegistry
+	}
+	if ref.Registry == DockerHubRegistry && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+	return ref
+}
+
+func (r *imageReference) manifestURL(reference string) *url.URL {
+	host := r.Registry
+	scheme := "https"
+	if host == DockerHubRegistry {
+		host = DockerHubAPIHost
+	} else if strings.HasPrefix(host, "localhost") || strings.HasPrefix(host, "127.0.0.1") {
+		// Like Docker, allow local registries without TLS
+		scheme = "http"
+	}
+	return &url.URL{
+		Scheme: scheme,
+		Host:   host,
+		Path:   fmt.Sprintf("/v2/%s/manifests/%s", r.Repository, reference),
+	}
+}
+
+var challengeParamRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// registryAuthorization answers the WWW-Authenticate challenge from the registry, and returns the Authorization header to use
+func (m *Marketplace) registryAuthorization(ref *imageReference, challenge string) (string, error) {
+	username, password, err := RegistryAuth(ref.Registry)
+	if err != nil {
+		return "", err
+	}
+
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if strings.EqualFold(scheme, "basic") {
+		if username == "" {
+			return "", nil
+		}
+		req := &http.Request{Header: http.Header{}}
+		req.SetBasicAuth(username, password)
+		return req.Header.Get("Authorization"), nil
+	}
+	if !strings.EqualFold(scheme, "bearer") {
+		return "", fmt.Errorf("unsupported registry authentication scheme: %s", scheme)
+	}
+
+	values := map[string]string{}
+	for _, match := range challengeParamRegex.FindAllStringSubmatch(params, -1) {
+		values[match[1]] = match[2]
+	}
+	tokenURL, err := url.Parse(values["realm"])
+	if err != nil || values["realm"] == "" {
+		return "", fmt.Errorf("invalid registry authentication realm: \"%s\"", values["realm"])
+	}
+	query := tokenURL.Query()
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", ref.Repository))
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequest("GET", tokenURL.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to make request for registry token: %w", err)
+	}
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get registry token from %s: %w", tokenURL.Host, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", fmt.Errorf("not authorized to read image %s:%s from %s, please check the credentials in the Docker config (docker login %s)", ref.Repository, ref.Reference, ref.Registry, ref.Registry)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("failed to get registry token from %s: (%d)\n%s", tokenURL.Host, resp.StatusCode, body)
+	}
+
+	token := &struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(token)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse registry token: %w", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	return "Bearer " + token.Token, nil
+}
+
+func (m *Marketplace) sendRegistryManifestRequest(method string, ref *imageReference, reference, authorization string) (*http.Response, error) {
+	req, err := http.NewRequest(method, ref.manifestURL(reference).String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request for the manifest of %s: %w", ref, err)
+	}
+	req.Header.Set("Accept", registryManifestAcceptHeader)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the manifest for %s: %w", ref, err)
+	}
+	return resp, nil
+}
+
+// requestRegistryManifest requests a manifest by tag or digest, answering the authentication challenge if there is one.
+// Any response other than 200 OK is returned as an error.
+func (m *Marketplace) requestRegistryManifest(method string, ref *imageReference, reference string, authorization *string) (*http.Response, error) {
+	resp, err := m.sendRegistryManifestRequest(method, ref, reference, *authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized && *authorization == "" {
+		_ = resp.Body.Close()
+		*authorization, err = m.registryAuthorization(ref, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return nil, err
+		}
+		resp, err = m.sendRegistryManifestRequest(method, ref, reference, *authorization)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("image %s:%s was not found in %s", ref.Repository, reference, ref.Registry)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("not authorized to read image %s:%s from %s, please check the credentials in the Docker config (docker login %s)", ref.Repository, reference, ref.Registry, ref.Registry)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get the manifest for %s: (%d)\n%s", ref, resp.StatusCode, body)
+	}
+}
+
+// fetchRegistryManifest gets a manifest by tag or digest, and returns its digest and contents
+func (m *Marketplace) fetchRegistryManifest(ref *imageReference, reference string, authorization *string) (string, *ociManifest, error) {
+	resp, err := m.requestRegistryManifest(http.MethodGet, ref, reference, authorization)
+	if err != nil {
+		return "", nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read the manifest for %s: %w", ref, err)
+	}
+	manifest := &ociManifest{}
+	err = json.Unmarshal(body, manifest)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse the manifest for %s: %w", ref, err)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		hash := sha256.Sum256(body)
+		digest = "sha256:" + hex.EncodeToString(hash[:])
+	}
+	return digest, manifest, nil
+}
+
+// GetRegistryImage checks that the image exists in its registry with a HEAD request for the tag, and returns its digest.
+// The size is only recorded for single-platform images, which takes one more request for the manifest. Multi-arch
+// images would need a request for every platform, so their size is left out.
+func (m *Marketplace) GetRegistryImage(image, tag string) (*RegistryImage, error) {
+	ref := parseImageReference(image, tag)
+	authorization := ""
+	resp, err := m.requestRegistryManifest(http.MethodHead, ref, tag, &authorization)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+
+	mediaType, _, _ := strings.Cut(resp.Header.Get("Content-Type"), ";")
+	registryImage := &RegistryImage{
+		Digest:      resp.Header.Get("Docker-Content-Digest"),
+		IsMultiArch: mediaType == MediaTypeOCIImageIndex || mediaType == MediaTypeDockerManifestList,
+	}
+	if registryImage.IsMultiArch && registryImage.Digest != "" {
+		return registryImage, nil
+	}
+
+	// Fetch by digest when the registry gave one, so the manifest is the one that was checked
+	reference := tag
+	if registryImage.Digest != "" {
+		reference = registryImage.Digest
+	}
+	digest, manifest, err := m.fetchRegistryManifest(ref, reference, &authorization)
+	if err != nil {
+		return nil, err
+	}
+	registryImage.Digest = digest
+	registryImage.IsMultiArch = len(manifest.Manifests) > 0
+	if !registryImage.IsMultiArch {
+		registryImage.Size = manifestSize(manifest)
+	}
+	return registryImage, nil
+}
+
+func manifestSize(manifest *ociManifest) int64 {
+	var size int64
+	if manifest.Config != nil {
+		size += manifest.Config.Size
+	}
+	for _, layer := range manifest.Layers {
+		size += layer.Size
+	}
+	return size
+}
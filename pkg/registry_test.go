@@ -0,0 +1,173 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package pkg_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg/pkgfakes"
+)
+
+// fakeRegistry is a minimal registry v2 API, which requires a bearer token for every manifest request.
+// Anyone can get a token for the "public" namespace, but the "private" namespace needs the user's credentials,
+// and the "broken" namespace fails to give a token. The digest of a tag is "sha256:<tag>-digest".
+// Every manifest request is recorded in requests, as "METHOD reference".
+func fakeRegistry(manifests map[string]string, requests *[]string) *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		scope := r.URL.Query().Get("scope")
+		username, password, _ := r.BasicAuth()
+		if strings.HasPrefix(scope, "repository:broken/") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, "invalid token request")
+			return
+		}
+		if strings.HasPrefix(scope, "repository:private/") && (username != "user" || password != "secret") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"token": "%s"}`, scope)
+	})
+
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		repository, reference, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/v2/"), "/manifests/")
+		if r.Header.Get("Authorization") != fmt.Sprintf("Bearer repository:%s:pull", repository) {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake-registry"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		*requests = append(*requests, r.Method+" "+reference)
+
+		manifest, ok := manifests[repository+"@"+reference]
+		if !ok {
+			manifest, ok = manifests[repository+"@"+strings.TrimSuffix(strings.TrimPrefix(reference, "sha256:"), "-digest")]
+		}
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"errors": [{"code": "MANIFEST_UNKNOWN"}]}`)
+			return
+		}
+		if strings.HasPrefix(reference, "sha256:") {
+			w.Header().Set("Docker-Content-Digest", reference)
+		} else {
+			w.Header().Set("Docker-Content-Digest", "sha256:"+reference+"-digest")
+		}
+		if strings.Contains(manifest, `"manifests"`) {
+			w.Header().Set("Content-Type", pkg.MediaTypeOCIImageIndex)
+		} else {
+			w.Header().Set("Content-Type", pkg.MediaTypeOCIImageManifest)
+		}
+		_, _ = io.WriteString(w, manifest)
+	})
+
+	return server
+}
+
+var _ = Describe("GetRegistryImage", func() {
+	var (
+		server               *httptest.Server
+		requests             []string
+		registry             string
+		marketplace          *pkg.Marketplace
+		registryAuth         *pkgfakes.FakeRegistryAuthFunc
+		previousRegistryAuth pkg.RegistryAuthFunc
+	)
+
+	BeforeEach(func() {
+		requests = []string{}
+		server = fakeRegistry(map[string]string{
+			"public/db@1.0.0":        `{"schemaVersion": 2, "config": {"size": 10}, "layers": [{"size": 1000}]}`,
+			"public/db@multi":        `{"schemaVersion": 2, "manifests": [{"digest": "sha256:amd64"}, {"digest": "sha256:arm64"}]}`,
+			"public/db@sha256:amd64": `{"schemaVersion": 2, "config": {"size": 10}, "layers": [{"size": 1000}]}`,
+			"public/db@sha256:arm64": `{"schemaVersion": 2, "config": {"size": 20}, "layers": [{"size": 2000}]}`,
+			"private/db@1.0.0":       `{"schemaVersion": 2, "config": {"size": 10}, "layers": [{"size": 1000}]}`,
+			"broken/db@1.0.0":        `{"schemaVersion": 2, "config": {"size": 10}, "layers": [{"size": 1000}]}`,
+		}, &requests)
+		registry = strings.TrimPrefix(server.URL, "http://")
+
+		marketplace = &pkg.Marketplace{
+			Client: pkg.NewClient(io.Discard, false, false, false),
+		}
+
+		registryAuth = &pkgfakes.FakeRegistryAuthFunc{}
+		previousRegistryAuth = pkg.RegistryAuth
+		pkg.RegistryAuth = registryAuth.Spy
+	})
+
+	AfterEach(func() {
+		server.Close()
+		pkg.RegistryAuth = previousRegistryAuth
+	})
+
+	It("returns the digest and size of the image", func() {
+		image, err := marketplace.GetRegistryImage(registry+"/public/db", "1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(image.Digest).To(Equal("sha256:1.0.0-digest"))
+		Expect(image.Size).To(Equal(int64(1010)))
+		Expect(image.IsMultiArch).To(BeFalse())
+
+		By("checking the tag, and then getting its manifest by digest", func() {
+			Expect(requests).To(Equal([]string{"HEAD 1.0.0", "GET sha256:1.0.0-digest"}))
+		})
+
+		By("looking up the credentials for the registry", func() {
+			Expect(registryAuth.CallCount()).To(Equal(1))
+			Expect(registryAuth.ArgsForCall(0)).To(Equal(registry))
+		})
+	})
+
+	When("the image is multi-arch", func() {
+		It("only checks the tag, without fetching every platform manifest", func() {
+			image, err := marketplace.GetRegistryImage(registry+"/public/db", "multi")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(image.Digest).To(Equal("sha256:multi-digest"))
+			Expect(image.Size).To(BeZero())
+			Expect(image.IsMultiArch).To(BeTrue())
+			Expect(requests).To(Equal([]string{"HEAD multi"}))
+		})
+	})
+
+	When("the tag does not exist", func() {
+		It("returns an error", func() {
+			_, err := marketplace.GetRegistryImage(registry+"/public/db", "9.9.9")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(fmt.Sprintf("image public/db:9.9.9 was not found in %s", registry)))
+		})
+	})
+
+	When("the registry fails to give a token", func() {
+		It("returns an error", func() {
+			_, err := marketplace.GetRegistryImage(registry+"/broken/db", "1.0.0")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(fmt.Sprintf("failed to get registry token from %s: (400)\ninvalid token request", registry)))
+			Expect(requests).To(BeEmpty())
+		})
+	})
+
+	When("the image is private", func() {
+		It("uses the credentials from the Docker config", func() {
+			registryAuth.Returns("user", "secret", nil)
+			image, err := marketplace.GetRegistryImage(registry+"/private/db", "1.0.0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(image.Digest).To(Equal("sha256:1.0.0-digest"))
+		})
+
+		When("there are no credentials for the registry", func() {
+			It("returns an error", func() {
+				_, err := marketplace.GetRegistryImage(registry+"/private/db", "1.0.0")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal(fmt.Sprintf("not authorized to read image private/db:1.0.0 from %s, please check the credentials in the Docker config (docker login %s)", registry, registry)))
+			})
+		})
+	})
+})
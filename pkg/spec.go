@@ -0,0 +1,79 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// ProductSpec is a partial product definition, written in YAML or JSON.
+// It uses the same field names as the Marketplace API, so any field of a product can be set.
+type ProductSpec map[string]interface{}
+
+func LoadProductSpec(specPath string) (ProductSpec, error) {
+	contents, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read product spec %s: %w", specPath, err)
+	}
+
+	// Decoding into a plain map, otherwise nested objects would also become ProductSpecs
+	spec := map[string]interface{}{}
+	err = yaml.Unmarshal(contents, &spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse product spec %s: %w", specPath, err)
+	}
+	return spec, nil
+}
+
+func (s ProductSpec) Slug() string {
+	slug, _ := s["slug"].(string)
+	return slug
+}
+
+// ApplyTo returns a copy of the product with the fields from the spec applied.
+// Objects are merged recursively, while lists and values replace what is in the product.
+func (s ProductSpec) ApplyTo(product *models.Product) (*models.Product, error) {
+	productValue, err := toGenericJSON(product)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode product %s: %w", product.Slug, err)
+	}
+
+	productMap, ok := productValue.(map[string]interface{})
+	if !ok {
+		productMap = map[string]interface{}{}
+	}
+	mergeSpec(productMap, s)
+
+	encoded, err := json.Marshal(productMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the product spec: %w", err)
+	}
+
+	updated := &models.Product{}
+	d := json.NewDecoder(bytes.NewReader(encoded))
+	d.DisallowUnknownFields()
+	err = d.Decode(updated)
+	if err != nil {
+		return nil, fmt.Errorf("product spec is not valid: %w", err)
+	}
+	return updated, nil
+}
+
+func mergeSpec(target map[string]interface{}, spec map[string]interface{}) {
+	for key, value := range spec {
+		specObject, specIsObject := value.(map[string]interface{})
+		targetObject, targetIsObject := target[key].(map[string]interface{})
+		if specIsObject && targetIsObject {
+			mergeSpec(targetObject, specObject)
+		} else {
+			target[key] = value
+		}
+	}
+}
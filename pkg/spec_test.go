@@ -0,0 +1,105 @@
+// Copyright 2023 VMware, Inc.
+// SPDX-License-Identifier: BSD-2-Clause
+
+package pkg_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
+	"github.com/vmware-labs/marketplace-cli/v2/pkg"
+	"github.com/vmware-labs/marketplace-cli/v2/test"
+)
+
+var _ = Describe("ProductSpec", func() {
+	var specFile *os.File
+
+	BeforeEach(func() {
+		var err error
+		specFile, err = os.CreateTemp("", "mkpcli-test-spec-*.yaml")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.Remove(specFile.Name())).To(Succeed())
+	})
+
+	writeSpec := func(contents string) {
+		Expect(os.WriteFile(specFile.Name(), []byte(contents), 0600)).To(Succeed())
+	}
+
+	Describe("LoadProductSpec", func() {
+		It("loads a YAML spec", func() {
+			writeSpec("slug: my-super-product\ndisplayname: My Super Product\n")
+			spec, err := pkg.LoadProductSpec(specFile.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(spec.Slug()).To(Equal("my-super-product"))
+			Expect(spec["displayname"]).To(Equal("My Super Product"))
+		})
+
+		It("loads a JSON spec", func() {
+			writeSpec(`{"slug": "my-super-product", "tagsList": ["database"]}`)
+			spec, err := pkg.LoadProductSpec(specFile.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(spec.Slug()).To(Equal("my-super-product"))
+			Expect(spec["tagsList"]).To(Equal([]interface{}{"database"}))
+		})
+
+		When("the spec file does not exist", func() {
+			It("returns an error", func() {
+				_, err := pkg.LoadProductSpec("/this/path/does/not/exist.yaml")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("failed to read product spec /this/path/does/not/exist.yaml: open /this/path/does/not/exist.yaml: no such file or directory"))
+			})
+		})
+	})
+
+	Describe("ApplyTo", func() {
+		var product *models.Product
+
+		BeforeEach(func() {
+			product = test.CreateFakeProduct("", "My Super Product", "my-super-product", models.SolutionTypeChart)
+			product.Description = &models.Description{
+				Summary:     "The best product",
+				Description: "A product that does things",
+			}
+			product.Tags = []string{"old-tag"}
+		})
+
+		It("updates the fields in the spec", func() {
+			writeSpec("displayname: My Amazing Product\ndescription:\n  summary: The very best product\ntagsList:\n  - database\n")
+			spec, err := pkg.LoadProductSpec(specFile.Name())
+			Expect(err).ToNot(HaveOccurred())
+
+			updated, err := spec.ApplyTo(product)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated.DisplayName).To(Equal("My Amazing Product"))
+			Expect(updated.Description.Summary).To(Equal("The very best product"))
+			Expect(updated.Tags).To(Equal([]string{"database"}))
+
+			By("keeping the fields not in the spec", func() {
+				Expect(updated.ProductId).To(Equal(product.ProductId))
+				Expect(updated.Description.Description).To(Equal("A product that does things"))
+			})
+
+			By("not modifying the original product", func() {
+				Expect(product.DisplayName).To(Equal("My Super Product"))
+				Expect(product.Tags).To(Equal([]string{"old-tag"}))
+			})
+		})
+
+		When("the spec has an unknown field", func() {
+			It("returns an error", func() {
+				writeSpec("displayname: My Amazing Product\ndisplaynmae: typo\n")
+				spec, err := pkg.LoadProductSpec(specFile.Name())
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = spec.ApplyTo(product)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("product spec is not valid: json: unknown field \"displaynmae\""))
+			})
+		})
+	})
+})
@@ -4,9 +4,11 @@
 package pkg
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -53,18 +55,87 @@ func (m *Marketplace) GetUploadCredentials() (*CredentialsResponse, error) {
 	return credsResponse, nil
 }
 
-func (m *Marketplace) GetUploader(orgID string) (internal.Uploader, error) {
-	if m.uploader == nil {
-		credentials, err := m.GetUploadCredentials()
+// UploadCredentialsRefreshWindow is how long before they expire that upload credentials are replaced
+var UploadCredentialsRefreshWindow = 5 * time.Minute
+
+// uploadCredentialsProvider gets new upload credentials from the Marketplace whenever the current ones are about to expire
+type uploadCredentialsProvider struct {
+	marketplace *Marketplace
+	credentials *CredentialsResponse
+	lock        sync.Mutex
+}
+
+func (p *uploadCredentialsProvider) Retrieve(_ context.Context) (aws.Credentials, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.credentials == nil || p.expiresSoon() {
+		credentials, err := p.marketplace.GetUploadCredentials()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get upload credentials: %w", err)
+			return aws.Credentials{}, fmt.Errorf("failed to get upload credentials: %w", err)
 		}
-		client := internal.NewS3Client(m.StorageRegion, credentials.AWSCredentials())
-		return internal.NewS3Uploader(m.StorageBucket, m.StorageRegion, orgID, client, m.Output), nil
+		p.credentials = credentials
+	}
+
+	credentials := p.credentials.AWSCredentials()
+	if !credentials.Expires.IsZero() {
+		// Make sure that anything caching these credentials asks again before they are replaced
+		credentials.CanExpire = true
+		credentials.Expires = credentials.Expires.Add(-UploadCredentialsRefreshWindow)
+	}
+	return credentials, nil
+}
+
+func (p *uploadCredentialsProvider) expiresSoon() bool {
+	if p.credentials.Expiration.IsZero() {
+		return false
+	}
+	return time.Now().Add(UploadCredentialsRefreshWindow).After(p.credentials.Expiration)
+}
+
+// UploadCredentialsProvider returns the provider of upload credentials, which is shared by all uploads
+func (m *Marketplace) UploadCredentialsProvider() aws.CredentialsProvider {
+	if m.uploadCredentials == nil {
+		m.uploadCredentials = &uploadCredentialsProvider{marketplace: m}
+	}
+	return m.uploadCredentials
+}
+
+// GetUploader returns the uploader for the organization, making one the first time it is needed.
+// In dry-run mode, files are read but not uploaded.
+func (m *Marketplace) GetUploader(orgID string) (internal.Uploader, error) {
+	if m.uploader != nil {
+		return m.uploader, nil
+	}
+	if m.dryRun {
+		return internal.NewDryRunUploader(), nil
+	}
+	if uploader, ok := m.uploaders[orgID]; ok {
+		return uploader, nil
+	}
+
+	credentialsProvider := m.UploadCredentialsProvider()
+	_, err := credentialsProvider.Retrieve(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	client := internal.NewS3Client(m.StorageRegion, credentialsProvider)
+	uploader := internal.NewS3Uploader(m.StorageBucket, m.StorageRegion, orgID, client, m.Output)
+	if m.UploadPartSize > 0 {
+		uploader.SetPartSize(m.UploadPartSize)
+	}
+	if m.UploadConcurrency > 0 {
+		uploader.SetConcurrency(m.UploadConcurrency)
+	}
+
+	if m.uploaders == nil {
+		m.uploaders = map[string]internal.Uploader{}
 	}
-	return m.uploader, nil
+	m.uploaders[orgID] = uploader
+	return uploader, nil
 }
 
+// SetUploader sets the uploader to use for every organization
 func (m *Marketplace) SetUploader(uploader internal.Uploader) {
 	m.uploader = uploader
 }
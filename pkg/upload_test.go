@@ -4,8 +4,11 @@
 package pkg_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -116,6 +119,33 @@ var _ = Describe("Upload", func() {
 			Expect(uploader).ToNot(BeNil())
 		})
 
+		It("reuses the uploader for the same organization", func() {
+			uploader, err := marketplace.GetUploader("my-org")
+			Expect(err).ToNot(HaveOccurred())
+
+			sameUploader, err := marketplace.GetUploader("my-org")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sameUploader).To(BeIdenticalTo(uploader))
+
+			otherUploader, err := marketplace.GetUploader("other-org")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherUploader).ToNot(BeIdenticalTo(uploader))
+
+			By("sharing the upload credentials", func() {
+				Expect(httpClient.GetCallCount()).To(Equal(1))
+			})
+		})
+
+		When("in dry-run mode", func() {
+			It("returns an uploader that does not upload", func() {
+				marketplace.EnableDryRun()
+				uploader, err := marketplace.GetUploader("my-org")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(uploader).To(BeAssignableToTypeOf(&internal.DryRunUploader{}))
+				Expect(httpClient.GetCallCount()).To(Equal(0))
+			})
+		})
+
 		When("getting the credentials fails", func() {
 			BeforeEach(func() {
 				httpClient.GetReturns(nil, errors.New("get credentials failed"))
@@ -139,4 +169,68 @@ var _ = Describe("Upload", func() {
 			})
 		})
 	})
+
+	Describe("UploadCredentialsProvider", func() {
+		var expiration time.Time
+
+		BeforeEach(func() {
+			expiration = time.Now().Add(time.Hour)
+			httpClient.GetStub = func(requestURL *url.URL) (*http.Response, error) {
+				return test.MakeJSONResponse(&pkg.CredentialsResponse{
+					AccessID:     fmt.Sprintf("my-access-id-%d", httpClient.GetCallCount()),
+					AccessKey:    "my-access-key",
+					SessionToken: "my-session-token",
+					Expiration:   expiration,
+				}), nil
+			}
+		})
+
+		It("reuses the credentials until they are about to expire", func() {
+			provider := marketplace.UploadCredentialsProvider()
+			credentials, err := provider.Retrieve(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credentials.AccessKeyID).To(Equal("my-access-id-1"))
+			Expect(credentials.CanExpire).To(BeTrue())
+			Expect(credentials.Expires).To(BeTemporally("~", expiration.Add(-pkg.UploadCredentialsRefreshWindow)))
+
+			credentials, err = provider.Retrieve(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credentials.AccessKeyID).To(Equal("my-access-id-1"))
+			Expect(httpClient.GetCallCount()).To(Equal(1))
+		})
+
+		It("is shared by all uploads", func() {
+			Expect(marketplace.UploadCredentialsProvider()).To(BeIdenticalTo(marketplace.UploadCredentialsProvider()))
+		})
+
+		When("the credentials are about to expire", func() {
+			BeforeEach(func() {
+				expiration = time.Now().Add(pkg.UploadCredentialsRefreshWindow / 2)
+			})
+
+			It("gets new credentials", func() {
+				provider := marketplace.UploadCredentialsProvider()
+				_, err := provider.Retrieve(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+
+				credentials, err := provider.Retrieve(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(credentials.AccessKeyID).To(Equal("my-access-id-2"))
+				Expect(httpClient.GetCallCount()).To(Equal(2))
+			})
+		})
+
+		When("getting new credentials fails", func() {
+			BeforeEach(func() {
+				httpClient.GetStub = nil
+				httpClient.GetReturns(nil, errors.New("get credentials failed"))
+			})
+
+			It("returns an error", func() {
+				_, err := marketplace.UploadCredentialsProvider().Retrieve(context.Background())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("failed to get upload credentials: get credentials failed"))
+			})
+		})
+	})
 })
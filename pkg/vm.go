@@ -16,33 +16,51 @@ func makeUniqueFileID() string {
 }
 
 func (m *Marketplace) UploadVM(vmFile string, product *models.Product, version *models.Version) (*models.Product, error) {
-	hashString, err := Hash(vmFile, models.HashAlgoSHA1)
+	uploader, err := m.GetUploader(product.PublisherDetails.OrgId)
 	if err != nil {
 		return nil, err
 	}
-
-	uploader, err := m.GetUploader(product.PublisherDetails.OrgId)
+	filename, fileUrl, hashString, err := uploadAndHash(vmFile, m.hashAlgorithm(), uploader.UploadProductFileWithHash)
 	if err != nil {
 		return nil, err
 	}
-	filename, fileUrl, err := uploader.UploadProductFile(vmFile)
+
+	product.PrepForUpdate()
+	product.ProductDeploymentFiles = append(product.ProductDeploymentFiles, &models.ProductDeploymentFile{
+		Name:          filename,
+		AppVersion:    version.Number,
+		Url:           fileUrl,
+		HashAlgo:      m.hashAlgorithm(),
+		HashDigest:    hashString,
+		IsRedirectUrl: false,
+		UniqueFileID:  makeUniqueFileID(),
+		VersionList:   []string{},
+	})
+
+	return m.PutProduct(product, version.IsNewVersion)
+}
+
+// AttachExternalVM attaches a virtual machine file that stays hosted at the given URL, instead of uploading it to the Marketplace
+func (m *Marketplace) AttachExternalVM(fileURL string, fetch bool, product *models.Product, version *models.Version) (*models.Product, error) {
+	file, err := m.getExternalFile(fileURL, fetch)
 	if err != nil {
 		return nil, err
 	}
 
 	product.PrepForUpdate()
-	product.ProductDeploymentFiles = []*models.ProductDeploymentFile{
-		{
-			Name:          filename,
-			AppVersion:    version.Number,
-			Url:           fileUrl,
-			HashAlgo:      models.HashAlgoSHA1,
-			HashDigest:    hashString,
-			IsRedirectUrl: false,
-			UniqueFileID:  makeUniqueFileID(),
-			VersionList:   []string{},
-		},
-	}
+	product.ProductDeploymentFiles = append(product.ProductDeploymentFiles, &models.ProductDeploymentFile{
+		Name:            file.Name,
+		AppVersion:      version.Number,
+		Url:             file.URL,
+		HashAlgo:        file.HashAlgorithm,
+		HashDigest:      file.HashDigest,
+		Size:            file.Size,
+		IsThirdPartyUrl: true,
+		ThirdPartyUrl:   file.URL,
+		IsRedirectUrl:   true,
+		UniqueFileID:    makeUniqueFileID(),
+		VersionList:     []string{},
+	})
 
 	return m.PutProduct(product, version.IsNewVersion)
 }
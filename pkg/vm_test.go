@@ -5,10 +5,13 @@ package pkg_test
 
 import (
 	"errors"
+	"hash"
+	"net/http"
 	"os"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
 	"github.com/spf13/viper"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/internalfakes"
 	"github.com/vmware-labs/marketplace-cli/v2/internal/models"
@@ -42,7 +45,10 @@ var _ = Describe("VM", func() {
 			vmFile, err := os.CreateTemp("", "mkpcli-uploadvm-test-vm.iso")
 			Expect(err).ToNot(HaveOccurred())
 			vmFilePath = vmFile.Name()
-			uploader.UploadProductFileReturns("uploaded-file.iso", "https://example.com/uploaded-file.iso", err)
+			uploader.UploadProductFileWithHashStub = func(filePath string, hasher hash.Hash) (string, string, error) {
+				_, _ = hasher.Write([]byte("vm contents"))
+				return "uploaded-file.iso", "https://example.com/uploaded-file.iso", nil
+			}
 
 			httpClient.PutStub = PutProductEchoResponse
 		})
@@ -59,8 +65,8 @@ var _ = Describe("VM", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			By("uploading the file", func() {
-				Expect(uploader.UploadProductFileCallCount()).To(Equal(1))
-				uploadedFilePath := uploader.UploadProductFileArgsForCall(0)
+				Expect(uploader.UploadProductFileWithHashCallCount()).To(Equal(1))
+				uploadedFilePath, _ := uploader.UploadProductFileWithHashArgsForCall(0)
 				Expect(uploadedFilePath).To(Equal(vmFilePath))
 			})
 
@@ -70,21 +76,65 @@ var _ = Describe("VM", func() {
 				Expect(uploadedFile.Name).To(Equal("uploaded-file.iso"))
 				Expect(uploadedFile.AppVersion).To(Equal("1.2.3"))
 				Expect(uploadedFile.Url).To(Equal("https://example.com/uploaded-file.iso"))
-				Expect(uploadedFile.HashAlgo).To(Equal("SHA1"))
-				Expect(uploadedFile.HashDigest).To(Equal("da39a3ee5e6b4b0d3255bfef95601890afd80709"))
+				Expect(uploadedFile.HashAlgo).To(Equal("SHA256"))
+				Expect(uploadedFile.HashDigest).To(Equal("fc747eb725b5c352fbeebc1fd91a78a398a773c9c19b8c6380c6d6c6f05d5baa"))
 				Expect(uploadedFile.IsRedirectUrl).To(BeFalse())
 				Expect(uploadedFile.UniqueFileID).To(MatchRegexp("fileuploader[0-9]+.url"))
 			})
 		})
 
-		When("hashing fails", func() {
+		When("the product already has vm files", func() {
+			It("keeps them, except for a file that was removed to replace it", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeISO)
+				test.AddVersions(product, "1.2.2", "1.2.3")
+				product.ProductDeploymentFiles = []*models.ProductDeploymentFile{
+					test.CreateFakeOVA("uploaded-file.iso", "1.2.3"),
+					test.CreateFakeOVA("other-file.iso", "1.2.3"),
+					test.CreateFakeOVA("uploaded-file.iso", "1.2.2"),
+				}
+				product.RemoveFile("1.2.3", "uploaded-file.iso")
+
+				sentProduct, err := marketplace.UploadVM(vmFilePath, product, &models.Version{Number: "1.2.3"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(httpClient.PutCallCount()).To(Equal(1))
+				Expect(sentProduct.ProductDeploymentFiles).To(HaveLen(3))
+				Expect(sentProduct.ProductDeploymentFiles[0].Name).To(Equal("other-file.iso"))
+				Expect(sentProduct.ProductDeploymentFiles[1].Name).To(Equal("uploaded-file.iso"))
+				Expect(sentProduct.ProductDeploymentFiles[1].AppVersion).To(Equal("1.2.2"))
+				Expect(sentProduct.ProductDeploymentFiles[2].Name).To(Equal("uploaded-file.iso"))
+				Expect(sentProduct.ProductDeploymentFiles[2].AppVersion).To(Equal("1.2.3"))
+				Expect(sentProduct.ProductDeploymentFiles[2].Url).To(Equal("https://example.com/uploaded-file.iso"))
+			})
+		})
+
+		When("using a different hash algorithm", func() {
+			BeforeEach(func() {
+				marketplace.HashAlgorithm = "sha1"
+			})
+			It("hashes the file with that algorithm", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeISO)
+				test.AddVersions(product, "1.2.3")
+
+				updatedProduct, err := marketplace.UploadVM(vmFilePath, product, &models.Version{Number: "1.2.3"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedProduct.ProductDeploymentFiles[0].HashAlgo).To(Equal("SHA1"))
+				Expect(updatedProduct.ProductDeploymentFiles[0].HashDigest).To(Equal("5790a5036e82bf755dce21fed6dc1e832567b08f"))
+			})
+		})
+
+		When("the hash algorithm is not supported", func() {
+			BeforeEach(func() {
+				marketplace.HashAlgorithm = "md5"
+			})
 			It("returns an error", func() {
 				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeISO)
 				test.AddVersions(product, "1.2.3")
 
-				_, err := marketplace.UploadVM("this/file/does/not/exist", product, &models.Version{Number: "1.2.3"})
+				_, err := marketplace.UploadVM(vmFilePath, product, &models.Version{Number: "1.2.3"})
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("failed to open this/file/does/not/exist: open this/file/does/not/exist: no such file or directory"))
+				Expect(err.Error()).To(Equal("unsupported hash algorithm: \"MD5\""))
+				Expect(uploader.UploadProductFileWithHashCallCount()).To(Equal(0))
 			})
 		})
 
@@ -106,7 +156,8 @@ var _ = Describe("VM", func() {
 
 		When("uploading the VM image fails", func() {
 			BeforeEach(func() {
-				uploader.UploadProductFileReturns("", "", errors.New("upload product file failed"))
+				uploader.UploadProductFileWithHashStub = nil
+				uploader.UploadProductFileWithHashReturns("", "", errors.New("upload product file failed"))
 			})
 			It("returns an error", func() {
 				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeISO)
@@ -132,4 +183,84 @@ var _ = Describe("VM", func() {
 			})
 		})
 	})
+
+	Describe("AttachExternalVM", func() {
+		BeforeEach(func() {
+			marketplace.Output = NewBuffer()
+			httpClient.DoReturns(test.MakeStringResponse("vm contents"), nil)
+			httpClient.PutStub = PutProductEchoResponse
+		})
+
+		It("attaches the vm file by its URL", func() {
+			product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeOVA)
+			test.AddVersions(product, "1.2.3")
+
+			updatedProduct, err := marketplace.AttachExternalVM("https://downloads.example.com/vms/hyperspace-db.ova", false, product, &models.Version{Number: "1.2.3"})
+			Expect(err).ToNot(HaveOccurred())
+
+			By("not uploading or fetching the file", func() {
+				Expect(uploader.UploadProductFileCallCount()).To(Equal(0))
+				Expect(httpClient.DoCallCount()).To(Equal(0))
+			})
+
+			By("updating the product in the marketplace", func() {
+				Expect(updatedProduct.ProductDeploymentFiles).To(HaveLen(1))
+				file := updatedProduct.ProductDeploymentFiles[0]
+				Expect(file.Name).To(Equal("hyperspace-db.ova"))
+				Expect(file.AppVersion).To(Equal("1.2.3"))
+				Expect(file.Url).To(Equal("https://downloads.example.com/vms/hyperspace-db.ova"))
+				Expect(file.IsThirdPartyUrl).To(BeTrue())
+				Expect(file.ThirdPartyUrl).To(Equal("https://downloads.example.com/vms/hyperspace-db.ova"))
+				Expect(file.IsRedirectUrl).To(BeTrue())
+				Expect(file.HashDigest).To(BeEmpty())
+			})
+		})
+
+		When("fetching the file", func() {
+			It("records the size and hash of the file", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeOVA)
+				test.AddVersions(product, "1.2.3")
+
+				updatedProduct, err := marketplace.AttachExternalVM("https://downloads.example.com/vms/hyperspace-db.ova", true, product, &models.Version{Number: "1.2.3"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(httpClient.DoCallCount()).To(Equal(1))
+				Expect(httpClient.DoArgsForCall(0).URL.String()).To(Equal("https://downloads.example.com/vms/hyperspace-db.ova"))
+
+				file := updatedProduct.ProductDeploymentFiles[0]
+				Expect(file.Size).To(Equal(int64(11)))
+				Expect(file.HashAlgo).To(Equal("SHA256"))
+				Expect(file.HashDigest).To(Equal("fc747eb725b5c352fbeebc1fd91a78a398a773c9c19b8c6380c6d6c6f05d5baa"))
+			})
+
+			When("the file cannot be fetched", func() {
+				BeforeEach(func() {
+					response := test.MakeStringResponse("not found")
+					response.StatusCode = http.StatusNotFound
+					response.Status = "404 Not Found"
+					httpClient.DoReturns(response, nil)
+				})
+				It("returns an error", func() {
+					product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeOVA)
+					test.AddVersions(product, "1.2.3")
+
+					_, err := marketplace.AttachExternalVM("https://downloads.example.com/vms/hyperspace-db.ova", true, product, &models.Version{Number: "1.2.3"})
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("failed to fetch https://downloads.example.com/vms/hyperspace-db.ova: 404 Not Found"))
+					Expect(httpClient.PutCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		When("the URL is not valid", func() {
+			It("returns an error", func() {
+				product := test.CreateFakeProduct("", "Hyperspace Database", "hyperspace-database", models.SolutionTypeOVA)
+				test.AddVersions(product, "1.2.3")
+
+				_, err := marketplace.AttachExternalVM("hyperspace-db.ova", false, product, &models.Version{Number: "1.2.3"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("invalid file URL: hyperspace-db.ova"))
+			})
+		})
+	})
 })
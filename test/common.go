@@ -149,6 +149,23 @@ func CreateFakeMetaFile(name, version, productVersion string) *models.MetaFile {
 	}
 }
 
+func CreateFakeBlueprint(name, version string) *models.ProductBlueprintDetails {
+	return &models.ProductBlueprintDetails{
+		Version:      version,
+		Instructions: "Import the blueprint",
+		BlueprintFiles: []models.BlueprintFile{
+			{
+				ID:         uuid.New().String(),
+				Title:      name,
+				URL:        "https://marketplace.example.com/product-files/" + name,
+				Status:     models.DeploymentStatusActive,
+				VRAVersion: "8.11",
+			},
+		},
+		Prerequisites: []string{"vRealize Automation 8.11"},
+	}
+}
+
 func AddVersions(product *models.Product, versions ...string) *models.Product {
 	for _, version := range versions {
 		versionObject := &models.Version{
@@ -20,14 +20,14 @@ var _ = Describe("authentication", func() {
 
 	Scenario("Authentication works", func() {
 		steps.Given("targeting the production environment")
-		steps.When("running mkpcli auth")
+		steps.When("running mkpcli auth token")
 		steps.Then("the command exits without error")
 		steps.And("the token is printed")
 	})
 
 	Scenario("Expired token", func() {
 		steps.Given("targeting the production environment")
-		steps.When(fmt.Sprintf("running mkpcli --csp-api-token %s auth", ExpiredToken))
+		steps.When(fmt.Sprintf("running mkpcli --csp-api-token %s auth token", ExpiredToken))
 		steps.Then("the command exits with an error")
 		steps.And("the expired token error message is printed")
 	})